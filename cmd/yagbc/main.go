@@ -1,13 +1,84 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"os"
+	"time"
 
+	"github.com/antoniosarro/yagbc/internal/core/gb"
 	"github.com/antoniosarro/yagbc/internal/core/gb/memory"
 	"github.com/antoniosarro/yagbc/internal/core/gb/processor"
 )
 
+var (
+	explain      = flag.Bool("explain", false, "explain every executed instruction")
+	speed        = flag.Float64("speed", 0, "instructions per second in explain mode (0 = unthrottled)")
+	tutorial     = flag.Bool("tutorial", false, "step through the guided tutorial lessons")
+	frames       = flag.Int("frames", 0, "frames to run a ROM for (0 = until the CPU locks up)")
+	serialStdout = flag.Bool("serial-stdout", false, "print the bytes a ROM sends over the serial port")
+	watch        = flag.Bool("watch", false, "restart the ROM whenever its file changes")
+	keepRAM      = flag.Bool("keep-ram", false, "with --watch, keep the cartridge RAM (save data) across restarts")
+
+	// initValues collects the --init overrides.
+	initValues []gb.InitValue
+)
+
+func init() {
+	flag.Func("init", "override initial register values for a ROM, e.g. A=0x11,DIV=0xAB", func(spec string) error {
+		values, err := gb.ParseInitValues(spec)
+		initValues = append(initValues, values...)
+		return err
+	})
+}
+
+// parseCommandLine parses the command-line arguments (without the
+// program name) into the flags. A leading "run" subcommand is
+// accepted and skipped, with its own flags parsed after it, so
+// "yagbc run --watch game.gb" means "yagbc --watch game.gb".
+func parseCommandLine(args []string) error {
+	if err := flag.CommandLine.Parse(args); err != nil {
+		return err
+	}
+	if flag.Arg(0) == "run" {
+		return flag.CommandLine.Parse(flag.Args()[1:])
+	}
+	return nil
+}
+
+// Usage:
+//
+//	yagbc [--explain [--speed n]]             instruction showcase
+//	yagbc --tutorial                          guided tutorial
+//	yagbc [run] [--frames n] [--serial-stdout] [--watch [--keep-ram]] [--init regs] rom
+//	                                          run a ROM file
 func main() {
+	parseCommandLine(os.Args[1:]) // Exits on errors (flag.ExitOnError)
+
+	if flag.NArg() > 0 {
+		cfg := romConfig{frames: *frames, init: initValues, log: os.Stderr, keepRAM: *keepRAM}
+		if *serialStdout {
+			cfg.serialOut = os.Stdout
+		}
+		run := func() error { return runROM(flag.Arg(0), cfg) }
+		if *watch {
+			run = func() error { return watchROM(flag.Arg(0), cfg, nil) }
+		}
+		if err := run(); err != nil {
+			fmt.Fprintln(os.Stderr, "yagbc:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *tutorial {
+		if err := runTutorial(os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, "yagbc:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	fmt.Println("╔════════════════════════════════════════════╗")
 	fmt.Println("║   Game Boy CPU - Instruction Showcase     ║")
 	fmt.Println("╚════════════════════════════════════════════╝")
@@ -20,6 +91,25 @@ func main() {
 	testFlagBehavior()
 }
 
+// newCPU creates a CPU connected to mem, set up to explain every
+// instruction it executes if --explain was given.
+func newCPU(mem *memory.BasicMemory) *processor.CPU {
+	cpu := processor.NewCPU(mem)
+	if !*explain {
+		return cpu
+	}
+
+	var delay time.Duration
+	if *speed > 0 {
+		delay = time.Duration(float64(time.Second) / *speed)
+	}
+	cpu.OnExplain = func(cpu *processor.CPU, e *processor.Explanation) {
+		fmt.Print(e)
+		time.Sleep(delay)
+	}
+	return cpu
+}
+
 // Test 1: Basic arithmetic
 func testBasicArithmetic() {
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
@@ -27,7 +117,7 @@ func testBasicArithmetic() {
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
 	mem := memory.NewBasicMemory()
-	cpu := processor.NewCPU(mem)
+	cpu := newCPU(mem)
 
 	// Program: 10 + 20 = 30
 	program := []byte{
@@ -62,7 +152,7 @@ func testRegisterCopying() {
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
 	mem := memory.NewBasicMemory()
-	cpu := processor.NewCPU(mem)
+	cpu := newCPU(mem)
 
 	// Program: Load values into B and C, then copy them to A
 	program := []byte{
@@ -107,7 +197,7 @@ func testJumpInstruction() {
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
 	mem := memory.NewBasicMemory()
-	cpu := processor.NewCPU(mem)
+	cpu := newCPU(mem)
 
 	// Program with a jump
 	program := []byte{
@@ -153,7 +243,7 @@ func testFlagBehavior() {
 	fmt.Println("Test 4a: Zero Flag")
 	fmt.Println("  0 + 0 should set Z flag")
 	mem := memory.NewBasicMemory()
-	cpu := processor.NewCPU(mem)
+	cpu := newCPU(mem)
 	program := []byte{
 		0x3E, 0x00, // LD A, 0
 		0x06, 0x00, // LD B, 0
@@ -182,7 +272,7 @@ func testFlagBehavior() {
 	fmt.Println("Test 4b: Half-Carry Flag")
 	fmt.Println("  0x0F + 0x01 = 0x10 (carry from bit 3 to 4)")
 	mem = memory.NewBasicMemory()
-	cpu = processor.NewCPU(mem)
+	cpu = newCPU(mem)
 	program = []byte{
 		0x3E, 0x0F, // LD A, 0x0F
 		0x06, 0x01, // LD B, 0x01
@@ -211,7 +301,7 @@ func testFlagBehavior() {
 	fmt.Println("Test 4c: Carry Flag")
 	fmt.Println("  0xFF + 0x01 = 0x00 (carry from bit 7)")
 	mem = memory.NewBasicMemory()
-	cpu = processor.NewCPU(mem)
+	cpu = newCPU(mem)
 	program = []byte{
 		0x3E, 0xFF, // LD A, 0xFF
 		0x06, 0x01, // LD B, 0x01
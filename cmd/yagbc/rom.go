@@ -0,0 +1,168 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/antoniosarro/yagbc/internal/core/gb"
+	"github.com/antoniosarro/yagbc/internal/core/gb/cartridge"
+	"github.com/antoniosarro/yagbc/internal/core/gb/processor"
+)
+
+// watchPoll is how often watchROM checks an idle ROM file for changes.
+var watchPoll = 250 * time.Millisecond
+
+// romConfig sets up a ROM run.
+type romConfig struct {
+	frames    int            // Frames to run for (0 = until the CPU locks up)
+	init      []gb.InitValue // Overrides of the boot ROM's register values
+	serialOut io.Writer      // Receives serial output as it is sent, if not nil
+	log       io.Writer      // Warnings and status lines
+	keepRAM   bool           // Carry cartridge RAM across watchROM reloads
+}
+
+// runROM runs the cartridge in the ROM file at path, starting where
+// the boot ROM hands over, as set up by cfg.
+func runROM(path string, cfg romConfig) error {
+	gameboy, err := loadROM(path, cfg)
+	if err != nil {
+		return err
+	}
+
+	for frame := 0; cfg.frames == 0 || frame < cfg.frames; frame++ {
+		if _, err := gameboy.CPU.RunFrame(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// watchROM runs the ROM file at path like runROM, but restarts it
+// from a fresh machine every time the file changes, e.g. after each
+// rebuild. A run that ends (after cfg.frames frames, or on a
+// lock-up) waits for the next change instead of exiting, and so does
+// a file that fails to load, which is usually a build still being
+// written. watchROM returns when stop is closed.
+//
+// With cfg.keepRAM, the cartridge RAM (the game's save data) carries
+// over into the reloaded ROM, so a rebuild can be tested against an
+// existing save. The rest of the machine always starts afresh: a CPU
+// snapshot would resume in the middle of code that has moved.
+func watchROM(path string, cfg romConfig, stop <-chan struct{}) error {
+	w, err := newROMWatcher(path)
+	if err != nil {
+		return err
+	}
+
+	var ram []byte // Cartridge RAM of the last run, for cfg.keepRAM
+	for {
+		gameboy, err := loadROM(path, cfg)
+		if err != nil {
+			fmt.Fprintf(cfg.log, "yagbc: %v, waiting for a rebuild\n", err)
+		} else {
+			fmt.Fprintf(cfg.log, "yagbc: running %s\n", path)
+			if c, ok := gameboy.Memory.Cartridge().(cartridge.RAMBacked); ok && cfg.keepRAM && ram != nil {
+				c.LoadRAM(ram)
+			}
+		}
+
+		// Run until the run ends or the ROM changes under it. Frames
+		// run unthrottled, so the file is only checked every watchPoll
+		changed := false
+		nextCheck := time.Now().Add(watchPoll)
+		for frame := 0; gameboy != nil && (cfg.frames == 0 || frame < cfg.frames); frame++ {
+			if _, err := gameboy.CPU.RunFrame(); err != nil {
+				fmt.Fprintf(cfg.log, "yagbc: %v, waiting for a rebuild\n", err)
+				break
+			}
+			if now := time.Now(); now.After(nextCheck) {
+				nextCheck = now.Add(watchPoll)
+				if changed = w.changed(); changed {
+					break
+				}
+			}
+			select {
+			case <-stop:
+				return nil
+			default:
+			}
+		}
+
+		if gameboy != nil {
+			if c, ok := gameboy.Memory.Cartridge().(cartridge.RAMBacked); ok {
+				ram = c.RAM()
+			}
+		}
+
+		for !changed {
+			select {
+			case <-stop:
+				return nil
+			case <-time.After(watchPoll):
+				changed = w.changed()
+			}
+		}
+	}
+}
+
+// loadROM loads the ROM file at path into a Game Boy, in the state
+// the DMG boot ROM leaves it (with cfg.init applied on top), and
+// with the serial port sending to cfg.serialOut. Checksum mismatches
+// are only warned about on cfg.log: the ROM may be a work in
+// progress, and runs anyway. Debug traps in the code (see
+// processor.CPU.OnBreakpoint) are logged as well.
+func loadROM(path string, cfg romConfig) (*gb.GameBoy, error) {
+	cart, err := cartridge.LoadFile(path)
+	if errors.Is(err, cartridge.ErrChecksum) && cart != nil {
+		for _, line := range strings.Split(err.Error(), "\n") {
+			fmt.Fprintf(cfg.log, "yagbc: warning: %s\n", line)
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	gameboy := gb.NewGameBoy()
+	gameboy.Memory.InsertCartridge(cart)
+	gameboy.CPU.Reset(processor.ModelDMG)
+	gameboy.ApplyInitValues(cfg.init)
+	gameboy.Serial.Output = cfg.serialOut
+
+	// Homebrew debug traps report to the log
+	gameboy.CPU.OnBreakpoint = func(cpu *processor.CPU) {
+		fmt.Fprintf(cfg.log, "yagbc: breakpoint (LD B, B) at 0x%04X\n", cpu.Registers.PC-1)
+	}
+	gameboy.CPU.OnDebugMessage = func(cpu *processor.CPU, msg string) {
+		fmt.Fprintf(cfg.log, "yagbc: debug: %s\n", msg)
+	}
+	return gameboy, nil
+}
+
+// romWatcher detects changes to a file by its modification time.
+type romWatcher struct {
+	path    string
+	modTime time.Time
+}
+
+func newROMWatcher(path string) (*romWatcher, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	return &romWatcher{path: path, modTime: info.ModTime()}, nil
+}
+
+// changed reports whether the file was modified since the last call.
+// A file that is missing, e.g. while a build replaces it, has not
+// changed yet.
+func (w *romWatcher) changed() bool {
+	info, err := os.Stat(w.path)
+	if err != nil || info.ModTime().Equal(w.modTime) {
+		return false
+	}
+	w.modTime = info.ModTime()
+	return true
+}
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/antoniosarro/yagbc/internal/core/gb"
+	"github.com/antoniosarro/yagbc/internal/core/gb/processor"
+)
+
+// writeROM saves a 32KB ROM-only image with code at the 0x0100
+// entry point, and returns its path.
+func writeROM(t *testing.T, code ...byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.gb")
+	rewriteROM(t, path, code...)
+	return path
+}
+
+// rewriteROM replaces the ROM image at path with a new one.
+func rewriteROM(t *testing.T, path string, code ...byte) {
+	t.Helper()
+	rom := make([]byte, 0x8000)
+	copy(rom[0x0100:], code)
+	if err := os.WriteFile(path, rom, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// sendByte is a program that sends b over the serial port and halts.
+func sendByte(b byte) []byte {
+	return []byte{
+		0x3E, b, // LD A, b
+		0xE0, 0x01, // LDH (SB), A
+		0x3E, 0x81, // LD A, 0x81
+		0xE0, 0x02, // LDH (SC), A: send
+		0x76, // HALT
+	}
+}
+
+func TestRunROMSerial(t *testing.T) {
+	path := writeROM(t,
+		0x3E, 'H', // LD A, 'H'
+		0xE0, 0x01, // LDH (SB), A
+		0x3E, 0x81, // LD A, 0x81
+		0xE0, 0x02, // LDH (SC), A: send
+		0x3E, 'i', // LD A, 'i'
+		0xE0, 0x01, // LDH (SB), A
+		0x3E, 0x81, // LD A, 0x81
+		0xE0, 0x02, // LDH (SC), A: send
+		0x76, // HALT
+	)
+
+	var out strings.Builder
+	if err := runROM(path, romConfig{frames: 1, serialOut: &out, log: io.Discard}); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "Hi" {
+		t.Errorf("Expected serial output %q, got %q", "Hi", out.String())
+	}
+}
+
+func TestRunROMLocks(t *testing.T) {
+	path := writeROM(t, 0xD3) // Illegal opcode
+
+	// Runs until the lock-up, even with no frame limit
+	err := runROM(path, romConfig{log: io.Discard})
+	if !errors.Is(err, processor.ErrLocked) {
+		t.Errorf("Expected the lock-up to end the run, got %v", err)
+	}
+}
+
+func TestWatchROMKeepRAM(t *testing.T) {
+	defer func(poll time.Duration) { watchPoll = poll }(watchPoll)
+	watchPoll = time.Millisecond
+
+	// Program: send the byte at 0xA000 over the serial port, then
+	// store it plus one (MBC1+RAM+BATTERY, 8KB of RAM)
+	code := []byte{
+		0x21, 0x00, 0x00, // LD HL, 0x0000
+		0x3E, 0x0A, // LD A, 0x0A
+		0x22,             // LD (HL+), A: enable RAM
+		0x21, 0x00, 0xA0, // LD HL, 0xA000
+		0x2A,       // LD A, (HL+)
+		0xE0, 0x01, // LDH (SB), A
+		0x3C,             // INC A
+		0x21, 0x00, 0xA0, // LD HL, 0xA000
+		0x22,       // LD (HL+), A
+		0x3E, 0x81, // LD A, 0x81
+		0xE0, 0x02, // LDH (SC), A: send
+		0x76, // HALT
+	}
+	writeMBC1 := func(path string) {
+		rom := make([]byte, 0x8000)
+		copy(rom[0x0100:], code)
+		rom[0x0147], rom[0x0149] = 0x03, 0x02
+		if err := os.WriteFile(path, rom, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	path := filepath.Join(t.TempDir(), "save.gb")
+	writeMBC1(path)
+
+	var out syncBuffer
+	stop := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- watchROM(path, romConfig{frames: 1, serialOut: &out, log: io.Discard, keepRAM: true}, stop)
+	}()
+	waitFor(t, &out, "\xFF") // Fresh RAM
+
+	later := time.Now().Add(time.Minute)
+	writeMBC1(path)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, &out, "\xFF\x00") // The first run's write survived
+
+	close(stop)
+	if err := <-done; err != nil {
+		t.Errorf("Expected watchROM to stop cleanly, got %v", err)
+	}
+}
+
+func TestRunROMChecksumWarning(t *testing.T) {
+	// writeROM leaves both checksums 0
+	path := writeROM(t, 0x76) // HALT
+
+	var log strings.Builder
+	if err := runROM(path, romConfig{frames: 1, log: &log}); err != nil {
+		t.Fatalf("Expected the ROM to run despite its checksums, got %v", err)
+	}
+	want := "yagbc: warning: cartridge: header checksum mismatch: header says 0x00, ROM gives 0xE7\n" +
+		"yagbc: warning: cartridge: global checksum mismatch: header says 0x0000, ROM gives 0x0076\n"
+	if log.String() != want {
+		t.Errorf("Expected warnings %q, got %q", want, log.String())
+	}
+}
+
+func TestRunROMDebugTraps(t *testing.T) {
+	path := writeROM(t,
+		0x40,             // 0x0100: LD B, B
+		0x52, 0x18, 0x06, // 0x0101: LD D, D; JR .end
+		0x64, 0x64, 0x00, 0x00, 'o', 'k', // DW $6464, $0000; DB "ok"
+		0x76, // .end: HALT
+	)
+
+	var log strings.Builder
+	if err := runROM(path, romConfig{frames: 1, log: &log}); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"yagbc: breakpoint (LD B, B) at 0x0100\n", "yagbc: debug: ok\n"} {
+		if !strings.Contains(log.String(), want) {
+			t.Errorf("Expected %q in the log, got %q", want, log.String())
+		}
+	}
+}
+
+func TestRunROMInit(t *testing.T) {
+	// Program: send A and DIV over the serial port
+	path := writeROM(t,
+		0xE0, 0x01, // LDH (SB), A
+		0x3E, 0x81, // LD A, 0x81
+		0xE0, 0x02, // LDH (SC), A: send
+		0xF0, 0x04, // LDH A, (DIV)
+		0xE0, 0x01, // LDH (SB), A
+		0x3E, 0x81, // LD A, 0x81
+		0xE0, 0x02, // LDH (SC), A: send
+		0x76, // HALT
+	)
+	init, err := gb.ParseInitValues("A=0x11,DIV=0x42")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	if err := runROM(path, romConfig{frames: 1, init: init, serialOut: &out, log: io.Discard}); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "\x11\x42" {
+		t.Errorf("Expected the overridden A and DIV, got %q", out.String())
+	}
+}
+
+func TestRunROMBadFile(t *testing.T) {
+	if err := runROM(filepath.Join(t.TempDir(), "missing.gb"), romConfig{frames: 1, log: io.Discard}); err == nil {
+		t.Error("Expected an error for a missing ROM file")
+	}
+}
+
+// syncBuffer is a strings.Builder safe to read while watchROM writes.
+type syncBuffer struct {
+	mu sync.Mutex
+	sb strings.Builder
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.sb.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.sb.String()
+}
+
+// waitFor polls until the buffer holds want, or fails the test.
+func waitFor(t *testing.T, b *syncBuffer, want string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for b.String() != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected serial output %q, got %q", want, b.String())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestWatchROM(t *testing.T) {
+	defer func(poll time.Duration) { watchPoll = poll }(watchPoll)
+	watchPoll = time.Millisecond
+
+	path := writeROM(t, sendByte('A')...)
+	var out syncBuffer
+	stop := make(chan struct{})
+	done := make(chan error)
+	go func() { done <- watchROM(path, romConfig{frames: 1, serialOut: &out, log: io.Discard}, stop) }()
+	waitFor(t, &out, "A")
+
+	// A rebuild restarts the ROM; bump the time in case the file
+	// system's timestamps are too coarse to tell the writes apart
+	later := time.Now().Add(time.Minute)
+	rewriteROM(t, path, sendByte('B')...)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, &out, "AB")
+
+	// A broken build waits for the next one
+	later = later.Add(time.Minute)
+	if err := os.WriteFile(path, []byte("not a ROM"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	later = later.Add(time.Minute)
+	time.Sleep(10 * time.Millisecond)
+	rewriteROM(t, path, sendByte('C')...)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, &out, "ABC")
+
+	close(stop)
+	if err := <-done; err != nil {
+		t.Errorf("Expected watchROM to stop cleanly, got %v", err)
+	}
+}
+
+func TestParseCommandLineRun(t *testing.T) {
+	defer func(w bool, n int) { *watch, *frames = w, n }(*watch, *frames)
+
+	for _, args := range [][]string{
+		{"run", "--watch", "game.gb"},
+		{"--watch", "game.gb"},
+		{"--frames", "5", "run", "--watch", "game.gb"},
+	} {
+		*watch = false
+		if err := parseCommandLine(args); err != nil {
+			t.Fatalf("%q: %v", args, err)
+		}
+		if !*watch || flag.NArg() != 1 || flag.Arg(0) != "game.gb" {
+			t.Errorf("%q: expected --watch and ROM game.gb, got watch=%v, args %q", args, *watch, flag.Args())
+		}
+	}
+	if *frames != 5 {
+		t.Errorf("Expected flags before run to count, got --frames %d", *frames)
+	}
+}
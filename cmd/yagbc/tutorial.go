@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/antoniosarro/yagbc/internal/core/gb/memory"
+	"github.com/antoniosarro/yagbc/internal/core/gb/processor"
+)
+
+// lesson is one guided mini-program of the tutorial.
+type lesson struct {
+	title string
+	intro []string          // Shown before the first instruction
+	code  map[uint16][]byte // Machine code, by load address
+
+	// wake is called when the CPU halts. It may raise an interrupt to
+	// carry on, returning a note about it; "" ends the lesson.
+	wake func(cpu *processor.CPU) string
+
+	// result describes the outcome once the lesson has ended.
+	result func(cpu *processor.CPU) string
+}
+
+// rom assembles the lesson's code into a ROM image.
+func (l lesson) rom() []byte {
+	var rom []byte
+	for addr, code := range l.code {
+		if end := int(addr) + len(code); end > len(rom) {
+			rom = append(rom, make([]byte, end-len(rom))...)
+		}
+		copy(rom[addr:], code)
+	}
+	return rom
+}
+
+// lessons is the tutorial course, in order.
+var lessons = []lesson{
+	{
+		title: "Loops",
+		intro: []string{
+			"Add 3 + 2 + 1 by counting B down to zero.",
+			"DEC B sets the Z flag when B reaches 0, and JR NZ jumps back",
+			"to the ADD for as long as Z is still 0.",
+		},
+		code: map[uint16][]byte{0x0000: {
+			0x3E, 0x00, // 0x0000: LD A, 0
+			0x06, 0x03, // 0x0002: LD B, 3
+			0x80,       // 0x0004: ADD A, B
+			0x05,       // 0x0005: DEC B
+			0x20, 0xFC, // 0x0006: JR NZ, -4 (back to 0x0004)
+			0x76, // 0x0008: HALT
+		}},
+		result: func(cpu *processor.CPU) string {
+			return fmt.Sprintf("A = %d", cpu.Registers.A)
+		},
+	},
+	{
+		title: "The stack",
+		intro: []string{
+			"PUSH saves a register pair on the stack and POP gets it back,",
+			"even into a different pair. CALL pushes the return address, so",
+			"the subroutine's RET knows where to come back to. Watch SP move",
+			"down as the stack grows and up as it shrinks.",
+		},
+		code: map[uint16][]byte{
+			0x0000: {
+				0x31, 0x00, 0xD0, // 0x0000: LD SP, 0xD000
+				0x01, 0x34, 0x12, // 0x0003: LD BC, 0x1234
+				0xC5,             // 0x0006: PUSH BC
+				0xCD, 0x10, 0x00, // 0x0007: CALL 0x0010
+				0xD1, // 0x000A: POP DE
+				0x76, // 0x000B: HALT
+			},
+			0x0010: {
+				0x06, 0xFF, // 0x0010: LD B, 0xFF
+				0xC9, // 0x0012: RET
+			},
+		},
+		result: func(cpu *processor.CPU) string {
+			return fmt.Sprintf("DE = 0x%04X, SP = 0x%04X", cpu.Registers.DE(), cpu.Registers.SP)
+		},
+	},
+	{
+		title: "Interrupts",
+		intro: []string{
+			"The program enables the VBlank interrupt in IE (0xFFFF), turns",
+			"on IME with EI and halts. When VBlank is raised, the CPU wakes",
+			"up, pushes PC and jumps to the handler at 0x0040, which sets",
+			"C and returns with RETI.",
+		},
+		code: map[uint16][]byte{
+			0x0000: {
+				0x31, 0x00, 0xD0, // 0x0000: LD SP, 0xD000
+				0x3E, 0x01, // 0x0003: LD A, 0x01 (VBlank)
+				0xE0, 0xFF, // 0x0005: LDH (0xFF), A (IE = VBlank)
+				0xFB, // 0x0007: EI
+				0x76, // 0x0008: HALT
+				0x76, // 0x0009: HALT
+			},
+			0x0040: {
+				0x0E, 0x42, // 0x0040: LD C, 0x42
+				0xD9, // 0x0042: RETI
+			},
+		},
+		wake: func(cpu *processor.CPU) string {
+			if cpu.Registers.C != 0 {
+				return "" // The handler ran already
+			}
+			cpu.RequestInterrupt(processor.InterruptVBlank)
+			return "The PPU raises the VBlank interrupt (IF bit 0)."
+		},
+		result: func(cpu *processor.CPU) string {
+			return fmt.Sprintf("C = 0x%02X, set by the interrupt handler", cpu.Registers.C)
+		},
+	},
+}
+
+// maxLessonSteps bounds a lesson in case its program never halts.
+const maxLessonSteps = 1000
+
+// runTutorial walks through the lessons, one instruction per line
+// read from in. Typing "r" runs the rest of the lesson, "q" quits,
+// and the end of the input runs everything to completion.
+func runTutorial(in io.Reader, out io.Writer) error {
+	input := bufio.NewScanner(in)
+	interactive, eof := true, false
+
+	// prompt waits for the user and reports whether to keep stepping
+	// one instruction at a time.
+	prompt := func() (quit bool) {
+		if !interactive {
+			return false
+		}
+		fmt.Fprint(out, "[Enter] step  [r] run  [q] quit > ")
+		if !input.Scan() {
+			fmt.Fprintln(out)
+			interactive, eof = false, true
+			return false
+		}
+		switch strings.TrimSpace(input.Text()) {
+		case "q":
+			return true
+		case "r":
+			interactive = false
+		}
+		return false
+	}
+
+	for i, l := range lessons {
+		fmt.Fprintf(out, "━━━ Lesson %d/%d: %s ━━━\n", i+1, len(lessons), l.title)
+		for _, line := range l.intro {
+			fmt.Fprintln(out, line)
+		}
+		fmt.Fprintln(out)
+
+		mem := memory.NewBasicMemory()
+		if err := mem.LoadROM(l.rom()); err != nil {
+			return err
+		}
+		cpu := processor.NewCPU(mem)
+		explained := false
+		cpu.OnExplain = func(cpu *processor.CPU, e *processor.Explanation) {
+			explained = true
+			fmt.Fprint(out, e)
+		}
+		showState(out, cpu)
+		interactive = !eof
+
+		for steps := 0; ; steps++ {
+			if steps == maxLessonSteps {
+				return fmt.Errorf("lesson %q did not finish after %d steps", l.title, maxLessonSteps)
+			}
+			if cpu.Halted {
+				note := ""
+				if l.wake != nil {
+					note = l.wake(cpu)
+				}
+				if note == "" {
+					break
+				}
+				fmt.Fprintln(out, note)
+			}
+			if prompt() {
+				return nil
+			}
+
+			pc := cpu.Registers.PC
+			explained = false
+			if _, err := cpu.Step(); err != nil {
+				return err
+			}
+
+			// Only instructions are explained; say what else happened
+			switch {
+			case explained:
+			case cpu.Registers.PC != pc:
+				fmt.Fprintf(out, "Interrupt dispatched: PC 0x%04X pushed, jumped to 0x%04X\n", pc, cpu.Registers.PC)
+			default:
+				fmt.Fprintln(out, "Halted: waiting for an interrupt")
+			}
+			showState(out, cpu)
+		}
+
+		fmt.Fprintf(out, "Done: %s\n\n", l.result(cpu))
+	}
+	return nil
+}
+
+// showState prints the registers, flags and interrupt state.
+func showState(out io.Writer, cpu *processor.CPU) {
+	r := cpu.Registers
+	fmt.Fprintf(out, "  │ AF=%04X BC=%04X DE=%04X HL=%04X SP=%04X PC=%04X │ Z=%d N=%d H=%d C=%d │ IME=%d\n\n",
+		r.AF(), r.BC(), r.DE(), r.HL(), r.SP, r.PC,
+		bit(r.GetFlagZ()), bit(r.GetFlagN()), bit(r.GetFlagH()), bit(r.GetFlagC()), bit(cpu.IME))
+}
+
+// bit converts a flag state to 0 or 1 for display.
+func bit(set bool) int {
+	if set {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRunTutorial(t *testing.T) {
+	var out strings.Builder
+	if err := runTutorial(strings.NewReader(""), &out); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"Done: A = 6\n",
+		"Done: DE = 0x1234, SP = 0xD000\n",
+		"Interrupt dispatched: PC 0x0009 pushed, jumped to 0x0040\n",
+		"Done: C = 0x42, set by the interrupt handler\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestRunTutorialQuit(t *testing.T) {
+	var out strings.Builder
+	if err := runTutorial(strings.NewReader("\nq\n"), &out); err != nil {
+		t.Fatal(err)
+	}
+
+	// One instruction stepped, then quit
+	if got := strings.Count(out.String(), "(8 cycles)"); got != 1 {
+		t.Errorf("Expected 1 instruction before quitting, got %d:\n%s", got, out.String())
+	}
+	if strings.Contains(out.String(), "Lesson 2/") {
+		t.Error("Expected the tutorial to stop at lesson 1")
+	}
+}
@@ -0,0 +1,70 @@
+// Command frontend is the skeleton of an emulator frontend: a main
+// loop that runs the CPU one frame at a time, raises the VBlank
+// interrupt at the end of each frame and reacts to the CPU's states.
+//
+// A real frontend would draw the frame and poll input where the
+// TODO comments are; this one just prints what happened.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/antoniosarro/yagbc/internal/core/gb/memory"
+	"github.com/antoniosarro/yagbc/internal/core/gb/processor"
+)
+
+// frameCounter is where the program's VBlank handler counts frames.
+const frameCounter uint16 = 0xFF80
+
+// buildProgram returns a ROM that enables the VBlank interrupt and
+// halts forever; the handler at 0x0040 increments frameCounter.
+func buildProgram() []byte {
+	rom := make([]byte, 0x100)
+	copy(rom[0x0000:], []byte{
+		0x3E, 0x01, // 0x0000: LD A, 0x01 (VBlank)
+		0xE0, 0xFF, // 0x0002: LDH (0xFF), A - write IE
+		0xFB,       // 0x0004: EI
+		0x76,       // 0x0005: HALT
+		0x18, 0xFD, // 0x0006: JR -3 (back to HALT)
+	})
+	copy(rom[0x0040:], []byte{
+		0xF0, 0x80, // 0x0040: LDH A, (0x80)
+		0x3C,       // 0x0042: INC A
+		0xE0, 0x80, // 0x0043: LDH (0x80), A
+		0xD9, // 0x0045: RETI
+	})
+	return rom
+}
+
+func main() {
+	if err := run(os.Stdout, 5); err != nil {
+		fmt.Fprintln(os.Stderr, "frontend:", err)
+		os.Exit(1)
+	}
+}
+
+// run drives the emulator for the given number of frames.
+func run(w io.Writer, frames int) error {
+	mem := memory.NewBasicMemory()
+	if err := mem.LoadROM(buildProgram()); err != nil {
+		return err
+	}
+	cpu := processor.NewCPU(mem)
+
+	for frame := 1; frame <= frames; frame++ {
+		// TODO: Poll input here
+
+		if _, err := cpu.RunFrame(); err != nil {
+			return fmt.Errorf("stopped at frame %d: %w", frame, err)
+		}
+
+		// The PPU enters VBlank once per frame
+		cpu.RequestInterrupt(processor.InterruptVBlank)
+
+		// TODO: Draw the frame here
+		fmt.Fprintf(w, "Frame %d: handler ran %d time(s)\n", frame, mem.Read(frameCounter))
+	}
+	return nil
+}
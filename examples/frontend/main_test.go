@@ -0,0 +1,22 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	var out strings.Builder
+	if err := run(&out, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	// The interrupt raised at the end of a frame is serviced during
+	// the next one
+	want := "Frame 1: handler ran 0 time(s)\n" +
+		"Frame 2: handler ran 1 time(s)\n" +
+		"Frame 3: handler ran 2 time(s)\n"
+	if out.String() != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, out.String())
+	}
+}
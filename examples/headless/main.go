@@ -0,0 +1,114 @@
+// Command headless runs a program on the emulator core without any
+// display, then prints the final CPU state. It is the starting point
+// for scripted runs, test ROM automation and benchmarks.
+//
+// Usage:
+//
+//	go run ./examples/headless [-trace file] [rom.gb] [frames]
+//
+// Without a ROM, a small built-in program is run. -trace writes a
+// Gameboy Doctor log of every instruction to file.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/antoniosarro/yagbc/internal/core/gb/memory"
+	"github.com/antoniosarro/yagbc/internal/core/gb/processor"
+)
+
+// demoProgram counts B down from 0x10 while adding it up in A,
+// then halts.
+var demoProgram = []byte{
+	0x3E, 0x00, // 0x0000: LD A, 0
+	0x06, 0x10, // 0x0002: LD B, 0x10
+	0x80,       // 0x0004: ADD A, B
+	0x05,       // 0x0005: DEC B
+	0x20, 0xFC, // 0x0006: JR NZ, -4 (back to ADD A, B)
+	0x76, // 0x0008: HALT
+}
+
+func main() {
+	tracePath := flag.String("trace", "", "write a Gameboy Doctor log to `file`")
+	flag.Parse()
+
+	rom := demoProgram
+	frames := 1
+
+	if flag.NArg() > 0 {
+		data, err := os.ReadFile(flag.Arg(0))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "headless:", err)
+			os.Exit(1)
+		}
+		rom = data
+	}
+	if flag.NArg() > 1 {
+		n, err := strconv.Atoi(flag.Arg(1))
+		if err != nil || n < 1 {
+			fmt.Fprintln(os.Stderr, "headless: frames must be a positive number")
+			os.Exit(1)
+		}
+		frames = n
+	}
+
+	var trace io.Writer // nil unless -trace is given
+	var buffered *bufio.Writer
+	if *tracePath != "" {
+		f, err := os.Create(*tracePath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "headless:", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		buffered = bufio.NewWriter(f)
+		trace = buffered
+	}
+
+	err := run(os.Stdout, rom, frames, trace)
+	if buffered != nil {
+		// Flush even a failed run: the end of the log is what matters
+		err = errors.Join(err, buffered.Flush())
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "headless:", err)
+		os.Exit(1)
+	}
+}
+
+// run executes rom for the given number of frames and writes a
+// summary of the final CPU state to w. If trace is not nil, it
+// receives a Gameboy Doctor log of every instruction.
+func run(w io.Writer, rom []byte, frames int, trace io.Writer) error {
+	mem := memory.NewBasicMemory()
+	if err := mem.LoadROM(rom); err != nil {
+		return err
+	}
+	cpu := processor.NewCPU(mem)
+	cpu.Trace = trace
+
+	// A locked-up CPU is part of the state being reported, so stop
+	// early rather than fail the run
+	for range frames {
+		_, err := cpu.RunFrame()
+		if errors.Is(err, processor.ErrLocked) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	regs := cpu.Registers
+	fmt.Fprintf(w, "Ran %d frame(s), %d cycles\n", frames, cpu.TotalCycles)
+	fmt.Fprintf(w, "AF=%04X BC=%04X DE=%04X HL=%04X SP=%04X PC=%04X\n",
+		regs.AF(), regs.BC(), regs.DE(), regs.HL(), regs.SP, regs.PC)
+	fmt.Fprintf(w, "Halted=%v Stopped=%v Locked=%v\n", cpu.Halted, cpu.Stopped, cpu.Locked)
+	return nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	var out strings.Builder
+	if err := run(&out, demoProgram, 1, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// 0x10 + 0x0F + ... + 0x01 = 0x88
+	for _, want := range []string{"Ran 1 frame(s)", "AF=88", "PC=0009", "Halted=true"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestRunROMTooLarge(t *testing.T) {
+	var out strings.Builder
+	if err := run(&out, make([]byte, 0x10000), 1, nil); err == nil {
+		t.Error("Expected an error for an oversized ROM")
+	}
+}
+
+func TestRunTrace(t *testing.T) {
+	var out, trace strings.Builder
+	if err := run(&out, demoProgram, 1, &trace); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(trace.String(), "\n"), "\n")
+	want := "A:00 F:00 B:00 C:00 D:00 E:00 H:00 L:00 SP:FFFE PC:0000 PCMEM:3E,00,06,10"
+	if lines[0] != want {
+		t.Errorf("Expected first trace line %q, got %q", want, lines[0])
+	}
+	// LD A, LD B, 16 x (ADD, DEC, JR NZ), HALT
+	if len(lines) != 2+16*3+1 {
+		t.Errorf("Expected %d trace lines, got %d", 2+16*3+1, len(lines))
+	}
+}
@@ -0,0 +1,75 @@
+// Command memscrape runs a program and then reads the results it
+// left in work RAM. The same approach pulls scores, positions or
+// other state out of a game: run it, then read the addresses where
+// the game keeps that data.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/antoniosarro/yagbc/internal/core/gb/memory"
+	"github.com/antoniosarro/yagbc/internal/core/gb/processor"
+)
+
+// tableAddr and tableLen locate the table the program builds in WRAM.
+const (
+	tableAddr uint16 = 0xC000
+	tableLen         = 8
+)
+
+// program writes the powers of two 1..128 to 0xC000-0xC007, then halts.
+var program = []byte{
+	0x21, 0x00, 0xC0, // 0x0000: LD HL, 0xC000
+	0x06, 0x08, // 0x0003: LD B, 8
+	0x3E, 0x01, // 0x0005: LD A, 1
+	0x22,       // 0x0007: LD (HL+), A
+	0x87,       // 0x0008: ADD A, A
+	0x05,       // 0x0009: DEC B
+	0x20, 0xFB, // 0x000A: JR NZ, -5 (back to LD (HL+), A)
+	0x76, // 0x000C: HALT
+}
+
+// maxSteps bounds the run in case the program never halts.
+const maxSteps = 10_000
+
+func main() {
+	if err := run(os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "memscrape:", err)
+		os.Exit(1)
+	}
+}
+
+// run executes the program and writes the scraped table to w.
+func run(w io.Writer) error {
+	mem := memory.NewBasicMemory()
+	if err := mem.LoadROM(program); err != nil {
+		return err
+	}
+	cpu := processor.NewCPU(mem)
+
+	for steps := 0; !cpu.Halted; steps++ {
+		if steps == maxSteps {
+			return fmt.Errorf("program did not halt after %d steps", maxSteps)
+		}
+		if _, err := cpu.Step(); err != nil {
+			return err
+		}
+	}
+
+	values := scrape(mem, tableAddr, tableLen)
+	for i, v := range values {
+		fmt.Fprintf(w, "0x%04X: %3d (0x%02X)\n", tableAddr+uint16(i), v, v)
+	}
+	return nil
+}
+
+// scrape reads n bytes of memory starting at addr.
+func scrape(mem memory.Memory, addr uint16, n int) []uint8 {
+	values := make([]uint8, n)
+	for i := range values {
+		values[i] = mem.Read(addr + uint16(i))
+	}
+	return values
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	var out strings.Builder
+	if err := run(&out); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != tableLen {
+		t.Fatalf("Expected %d lines, got %d:\n%s", tableLen, len(lines), out.String())
+	}
+	if want := "0xC000:   1 (0x01)"; lines[0] != want {
+		t.Errorf("Expected first line %q, got %q", want, lines[0])
+	}
+	if want := "0xC007: 128 (0x80)"; lines[7] != want {
+		t.Errorf("Expected last line %q, got %q", want, lines[7])
+	}
+}
@@ -0,0 +1,61 @@
+// Package cartridge implements Game Boy cartridges: the ROM, the
+// optional external RAM, and the memory bank controller (MBC) that
+// maps them into the CPU's address space.
+package cartridge
+
+import "fmt"
+
+// Cartridge is what the memory map delegates the cartridge slot to:
+//   - 0x0000-0x7FFF: ROM. Reads return ROM bytes; writes cannot
+//     change the ROM, they program the MBC's registers (if any)
+//   - 0xA000-0xBFFF: external RAM, if the cartridge has any
+//
+// Addresses are the CPU's, not offsets into the ROM or RAM.
+type Cartridge interface {
+	ReadROM(addr uint16) uint8
+	WriteROM(addr uint16, value uint8)
+	ReadRAM(addr uint16) uint8
+	WriteRAM(addr uint16, value uint8)
+}
+
+// RAMBacked is implemented by cartridges with external RAM, so its
+// contents can be carried over, e.g. into the same game reloaded.
+type RAMBacked interface {
+	RAM() []byte         // A copy of the RAM
+	LoadRAM(data []byte) // Copies data over the start of the RAM
+}
+
+// romOnlySize is the largest ROM that fits the address space without
+// an MBC: two 16KB banks.
+const romOnlySize = 0x8000
+
+// ROMOnly is a cartridge with no MBC: up to 32KB of ROM, mapped
+// as-is at 0x0000-0x7FFF, and no external RAM.
+type ROMOnly struct {
+	rom [romOnlySize]uint8
+}
+
+// NewROMOnly creates a ROM-only cartridge holding rom. A shorter
+// image is padded with 0x00, so small test programs can be loaded
+// directly.
+func NewROMOnly(rom []byte) (*ROMOnly, error) {
+	if len(rom) > romOnlySize {
+		return nil, fmt.Errorf("ROM data too large: %d bytes (max %d)", len(rom), romOnlySize)
+	}
+	c := &ROMOnly{}
+	copy(c.rom[:], rom)
+	return c, nil
+}
+
+func (c *ROMOnly) ReadROM(addr uint16) uint8 {
+	return c.rom[addr&(romOnlySize-1)]
+}
+
+// WriteROM is ignored: there is no MBC to program, and ROM is
+// read-only.
+func (c *ROMOnly) WriteROM(addr uint16, value uint8) {}
+
+// ReadRAM returns 0xFF: with no RAM chip, the bus is left floating.
+func (c *ROMOnly) ReadRAM(addr uint16) uint8 { return 0xFF }
+
+func (c *ROMOnly) WriteRAM(addr uint16, value uint8) {}
@@ -0,0 +1,33 @@
+package cartridge
+
+import "testing"
+
+func TestROMOnly(t *testing.T) {
+	c, err := NewROMOnly([]byte{0x3E, 0x42})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.ReadROM(0x0000) != 0x3E || c.ReadROM(0x0001) != 0x42 {
+		t.Errorf("Expected the ROM bytes, got 0x%02X 0x%02X", c.ReadROM(0x0000), c.ReadROM(0x0001))
+	}
+	if got := c.ReadROM(0x7FFF); got != 0x00 {
+		t.Errorf("Expected a short image to be padded with 0x00, got 0x%02X", got)
+	}
+
+	// ROM is read-only, and there is no RAM
+	c.WriteROM(0x0000, 0xFF)
+	if got := c.ReadROM(0x0000); got != 0x3E {
+		t.Errorf("Expected the ROM write to be ignored, got 0x%02X", got)
+	}
+	c.WriteRAM(0xA000, 0x12)
+	if got := c.ReadRAM(0xA000); got != 0xFF {
+		t.Errorf("Expected 0xFF with no RAM, got 0x%02X", got)
+	}
+}
+
+func TestROMOnlyTooLarge(t *testing.T) {
+	if _, err := NewROMOnly(make([]byte, 0x8001)); err == nil {
+		t.Error("Expected an error for a ROM larger than 32KB")
+	}
+}
@@ -0,0 +1,76 @@
+package cartridge
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrChecksum is wrapped by every *ChecksumError.
+var ErrChecksum = errors.New("cartridge: checksum mismatch")
+
+// ChecksumError reports a checksum in the header that does not match
+// the ROM's contents, which usually means a corrupted or patched
+// dump.
+type ChecksumError struct {
+	Checksum string // "header" (0x014D) or "global" (0x014E-0x014F)
+	Stored   uint16 // The value in the header
+	Computed uint16 // The value computed from the ROM
+}
+
+func (e *ChecksumError) Error() string {
+	digits := 4
+	if e.Checksum == "header" {
+		digits = 2
+	}
+	return fmt.Sprintf("cartridge: %s checksum mismatch: header says 0x%0*X, ROM gives 0x%0*X",
+		e.Checksum, digits, e.Stored, digits, e.Computed)
+}
+
+func (e *ChecksumError) Unwrap() error { return ErrChecksum }
+
+// VerifyChecksums checks both checksums of a ROM image, which must
+// hold at least a header (see ParseHeader), and returns a
+// *ChecksumError for each mismatch, joined with errors.Join, or nil:
+//   - header checksum (0x014D): x = x - byte - 1 over 0x0134-0x014C.
+//     The boot ROM locks up if it is wrong; emulation starts past the
+//     boot ROM, so the game runs anyway
+//   - global checksum (0x014E-0x014F): the 16-bit sum of every byte
+//     in the ROM except the checksum itself. Nothing checks it on
+//     hardware, and some homebrew leaves it 0
+func VerifyChecksums(rom []byte) error {
+	h, err := ParseHeader(rom)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	if got := headerChecksum(rom); got != h.HeaderChecksum {
+		errs = append(errs, &ChecksumError{Checksum: "header", Stored: uint16(h.HeaderChecksum), Computed: uint16(got)})
+	}
+	if got := globalChecksum(rom); got != h.GlobalChecksum {
+		errs = append(errs, &ChecksumError{Checksum: "global", Stored: h.GlobalChecksum, Computed: got})
+	}
+	return errors.Join(errs...)
+}
+
+// headerChecksum computes the header checksum the boot ROM expects at
+// 0x014D.
+func headerChecksum(rom []byte) uint8 {
+	var x uint8
+	for _, b := range rom[addrTitle:addrHeaderChecksum] {
+		x = x - b - 1
+	}
+	return x
+}
+
+// globalChecksum computes the global checksum expected at
+// 0x014E-0x014F.
+func globalChecksum(rom []byte) uint16 {
+	var sum uint16
+	for i, b := range rom {
+		if i != addrGlobalChecksum && i != addrGlobalChecksum+1 {
+			sum += uint16(b)
+		}
+	}
+	return sum
+}
@@ -0,0 +1,54 @@
+package cartridge
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestVerifyChecksums(t *testing.T) {
+	rom := make([]byte, 0x8000)
+	copy(rom[addrTitle:], "TEST")
+	rom[0x4000] = 0xFF
+
+	// Computed by hand: 0 - 'T' - 'E' - 'S' - 'T' - 25 (mod 256), and
+	// 'T' + 'E' + 'S' + 'T' + 0xFF + the header checksum
+	rom[addrHeaderChecksum] = 0xA7
+	rom[addrGlobalChecksum], rom[addrGlobalChecksum+1] = 0x02, 0xE6
+	if err := VerifyChecksums(rom); err != nil {
+		t.Fatalf("Expected valid checksums, got %v", err)
+	}
+
+	rom[addrTitle] = 'B' // Also changes the global checksum
+	err := VerifyChecksums(rom)
+	if !errors.Is(err, ErrChecksum) {
+		t.Fatalf("Expected ErrChecksum, got %v", err)
+	}
+
+	var mismatches []*ChecksumError
+	for _, err := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var ce *ChecksumError
+		if errors.As(err, &ce) {
+			mismatches = append(mismatches, ce)
+		}
+	}
+	want := []ChecksumError{
+		{Checksum: "header", Stored: 0xA7, Computed: 0xB9},
+		{Checksum: "global", Stored: 0x02E6, Computed: 0x02D4},
+	}
+	if len(mismatches) != len(want) {
+		t.Fatalf("Expected %d mismatches, got %v", len(want), err)
+	}
+	for i := range want {
+		if *mismatches[i] != want[i] {
+			t.Errorf("Expected %+v, got %+v", want[i], *mismatches[i])
+		}
+	}
+}
+
+func TestChecksumErrorMessage(t *testing.T) {
+	err := &ChecksumError{Checksum: "header", Stored: 0xA7, Computed: 0xB9}
+	want := "cartridge: header checksum mismatch: header says 0xA7, ROM gives 0xB9"
+	if got := err.Error(); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,200 @@
+package cartridge
+
+import (
+	"fmt"
+	"testing"
+)
+
+// ============================================================
+// Mapper conformance
+// ============================================================
+// Every cartridge implementation runs the same kind of script:
+// control-register writes, then reads at the bank boundaries
+// (0x0000/0x3FFF, 0x4000/0x7FFF, 0xA000/0xBFFF), each expecting a
+// given ROM or RAM bank. mapperContract holds the cases every mapper
+// must pass; mappers adds each mapper's own. A new MBC gets an entry
+// in mappers, and is held to the contract with it.
+//
+// The ROM and RAM are filled with markers that encode both the bank
+// and the offset in it, so a read proves the whole address mapping.
+
+// mapperWrite is a CPU write to the cartridge.
+type mapperWrite struct {
+	addr  uint16
+	value uint8
+}
+
+// bankRead expects the read at addr to come from bank (0x0000-0x7FFF:
+// ROM bank; 0xA000-0xBFFF: RAM bank, or openBus for 0xFF).
+type bankRead struct {
+	addr uint16
+	bank int
+}
+
+// openBus is the bank of a RAM read that reaches no RAM.
+const openBus = -1
+
+type mapperCase struct {
+	name   string
+	writes []mapperWrite
+	reads  []bankRead
+}
+
+// romMarker and ramMarker are the bytes at offset in a bank.
+func romMarker(bank, offset int) uint8 { return uint8(bank) ^ uint8(offset) }
+func ramMarker(bank, offset int) uint8 { return uint8(bank)<<5 | uint8(offset)&0x1F }
+
+// mapperContract holds for every mapper.
+var mapperContract = []mapperCase{
+	{name: "power-on", reads: []bankRead{
+		{0x0000, 0}, {0x3FFF, 0}, {0x4000, 1}, {0x7FFF, 1},
+		{0xA000, openBus}, {0xBFFF, openBus}, // RAM starts disabled (or absent)
+	}},
+}
+
+var mappers = []struct {
+	name     string
+	romBanks int
+	ramSize  int
+	new      func(rom []byte, ramSize int) (Cartridge, error)
+	prepare  func(rom []byte) // Optional ROM tweak, e.g. a multicart's logos
+	cases    []mapperCase
+}{
+	{
+		name: "ROMOnly", romBanks: 2,
+		new: func(rom []byte, _ int) (Cartridge, error) { return NewROMOnly(rom) },
+		cases: []mapperCase{
+			{"writes do nothing", []mapperWrite{{0x0000, 0x0A}, {0x2000, 0x05}, {0x4000, 0x01}, {0x6000, 0x01}},
+				[]bankRead{{0x0000, 0}, {0x4000, 1}, {0x7FFF, 1}, {0xA000, openBus}}},
+		},
+	},
+	{
+		name: "MBC1", romBanks: 128, ramSize: mbc1MaxRAM,
+		new: func(rom []byte, ramSize int) (Cartridge, error) { return NewMBC1(rom, ramSize) },
+		cases: []mapperCase{
+			{"BANK1 selects 0x4000", []mapperWrite{{0x2000, 0x05}},
+				[]bankRead{{0x3FFF, 0}, {0x4000, 5}, {0x7FFF, 5}}},
+			{"BANK1 spans 0x2000-0x3FFF", []mapperWrite{{0x3FFF, 0x07}},
+				[]bankRead{{0x4000, 7}}},
+			{"BANK1 0 selects 1", []mapperWrite{{0x2000, 0x05}, {0x2000, 0x00}},
+				[]bankRead{{0x4000, 1}}},
+			{"BANK1 has 5 bits", []mapperWrite{{0x2000, 0xE3}},
+				[]bankRead{{0x4000, 3}}},
+			{"BANK2 extends 0x4000", []mapperWrite{{0x2000, 0x01}, {0x5FFF, 0x02}},
+				[]bankRead{{0x0000, 0}, {0x4000, 0x41}}},
+			{"bank 0x20 reads 0x21", []mapperWrite{{0x4000, 0x01}, {0x2000, 0x00}},
+				[]bankRead{{0x4000, 0x21}}},
+			{"RAM enable", []mapperWrite{{0x0000, 0x0A}},
+				[]bankRead{{0xA000, 0}, {0xBFFF, 0}}},
+			{"RAM enable checks the low nibble", []mapperWrite{{0x1FFF, 0x1A}},
+				[]bankRead{{0xA000, 0}}},
+			{"RAM enable needs 0x0A", []mapperWrite{{0x0000, 0x0B}},
+				[]bankRead{{0xA000, openBus}}},
+			{"RAM disable", []mapperWrite{{0x0000, 0x0A}, {0x0000, 0x00}},
+				[]bankRead{{0xA000, openBus}}},
+			{"mode 0 keeps RAM bank 0", []mapperWrite{{0x0000, 0x0A}, {0x4000, 0x03}},
+				[]bankRead{{0x0000, 0}, {0xA000, 0}}},
+			{"mode 1 banks 0x0000 and RAM", []mapperWrite{{0x0000, 0x0A}, {0x4000, 0x03}, {0x2000, 0x02}, {0x6000, 0x01}},
+				[]bankRead{{0x0000, 0x60}, {0x3FFF, 0x60}, {0x4000, 0x62}, {0xA000, 3}, {0xBFFF, 3}}},
+			{"mode 0 again", []mapperWrite{{0x4000, 0x03}, {0x6000, 0x01}, {0x7FFF, 0x00}},
+				[]bankRead{{0x0000, 0}}},
+		},
+	},
+	{
+		name: "MBC1 256KB", romBanks: 16,
+		new: func(rom []byte, ramSize int) (Cartridge, error) { return NewMBC1(rom, ramSize) },
+		cases: []mapperCase{
+			{"unconnected BANK1 bit", []mapperWrite{{0x2000, 0x10}},
+				[]bankRead{{0x4000, 0}}},
+			{"unconnected BANK2", []mapperWrite{{0x2000, 0x03}, {0x4000, 0x03}, {0x6000, 0x01}},
+				[]bankRead{{0x0000, 0}, {0x4000, 3}}},
+			{"no RAM", []mapperWrite{{0x0000, 0x0A}},
+				[]bankRead{{0xA000, openBus}}},
+		},
+	},
+	{
+		name: "MBC1M", romBanks: 64,
+		new: func(rom []byte, ramSize int) (Cartridge, error) { return NewMBC1(rom, ramSize) },
+		prepare: func(rom []byte) {
+			// Nintendo logos in the headers of the menu and the second game
+			for i := addrLogo; i < addrTitle; i++ {
+				rom[i], rom[0x10*romBankSize+i] = 0xCE, 0xCE
+			}
+		},
+		cases: []mapperCase{
+			{"BANK2 is bits 4-5", []mapperWrite{{0x2000, 0x02}, {0x4000, 0x01}},
+				[]bankRead{{0x0000, 0}, {0x4000, 0x12}}},
+			{"BANK1 has 4 bits", []mapperWrite{{0x2000, 0x12}},
+				[]bankRead{{0x4000, 0x02}}},
+			{"BANK1 0x10 maps a game's bank 0", []mapperWrite{{0x2000, 0x10}, {0x4000, 0x01}},
+				[]bankRead{{0x4000, 0x10}}},
+			{"mode 1 maps each game's bank 0", []mapperWrite{{0x4000, 0x02}, {0x2000, 0x02}, {0x6000, 0x01}},
+				[]bankRead{{0x0000, 0x20}, {0x3FFF, 0x20}, {0x4000, 0x22}}},
+		},
+	},
+}
+
+func TestMapperConformance(t *testing.T) {
+	for _, m := range mappers {
+		rom := make([]byte, m.romBanks*romBankSize)
+		for i := range rom {
+			rom[i] = romMarker(i/romBankSize, i%romBankSize)
+		}
+		if m.prepare != nil {
+			m.prepare(rom)
+		}
+		ram := make([]byte, m.ramSize)
+		for i := range ram {
+			ram[i] = ramMarker(i/ramBankSize, i%ramBankSize)
+		}
+
+		for _, tc := range append(append([]mapperCase(nil), mapperContract...), m.cases...) {
+			t.Run(m.name+"/"+tc.name, func(t *testing.T) {
+				c, err := m.new(rom, m.ramSize)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if backed, ok := c.(RAMBacked); ok {
+					backed.LoadRAM(ram)
+				}
+
+				for _, w := range tc.writes {
+					c.WriteROM(w.addr, w.value)
+				}
+				for _, r := range tc.reads {
+					if got, want := readBank(c, r.addr), expectedByte(r); got != want {
+						t.Errorf("0x%04X: expected 0x%02X (bank %s), got 0x%02X",
+							r.addr, want, bankName(r.bank), got)
+					}
+				}
+			})
+		}
+	}
+}
+
+// readBank reads addr through the cartridge's ROM or RAM interface.
+func readBank(c Cartridge, addr uint16) uint8 {
+	if addr >= 0xA000 {
+		return c.ReadRAM(addr)
+	}
+	return c.ReadROM(addr)
+}
+
+// expectedByte returns the marker r expects at its address.
+func expectedByte(r bankRead) uint8 {
+	switch {
+	case r.addr < 0x8000:
+		return romMarker(r.bank, int(r.addr)%romBankSize)
+	case r.bank == openBus:
+		return 0xFF
+	default:
+		return ramMarker(r.bank, int(r.addr-0xA000)%ramBankSize)
+	}
+}
+
+func bankName(bank int) string {
+	if bank == openBus {
+		return "open bus"
+	}
+	return fmt.Sprintf("0x%02X", bank)
+}
@@ -0,0 +1,130 @@
+package cartridge_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/antoniosarro/yagbc/internal/core/gb"
+	"github.com/antoniosarro/yagbc/internal/core/gb/cartridge"
+	"github.com/antoniosarro/yagbc/internal/core/gb/processor"
+)
+
+// fuzzCycleBudget bounds how long a fuzzed cartridge may run: two
+// frames, plenty for the seed programs to switch banks.
+const fuzzCycleBudget = 2 * processor.CyclesPerFrame
+
+// fuzzProgram starts at the entry point (0x0100): it enables RAM,
+// selects ROM bank 5 (and BANK2 1, and mode 1), then copies a byte
+// from the switchable bank into cartridge RAM, forever.
+var fuzzProgram = []byte{
+	0x00, 0xC3, 0x50, 0x01, // 0x0100: NOP; JP 0x0150
+	0x3E, 0x0A, 0xEA, 0x00, 0x00, // 0x0150: LD A, 0x0A; LD (0x0000), A
+	0x3E, 0x05, 0xEA, 0x00, 0x20, // LD A, 5; LD (0x2000), A
+	0x3E, 0x01, 0xEA, 0x00, 0x40, // LD A, 1; LD (0x4000), A
+	0xEA, 0x00, 0x60, // LD (0x6000), A
+	0xFA, 0x00, 0x40, // LD A, (0x4000)
+	0xEA, 0x00, 0xA0, // LD (0xA000), A
+	0xC3, 0x50, 0x01, // JP 0x0150
+}
+
+// fuzzImage returns a ROM image of the given cartridge type and size
+// codes, running fuzzProgram, with valid checksums.
+func fuzzImage(cartType, romSize, ramSize uint8) []byte {
+	rom := make([]byte, cartridge.ROMSize(romSize).Bytes())
+	copy(rom[0x0100:], fuzzProgram[:4])
+	copy(rom[0x0150:], fuzzProgram[4:])
+	copy(rom[0x0134:], "FUZZ")
+	rom[0x0147], rom[0x0148], rom[0x0149] = cartType, romSize, ramSize
+	for bank := 1; bank < len(rom)/0x4000; bank++ {
+		rom[bank*0x4000] = uint8(bank)
+	}
+	fixChecksums(rom)
+	return rom
+}
+
+// fixChecksums makes both header checksums of rom match its contents.
+func fixChecksums(rom []byte) {
+	var x uint8
+	for _, b := range rom[0x0134:0x014D] {
+		x = x - b - 1
+	}
+	rom[0x014D] = x
+
+	var sum uint16
+	for i, b := range rom {
+		if i != 0x014E && i != 0x014F {
+			sum += uint16(b)
+		}
+	}
+	rom[0x014E], rom[0x014F] = uint8(sum>>8), uint8(sum)
+}
+
+// FuzzLoadReader loads arbitrary images as cartridges and boots the
+// ones that load, checking that no header or mapper state can make
+// the emulator panic or hang.
+//
+// The fuzzer mutates the header (cartridge type, ROM and RAM sizes,
+// checksums) along with the body. Most mutations break the declared
+// size, so each image is also loaded padded or cut to the size its
+// header declares, which keeps the mapper code in reach.
+//
+// Run with: go test -fuzz=FuzzLoadReader ./internal/core/gb/cartridge
+func FuzzLoadReader(f *testing.F) {
+	f.Add([]byte{})
+	f.Add(fuzzImage(0x00, 0x00, 0x00)) // ROM only, 32KB
+	f.Add(fuzzImage(0x01, 0x02, 0x00)) // MBC1, 128KB
+	f.Add(fuzzImage(0x03, 0x06, 0x03)) // MBC1+RAM+BATTERY, 2MB, 32KB RAM
+
+	// MBC1M: a 1MB MBC1 with the logo repeated in the second game
+	multicart := fuzzImage(0x01, 0x05, 0x00)
+	logo := bytes.Repeat([]byte{0xCE}, 0x30)
+	copy(multicart[0x0104:], logo)
+	copy(multicart[0x10*0x4000+0x0104:], logo)
+	fixChecksums(multicart)
+	f.Add(multicart)
+
+	f.Fuzz(func(t *testing.T, rom []byte) {
+		bootCartridge(t, rom)
+		if len(rom) > 0x0149 {
+			if size := cartridge.ROMSize(rom[0x0148]).Bytes(); size != 0 && size != len(rom) {
+				resized := make([]byte, size)
+				copy(resized, rom)
+				bootCartridge(t, resized)
+			}
+		}
+	})
+}
+
+// bootCartridge loads rom and, if it loads, runs it on a Game Boy for
+// fuzzCycleBudget cycles or until the CPU locks up.
+func bootCartridge(t *testing.T, rom []byte) {
+	c, err := cartridge.LoadReader(bytes.NewReader(rom))
+	if c == nil {
+		if err == nil {
+			t.Fatal("LoadReader returned neither a cartridge nor an error")
+		}
+		return
+	}
+	if err != nil && !errors.Is(err, cartridge.ErrChecksum) {
+		t.Fatalf("LoadReader returned a cartridge with error %v", err)
+	}
+
+	gameboy := gb.NewGameBoy()
+	gameboy.Memory.InsertCartridge(c)
+	gameboy.CPU.Reset(processor.ModelDMG)
+	for total := 0; total < fuzzCycleBudget; {
+		cycles, err := gameboy.Step()
+		if cycles <= 0 {
+			t.Fatalf("Step returned %d cycles at PC=0x%04X", cycles, gameboy.CPU.Registers.PC)
+		}
+		total += cycles
+		// Random bytes are bound to hit an illegal opcode eventually
+		if errors.Is(err, processor.ErrLocked) {
+			return
+		}
+		if err != nil {
+			t.Fatalf("Step failed at PC=0x%04X: %v", gameboy.CPU.Registers.PC, err)
+		}
+	}
+}
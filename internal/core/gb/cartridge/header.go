@@ -0,0 +1,258 @@
+package cartridge
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Cartridge header layout (https://gbdev.io/pandocs/The_Cartridge_Header.html).
+// The header sits at 0x0100-0x014F of every ROM image, right after
+// the interrupt vectors.
+const (
+	addrLogo           = 0x0104 // 48 bytes: the Nintendo logo
+	addrTitle          = 0x0134 // 16 bytes, or 11 on later cartridges
+	addrManufacturer   = 0x013F // 4 bytes, on later cartridges
+	addrCGBFlag        = 0x0143
+	addrNewLicensee    = 0x0144 // 2 ASCII characters
+	addrSGBFlag        = 0x0146
+	addrCartType       = 0x0147 // Cartridge type: which MBC, RAM, battery...
+	addrROMSize        = 0x0148 // ROM size: 32KB << value
+	addrRAMSize        = 0x0149
+	addrDestination    = 0x014A
+	addrOldLicensee    = 0x014B
+	addrVersion        = 0x014C
+	addrHeaderChecksum = 0x014D
+	addrGlobalChecksum = 0x014E // 2 bytes, big-endian
+	headerEnd          = 0x0150 // The header ends at 0x014F
+)
+
+// Header is the parsed cartridge header.
+type Header struct {
+	Title          string // Upper case ASCII, without the padding
+	Manufacturer   string // 4 characters, or empty on older cartridges
+	CGB            CGBFlag
+	SGB            bool   // Does the game support Super Game Boy functions?
+	NewLicensee    string // 2 characters, used if OldLicensee is 0x33
+	OldLicensee    uint8
+	Type           CartridgeType
+	ROMSize        ROMSize
+	RAMSize        RAMSize
+	Destination    Destination
+	Version        uint8
+	HeaderChecksum uint8
+	GlobalChecksum uint16
+}
+
+// ParseHeader reads the header of a ROM image. It only fails if the
+// image is too short to hold one: the fields are returned as they
+// are, and may hold codes the enums have no name for.
+func ParseHeader(rom []byte) (Header, error) {
+	if len(rom) < headerEnd {
+		return Header{}, fmt.Errorf("%w: %d bytes is too short to hold a header", ErrBadROM, len(rom))
+	}
+
+	h := Header{
+		CGB:            CGBFlag(rom[addrCGBFlag]),
+		SGB:            rom[addrSGBFlag] == 0x03,
+		NewLicensee:    headerString(rom[addrNewLicensee : addrNewLicensee+2]),
+		OldLicensee:    rom[addrOldLicensee],
+		Type:           CartridgeType(rom[addrCartType]),
+		ROMSize:        ROMSize(rom[addrROMSize]),
+		RAMSize:        RAMSize(rom[addrRAMSize]),
+		Destination:    Destination(rom[addrDestination]),
+		Version:        rom[addrVersion],
+		HeaderChecksum: rom[addrHeaderChecksum],
+		GlobalChecksum: uint16(rom[addrGlobalChecksum])<<8 | uint16(rom[addrGlobalChecksum+1]),
+	}
+
+	// CGB-era cartridges shortened the title to make room for the
+	// manufacturer code and the CGB flag
+	if h.CGB == CGBEnhanced || h.CGB == CGBOnly {
+		h.Title = headerString(rom[addrTitle:addrManufacturer])
+		h.Manufacturer = headerString(rom[addrManufacturer:addrCGBFlag])
+	} else {
+		h.Title = headerString(rom[addrTitle:addrNewLicensee])
+	}
+	return h, nil
+}
+
+// Licensee returns the publisher's licensee code: the 2-character
+// new code if the old one says to use it (0x33), or else the old
+// code in hex.
+func (h Header) Licensee() string {
+	if h.OldLicensee == 0x33 {
+		return h.NewLicensee
+	}
+	return fmt.Sprintf("%02X", h.OldLicensee)
+}
+
+// headerString decodes a NUL-padded ASCII header field.
+func headerString(b []byte) string {
+	s, _, _ := strings.Cut(string(b), "\x00")
+	return strings.TrimRight(s, " ")
+}
+
+// CGBFlag says whether a game supports the Game Boy Color.
+type CGBFlag uint8
+
+const (
+	CGBEnhanced CGBFlag = 0x80 // Runs on any model, with CGB features
+	CGBOnly     CGBFlag = 0xC0 // Only runs on the CGB
+)
+
+// String describes the flag; any other value is a DMG game (or part
+// of an older, 16-character title).
+func (f CGBFlag) String() string {
+	switch f {
+	case CGBEnhanced:
+		return "CGB enhanced"
+	case CGBOnly:
+		return "CGB only"
+	}
+	return "DMG"
+}
+
+// CartridgeType is the hardware on the cartridge: which MBC, and
+// whether it has RAM, a battery, a real-time clock or a rumble motor.
+type CartridgeType uint8
+
+const (
+	TypeROMOnly                    CartridgeType = 0x00
+	TypeMBC1                       CartridgeType = 0x01
+	TypeMBC1RAM                    CartridgeType = 0x02
+	TypeMBC1RAMBattery             CartridgeType = 0x03
+	TypeMBC2                       CartridgeType = 0x05
+	TypeMBC2Battery                CartridgeType = 0x06
+	TypeROMRAM                     CartridgeType = 0x08
+	TypeROMRAMBattery              CartridgeType = 0x09
+	TypeMMM01                      CartridgeType = 0x0B
+	TypeMMM01RAM                   CartridgeType = 0x0C
+	TypeMMM01RAMBattery            CartridgeType = 0x0D
+	TypeMBC3TimerBattery           CartridgeType = 0x0F
+	TypeMBC3TimerRAMBattery        CartridgeType = 0x10
+	TypeMBC3                       CartridgeType = 0x11
+	TypeMBC3RAM                    CartridgeType = 0x12
+	TypeMBC3RAMBattery             CartridgeType = 0x13
+	TypeMBC5                       CartridgeType = 0x19
+	TypeMBC5RAM                    CartridgeType = 0x1A
+	TypeMBC5RAMBattery             CartridgeType = 0x1B
+	TypeMBC5Rumble                 CartridgeType = 0x1C
+	TypeMBC5RumbleRAM              CartridgeType = 0x1D
+	TypeMBC5RumbleRAMBattery       CartridgeType = 0x1E
+	TypeMBC6                       CartridgeType = 0x20
+	TypeMBC7SensorRumbleRAMBattery CartridgeType = 0x22
+	TypePocketCamera               CartridgeType = 0xFC
+	TypeBandaiTAMA5                CartridgeType = 0xFD
+	TypeHuC3                       CartridgeType = 0xFE
+	TypeHuC1RAMBattery             CartridgeType = 0xFF
+)
+
+var cartridgeTypeNames = map[CartridgeType]string{
+	TypeROMOnly:                    "ROM ONLY",
+	TypeMBC1:                       "MBC1",
+	TypeMBC1RAM:                    "MBC1+RAM",
+	TypeMBC1RAMBattery:             "MBC1+RAM+BATTERY",
+	TypeMBC2:                       "MBC2",
+	TypeMBC2Battery:                "MBC2+BATTERY",
+	TypeROMRAM:                     "ROM+RAM",
+	TypeROMRAMBattery:              "ROM+RAM+BATTERY",
+	TypeMMM01:                      "MMM01",
+	TypeMMM01RAM:                   "MMM01+RAM",
+	TypeMMM01RAMBattery:            "MMM01+RAM+BATTERY",
+	TypeMBC3TimerBattery:           "MBC3+TIMER+BATTERY",
+	TypeMBC3TimerRAMBattery:        "MBC3+TIMER+RAM+BATTERY",
+	TypeMBC3:                       "MBC3",
+	TypeMBC3RAM:                    "MBC3+RAM",
+	TypeMBC3RAMBattery:             "MBC3+RAM+BATTERY",
+	TypeMBC5:                       "MBC5",
+	TypeMBC5RAM:                    "MBC5+RAM",
+	TypeMBC5RAMBattery:             "MBC5+RAM+BATTERY",
+	TypeMBC5Rumble:                 "MBC5+RUMBLE",
+	TypeMBC5RumbleRAM:              "MBC5+RUMBLE+RAM",
+	TypeMBC5RumbleRAMBattery:       "MBC5+RUMBLE+RAM+BATTERY",
+	TypeMBC6:                       "MBC6",
+	TypeMBC7SensorRumbleRAMBattery: "MBC7+SENSOR+RUMBLE+RAM+BATTERY",
+	TypePocketCamera:               "POCKET CAMERA",
+	TypeBandaiTAMA5:                "BANDAI TAMA5",
+	TypeHuC3:                       "HuC3",
+	TypeHuC1RAMBattery:             "HuC1+RAM+BATTERY",
+}
+
+// String returns the type's name as the Pan Docs spell it.
+func (t CartridgeType) String() string {
+	if name, ok := cartridgeTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown type 0x%02X", uint8(t))
+}
+
+// ROMSize is the header's ROM size code: 32KB << code, in 16KB banks.
+type ROMSize uint8
+
+// maxROMSizeCode is the largest size code: 8MB.
+const maxROMSizeCode ROMSize = 0x08
+
+// Bytes returns the ROM size, or 0 for an unknown code.
+func (s ROMSize) Bytes() int {
+	if s > maxROMSizeCode {
+		return 0
+	}
+	return 0x8000 << s
+}
+
+// Banks returns the number of 16KB ROM banks, or 0 for an unknown
+// code.
+func (s ROMSize) Banks() int {
+	return s.Bytes() / 0x4000
+}
+
+func (s ROMSize) String() string {
+	if s > maxROMSizeCode {
+		return fmt.Sprintf("unknown size 0x%02X", uint8(s))
+	}
+	return fmt.Sprintf("%d KiB", s.Bytes()/1024)
+}
+
+// RAMSize is the header's external RAM size code.
+type RAMSize uint8
+
+// ramSizes holds the size for each code; 0x01 was never used.
+var ramSizes = [...]int{0, 0, 8 * 1024, 32 * 1024, 128 * 1024, 64 * 1024}
+
+// Bytes returns the size of the external RAM, or 0 for none or an
+// unknown code. MBC2's built-in RAM is not counted: its header says
+// 0x00.
+func (s RAMSize) Bytes() int {
+	if int(s) >= len(ramSizes) {
+		return 0
+	}
+	return ramSizes[s]
+}
+
+func (s RAMSize) String() string {
+	switch {
+	case int(s) >= len(ramSizes):
+		return fmt.Sprintf("unknown size 0x%02X", uint8(s))
+	case s.Bytes() == 0:
+		return "none"
+	}
+	return fmt.Sprintf("%d KiB", s.Bytes()/1024)
+}
+
+// Destination is the market a game was sold in.
+type Destination uint8
+
+const (
+	DestinationJapan    Destination = 0x00 // Japan (and possibly overseas)
+	DestinationOverseas Destination = 0x01 // Overseas only
+)
+
+func (d Destination) String() string {
+	switch d {
+	case DestinationJapan:
+		return "Japan"
+	case DestinationOverseas:
+		return "Overseas"
+	}
+	return fmt.Sprintf("unknown destination 0x%02X", uint8(d))
+}
@@ -0,0 +1,99 @@
+package cartridge
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestParseHeader(t *testing.T) {
+	rom := make([]byte, 0x8000)
+	copy(rom[addrTitle:], "POKEMON YELLOW\x00\x80") // 15 characters, then the CGB flag
+	rom[addrNewLicensee], rom[addrNewLicensee+1] = '0', '1'
+	rom[addrSGBFlag] = 0x03
+	rom[addrCartType] = 0x1B
+	rom[addrROMSize] = 0x05
+	rom[addrRAMSize] = 0x03
+	rom[addrDestination] = 0x01
+	rom[addrOldLicensee] = 0x33
+	rom[addrVersion] = 0x02
+	rom[addrHeaderChecksum] = 0x04
+	rom[addrGlobalChecksum], rom[addrGlobalChecksum+1] = 0x04, 0x7C
+
+	h, err := ParseHeader(rom)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Header{
+		Title:          "POKEMON YEL", // The title makes room for the manufacturer code
+		Manufacturer:   "LOW",
+		CGB:            CGBEnhanced,
+		SGB:            true,
+		NewLicensee:    "01",
+		OldLicensee:    0x33,
+		Type:           TypeMBC5RAMBattery,
+		ROMSize:        0x05,
+		RAMSize:        0x03,
+		Destination:    DestinationOverseas,
+		Version:        0x02,
+		HeaderChecksum: 0x04,
+		GlobalChecksum: 0x047C,
+	}
+	if h != want {
+		t.Errorf("Expected %+v, got %+v", want, h)
+	}
+	if got := h.Licensee(); got != "01" {
+		t.Errorf("Expected the new licensee code, got %q", got)
+	}
+}
+
+func TestParseHeaderDMG(t *testing.T) {
+	rom := make([]byte, headerEnd)
+	copy(rom[addrTitle:], "TETRIS")
+	rom[addrOldLicensee] = 0x01
+
+	h, err := ParseHeader(rom)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.Title != "TETRIS" || h.Manufacturer != "" || h.CGB.String() != "DMG" {
+		t.Errorf("Expected a DMG game called TETRIS, got %+v", h)
+	}
+	if got := h.Licensee(); got != "01" {
+		t.Errorf("Expected the old licensee code, got %q", got)
+	}
+}
+
+func TestParseHeaderShort(t *testing.T) {
+	if _, err := ParseHeader(make([]byte, headerEnd-1)); !errors.Is(err, ErrBadROM) {
+		t.Errorf("Expected ErrBadROM, got %v", err)
+	}
+}
+
+func TestHeaderEnums(t *testing.T) {
+	tests := []struct {
+		value fmt.Stringer
+		want  string
+	}{
+		{TypeMBC1RAMBattery, "MBC1+RAM+BATTERY"},
+		{CartridgeType(0x42), "unknown type 0x42"},
+		{ROMSize(0x00), "32 KiB"},
+		{ROMSize(0x08), "8192 KiB"},
+		{ROMSize(0x52), "unknown size 0x52"},
+		{RAMSize(0x00), "none"},
+		{RAMSize(0x01), "none"},
+		{RAMSize(0x04), "128 KiB"},
+		{RAMSize(0x05), "64 KiB"},
+		{DestinationJapan, "Japan"},
+		{CGBOnly, "CGB only"},
+	}
+	for _, tt := range tests {
+		if got := tt.value.String(); got != tt.want {
+			t.Errorf("Expected %q, got %q", tt.want, got)
+		}
+	}
+
+	if got := ROMSize(0x06).Banks(); got != 128 {
+		t.Errorf("Expected 128 banks in 2MB, got %d", got)
+	}
+}
@@ -0,0 +1,73 @@
+package cartridge
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Errors returned when a ROM image cannot be loaded. They are
+// wrapped with the details; test with errors.Is.
+var (
+	ErrBadROM      = errors.New("cartridge: bad ROM image")
+	ErrUnsupported = errors.New("cartridge: unsupported cartridge type")
+)
+
+// LoadFile reads a .gb or .gbc ROM image from disk and returns the
+// cartridge it describes (see LoadReader).
+func LoadFile(path string) (Cartridge, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadReader(f)
+}
+
+// LoadReader reads a ROM image, e.g. one embedded in the binary, and
+// returns the cartridge it describes. The image must be exactly the
+// size its header declares, and its cartridge type must be one this
+// package implements.
+//
+// If the image only fails its checksums (see VerifyChecksums),
+// LoadReader returns both the cartridge and an error wrapping
+// ErrChecksum. Callers can report it as a warning and carry on.
+func LoadReader(r io.Reader) (Cartridge, error) {
+	// Read one byte more than the largest ROM, to catch oversized images
+	rom, err := io.ReadAll(io.LimitReader(r, int64(maxROMSizeCode.Bytes())+1))
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := ParseHeader(rom)
+	if err != nil {
+		return nil, err
+	}
+	size := h.ROMSize.Bytes()
+	if size == 0 {
+		return nil, fmt.Errorf("%w: unknown ROM size code 0x%02X", ErrBadROM, uint8(h.ROMSize))
+	}
+	if len(rom) != size {
+		return nil, fmt.Errorf("%w: header declares %d bytes, image has %d", ErrBadROM, size, len(rom))
+	}
+
+	var c Cartridge
+	switch h.Type {
+	case TypeROMOnly:
+		c, err = NewROMOnly(rom)
+	case TypeMBC1:
+		c, err = NewMBC1(rom, 0)
+	case TypeMBC1RAM, TypeMBC1RAMBattery: // Battery RAM is not saved yet
+		c, err = NewMBC1(rom, h.RAMSize.Bytes())
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrUnsupported, h.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadROM, err)
+	}
+
+	// A bad checksum does not stop the game from running: return the
+	// cartridge along with the mismatches
+	return c, VerifyChecksums(rom)
+}
@@ -0,0 +1,97 @@
+package cartridge
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testROM returns a ROM image of size bytes with the given header
+// cartridge type and ROM size code, and valid checksums.
+func testROM(size int, cartType, sizeCode uint8) []byte {
+	rom := make([]byte, size)
+	rom[0x0100] = 0x42
+	rom[addrCartType] = cartType
+	rom[addrROMSize] = sizeCode
+	if size >= headerEnd {
+		setChecksums(rom)
+	}
+	return rom
+}
+
+// setChecksums makes both checksums of rom match its contents.
+func setChecksums(rom []byte) {
+	rom[addrHeaderChecksum] = headerChecksum(rom)
+	sum := globalChecksum(rom)
+	rom[addrGlobalChecksum], rom[addrGlobalChecksum+1] = uint8(sum>>8), uint8(sum)
+}
+
+func TestLoadReader(t *testing.T) {
+	c, err := LoadReader(bytes.NewReader(testROM(0x8000, 0x00, 0x00)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.(*ROMOnly); !ok {
+		t.Fatalf("Expected a *ROMOnly, got %T", c)
+	}
+	if got := c.ReadROM(0x0100); got != 0x42 {
+		t.Errorf("Expected 0x42 at 0x0100, got 0x%02X", got)
+	}
+}
+
+func TestLoadReaderBadChecksum(t *testing.T) {
+	rom := testROM(0x8000, 0x00, 0x00)
+	rom[0x1234] = 0x01 // Corrupt the dump
+
+	c, err := LoadReader(bytes.NewReader(rom))
+	if !errors.Is(err, ErrChecksum) {
+		t.Errorf("Expected ErrChecksum, got %v", err)
+	}
+	if c == nil {
+		t.Error("Expected the cartridge despite the bad checksum")
+	}
+}
+
+func TestLoadReaderErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		rom  []byte
+		err  error
+	}{
+		{"no header", make([]byte, 0x100), ErrBadROM},
+		{"bad size code", testROM(0x8000, 0x00, 0x09), ErrBadROM},
+		{"truncated", testROM(0x7000, 0x00, 0x00), ErrBadROM},
+		{"size mismatch", testROM(0x8000, 0x00, 0x01), ErrBadROM},
+		{"oversized", testROM(0x800001, 0x00, 0x08), ErrBadROM},
+		{"unsupported type", testROM(0x8000, 0xFC, 0x00), ErrUnsupported}, // Pocket Camera
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadReader(bytes.NewReader(tt.rom)); !errors.Is(err, tt.err) {
+				t.Errorf("Expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.gb")
+	if err := os.WriteFile(path, testROM(0x8000, 0x00, 0x00), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := c.ReadROM(0x0100); got != 0x42 {
+		t.Errorf("Expected 0x42 at 0x0100, got 0x%02X", got)
+	}
+
+	if _, err := LoadFile(filepath.Join(t.TempDir(), "missing.gb")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected a not-exist error, got %v", err)
+	}
+}
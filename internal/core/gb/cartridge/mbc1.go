@@ -0,0 +1,168 @@
+package cartridge
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Size limits of MBC1 cartridges: 7 bank bits of 16KB ROM, and 4
+// banks of 8KB RAM.
+const (
+	romBankSize = 0x4000
+	ramBankSize = 0x2000
+	mbc1MaxROM  = 128 * romBankSize
+	mbc1MaxRAM  = 4 * ramBankSize
+)
+
+// MBC1 is a cartridge with the MBC1 memory bank controller: up to
+// 2MB of ROM and 32KB of RAM, banked through four write-only
+// registers in the ROM area (https://gbdev.io/pandocs/MBC1.html):
+//
+//	0x0000-0x1FFF  RAM enable: 0x0A in the low nibble enables RAM
+//	0x2000-0x3FFF  BANK1: 5-bit ROM bank for 0x4000-0x7FFF
+//	0x4000-0x5FFF  BANK2: 2 more bits, for the ROM or the RAM bank
+//	0x6000-0x7FFF  Mode: 0 = BANK2 only extends the 0x4000 bank,
+//	               1 = it also banks 0x0000-0x3FFF and the RAM
+//
+// BANK1 can never be 0: writing 0 selects bank 1, so that
+// 0x4000-0x7FFF does not normally mirror bank 0. The check looks at
+// all 5 bits before the bank number is cut down to the ROM size, so
+// banks 0x20, 0x40 and 0x60 read as 0x21, 0x41 and 0x61, but writing
+// 0x10 on a 256KB ROM (4 bank bits) still maps bank 0 there.
+//
+// MBC1M multicarts (1MB collections like Mortal Kombat I & II) wire
+// BANK2 one bit lower, leaving BANK1 4 bits wide, so that each 256KB
+// game sees its own bank 0. They share the header of an MBC1, and
+// are told apart by the Nintendo logo of a second game at bank 0x10.
+type MBC1 struct {
+	rom []byte
+	ram []byte
+
+	// multicart is set for MBC1M wiring.
+	multicart bool
+
+	ramEnabled bool
+	bank1      uint8 // 5 bits
+	bank2      uint8 // 2 bits
+	mode       uint8 // 1 bit
+}
+
+// NewMBC1 creates an MBC1 cartridge holding rom, with ramSize bytes
+// of RAM (0 for none). The ROM must be a power-of-two number of 16KB
+// banks, from 32KB to 2MB; RAM is 0, 2KB, 8KB or 32KB. MBC1M wiring
+// is detected from the ROM.
+func NewMBC1(rom []byte, ramSize int) (*MBC1, error) {
+	if len(rom) < 2*romBankSize || len(rom) > mbc1MaxROM || len(rom)&(len(rom)-1) != 0 {
+		return nil, fmt.Errorf("MBC1 ROM must be 32KB to 2MB, a power of two: got %d bytes", len(rom))
+	}
+	switch ramSize {
+	case 0, 0x800, ramBankSize, mbc1MaxRAM:
+	default:
+		return nil, fmt.Errorf("MBC1 RAM must be 0, 2KB, 8KB or 32KB: got %d bytes", ramSize)
+	}
+
+	c := &MBC1{
+		rom:       rom,
+		ram:       make([]byte, ramSize),
+		multicart: isMBC1M(rom),
+		bank1:     1,
+	}
+	for i := range c.ram {
+		c.ram[i] = 0xFF // Uninitialized SRAM
+	}
+	return c, nil
+}
+
+// isMBC1M reports whether rom looks like an MBC1M multicart: 1MB,
+// with the Nintendo logo repeated in the header at bank 0x10, where
+// the second game starts.
+func isMBC1M(rom []byte) bool {
+	const secondGame = 0x10 * romBankSize
+	if len(rom) != 0x100000 {
+		return false
+	}
+	logo := rom[addrLogo:addrTitle]
+	return bytes.Equal(rom[secondGame+addrLogo:secondGame+addrTitle], logo)
+}
+
+// bank2Shift is where BANK2 lands in a ROM bank number.
+func (c *MBC1) bank2Shift() uint {
+	if c.multicart {
+		return 4
+	}
+	return 5
+}
+
+// romBank returns the ROM bank mapped at addr (0x0000-0x7FFF).
+func (c *MBC1) romBank(addr uint16) int {
+	var bank int
+	if addr < romBankSize {
+		// Bank 0, unless mode 1 lets BANK2 select 0x00/0x20/0x40/0x60
+		if c.mode == 1 {
+			bank = int(c.bank2) << c.bank2Shift()
+		}
+	} else {
+		bank1 := c.bank1
+		if c.multicart {
+			bank1 &= 0x0F
+		}
+		bank = int(c.bank2)<<c.bank2Shift() | int(bank1)
+	}
+	return bank & (len(c.rom)/romBankSize - 1) // Unconnected bank bits
+}
+
+func (c *MBC1) ReadROM(addr uint16) uint8 {
+	addr &= 0x7FFF
+	return c.rom[c.romBank(addr)*romBankSize+int(addr)%romBankSize]
+}
+
+// WriteROM programs the MBC1 registers; see MBC1.
+func (c *MBC1) WriteROM(addr uint16, value uint8) {
+	switch addr & 0x7FFF >> 13 {
+	case 0: // 0x0000-0x1FFF
+		c.ramEnabled = value&0x0F == 0x0A
+	case 1: // 0x2000-0x3FFF
+		c.bank1 = value & 0x1F
+		if c.bank1 == 0 {
+			c.bank1 = 1
+		}
+	case 2: // 0x4000-0x5FFF
+		c.bank2 = value & 0x03
+	case 3: // 0x6000-0x7FFF
+		c.mode = value & 0x01
+	}
+}
+
+// ramOffset returns the offset in c.ram for addr (0xA000-0xBFFF), or
+// false if RAM is disabled or absent.
+func (c *MBC1) ramOffset(addr uint16) (int, bool) {
+	if !c.ramEnabled || len(c.ram) == 0 {
+		return 0, false
+	}
+	offset := int(addr-0xA000) % ramBankSize
+	if c.mode == 1 {
+		offset += int(c.bank2) * ramBankSize
+	}
+	return offset % len(c.ram), true // Smaller RAMs are mirrored
+}
+
+// ReadRAM returns 0xFF while RAM is disabled, as the chip is then
+// disconnected from the bus.
+func (c *MBC1) ReadRAM(addr uint16) uint8 {
+	if offset, ok := c.ramOffset(addr); ok {
+		return c.ram[offset]
+	}
+	return 0xFF
+}
+
+// WriteRAM is ignored while RAM is disabled.
+func (c *MBC1) WriteRAM(addr uint16, value uint8) {
+	if offset, ok := c.ramOffset(addr); ok {
+		c.ram[offset] = value
+	}
+}
+
+func (c *MBC1) RAM() []byte { return append([]byte(nil), c.ram...) }
+
+// LoadRAM copies as much of data as the RAM holds.
+func (c *MBC1) LoadRAM(data []byte) { copy(c.ram, data) }
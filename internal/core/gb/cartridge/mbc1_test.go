@@ -0,0 +1,191 @@
+package cartridge
+
+import (
+	"bytes"
+	"testing"
+)
+
+// bankedROM returns a ROM of banks 16KB banks, each filled with its
+// own bank number.
+func bankedROM(banks int) []byte {
+	rom := make([]byte, banks*romBankSize)
+	for i := range rom {
+		rom[i] = uint8(i / romBankSize)
+	}
+	return rom
+}
+
+func newMBC1(t *testing.T, rom []byte, ramSize int) *MBC1 {
+	t.Helper()
+	c, err := NewMBC1(rom, ramSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// expectBanks checks which ROM banks are mapped at 0x0000 and 0x4000.
+func expectBanks(t *testing.T, c *MBC1, low, high uint8) {
+	t.Helper()
+	if got := c.ReadROM(0x0000); got != low {
+		t.Errorf("Expected bank 0x%02X at 0x0000, got 0x%02X", low, got)
+	}
+	if got := c.ReadROM(0x7FFF); got != high {
+		t.Errorf("Expected bank 0x%02X at 0x4000, got 0x%02X", high, got)
+	}
+}
+
+func TestMBC1ROMBanking(t *testing.T) {
+	c := newMBC1(t, bankedROM(128), 0) // 2MB
+	expectBanks(t, c, 0x00, 0x01)
+
+	c.WriteROM(0x2000, 0x05)
+	expectBanks(t, c, 0x00, 0x05)
+
+	// BANK1 = 0 selects bank 1, even with BANK2 set
+	c.WriteROM(0x3FFF, 0x00)
+	c.WriteROM(0x4000, 0x01)
+	expectBanks(t, c, 0x00, 0x21)
+
+	// Only the low 5 bits of BANK1 and 2 bits of BANK2 exist
+	c.WriteROM(0x2000, 0xE3)
+	c.WriteROM(0x5FFF, 0xFE)
+	expectBanks(t, c, 0x00, 0x43)
+
+	// Mode 1: BANK2 also selects the bank at 0x0000
+	c.WriteROM(0x6000, 0x01)
+	expectBanks(t, c, 0x40, 0x43)
+}
+
+func TestMBC1SmallROM(t *testing.T) {
+	c := newMBC1(t, bankedROM(16), 0) // 256KB: 4 bank bits
+
+	// 0x10 passes the zero check, then loses its unconnected bit
+	c.WriteROM(0x2000, 0x10)
+	expectBanks(t, c, 0x00, 0x00)
+
+	// BANK2 is not connected either, even in mode 1
+	c.WriteROM(0x2000, 0x03)
+	c.WriteROM(0x4000, 0x03)
+	c.WriteROM(0x6000, 0x01)
+	expectBanks(t, c, 0x00, 0x03)
+}
+
+func TestMBC1RAM(t *testing.T) {
+	c := newMBC1(t, bankedROM(4), mbc1MaxRAM)
+
+	// Disabled at power-on: reads are open bus, writes are lost
+	c.WriteRAM(0xA000, 0x42)
+	if got := c.ReadRAM(0xA000); got != 0xFF {
+		t.Errorf("Expected 0xFF from disabled RAM, got 0x%02X", got)
+	}
+
+	c.WriteROM(0x0000, 0x1A) // Only the low nibble counts
+	c.WriteRAM(0xA000, 0x42)
+	if got := c.ReadRAM(0xA000); got != 0x42 {
+		t.Errorf("Expected 0x42 from enabled RAM, got 0x%02X", got)
+	}
+
+	// Mode 1 banks the RAM with BANK2; mode 0 always uses bank 0
+	c.WriteROM(0x4000, 0x02)
+	c.WriteROM(0x6000, 0x01)
+	c.WriteRAM(0xBFFF, 0x99)
+	if got := c.ReadRAM(0xA000); got != 0xFF {
+		t.Errorf("Expected fresh RAM in bank 2, got 0x%02X", got)
+	}
+	c.WriteROM(0x6000, 0x00)
+	if got := c.ReadRAM(0xA000); got != 0x42 {
+		t.Errorf("Expected bank 0 in mode 0, got 0x%02X", got)
+	}
+	if c.ram[2*ramBankSize+0x1FFF] != 0x99 {
+		t.Error("Expected the mode 1 write in RAM bank 2")
+	}
+
+	c.WriteROM(0x1FFF, 0x00)
+	if got := c.ReadRAM(0xA000); got != 0xFF {
+		t.Errorf("Expected 0xFF after disabling RAM, got 0x%02X", got)
+	}
+}
+
+func TestMBC1NoRAM(t *testing.T) {
+	c := newMBC1(t, bankedROM(4), 0)
+	c.WriteROM(0x0000, 0x0A)
+	c.WriteRAM(0xA000, 0x42)
+	if got := c.ReadRAM(0xA000); got != 0xFF {
+		t.Errorf("Expected 0xFF without RAM, got 0x%02X", got)
+	}
+}
+
+func TestMBC1M(t *testing.T) {
+	rom := bankedROM(64) // 1MB
+	logo := bytes.Repeat([]byte{0xCE}, addrTitle-addrLogo)
+	copy(rom[addrLogo:], logo)
+	copy(rom[0x10*romBankSize+addrLogo:], logo)
+	c := newMBC1(t, rom, 0)
+	if !c.multicart {
+		t.Fatal("Expected MBC1M wiring to be detected")
+	}
+
+	// BANK2 lands on bits 4-5, and BANK1 loses its bit 4
+	c.WriteROM(0x2000, 0x12)
+	c.WriteROM(0x4000, 0x01)
+	expectBanks(t, c, 0x00, 0x12)
+
+	// Each game gets its own bank 0 in mode 1
+	c.WriteROM(0x6000, 0x01)
+	c.WriteROM(0x4000, 0x02)
+	expectBanks(t, c, 0x20, 0x22)
+
+	// Without the second logo, it is a plain 1MB MBC1
+	rom[0x10*romBankSize+addrLogo] = 0x00
+	if newMBC1(t, rom, 0).multicart {
+		t.Error("Expected a plain MBC1 without the second logo")
+	}
+}
+
+func TestNewMBC1Errors(t *testing.T) {
+	for _, tt := range []struct {
+		romSize, ramSize int
+	}{
+		{romBankSize, 0},      // Too small
+		{3 * romBankSize, 0},  // Not a power of two
+		{mbc1MaxROM * 2, 0},   // Too large
+		{2 * romBankSize, 64}, // Unknown RAM size
+	} {
+		if _, err := NewMBC1(make([]byte, tt.romSize), tt.ramSize); err == nil {
+			t.Errorf("Expected an error for %d bytes of ROM and %d of RAM", tt.romSize, tt.ramSize)
+		}
+	}
+}
+
+func TestLoadReaderMBC1(t *testing.T) {
+	rom := testROM(0x10000, 0x03, 0x01) // MBC1+RAM+BATTERY, 64KB
+	rom[addrRAMSize] = 0x03             // 32KB
+	setChecksums(rom)
+	c, err := LoadReader(bytes.NewReader(rom))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mbc1, ok := c.(*MBC1)
+	if !ok {
+		t.Fatalf("Expected an *MBC1, got %T", c)
+	}
+	if len(mbc1.ram) != mbc1MaxRAM {
+		t.Errorf("Expected 32KB of RAM, got %d bytes", len(mbc1.ram))
+	}
+}
+
+func TestMBC1RAMBacked(t *testing.T) {
+	c := newMBC1(t, bankedROM(4), ramBankSize)
+	c.LoadRAM([]byte{0x12, 0x34})
+	c.WriteROM(0x0000, 0x0A)
+	if got := c.ReadRAM(0xA001); got != 0x34 {
+		t.Errorf("Expected the loaded 0x34, got 0x%02X", got)
+	}
+
+	ram := c.RAM()
+	ram[0] = 0x99 // A copy: the cartridge is unchanged
+	if len(ram) != ramBankSize || c.ReadRAM(0xA000) != 0x12 {
+		t.Errorf("Expected an 8KB copy of the RAM, got %d bytes", len(ram))
+	}
+}
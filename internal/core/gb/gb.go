@@ -6,31 +6,37 @@ package gb
 import (
 	"github.com/antoniosarro/yagbc/internal/core/gb/memory"
 	"github.com/antoniosarro/yagbc/internal/core/gb/processor"
+	"github.com/antoniosarro/yagbc/internal/core/gb/serial"
 )
 
 // GameBoy represents the entire Game Boy system.
 // It ties together all hardware components (CPU, memory, PPU, etc.)
 type GameBoy struct {
 	CPU    *processor.CPU
-	Memory memory.Memory
+	Memory *memory.MMU // Insert a cartridge here to run it
+	Serial *serial.Serial
 
 	// TODO: Add more components (PPU, APU, Timers, etc.)
 }
 
-// NewGameBoy creates and initializes a new Game Boy system.
+// NewGameBoy creates and initializes a new Game Boy system, with an
+// empty cartridge slot.
 func NewGameBoy() *GameBoy {
-	gb := &GameBoy{}
-
-	// TODO: Initialize memory
-	// TODO: Initialize CPU with memory reference
-
+	mem := memory.NewMMU()
+	gb := &GameBoy{
+		CPU:    processor.NewCPU(mem),
+		Memory: mem,
+	}
+	gb.Serial = serial.New(func() { gb.CPU.RequestInterrupt(processor.InterruptSerial) })
+	mem.Attach(memory.ComponentSerial, gb.Serial)
 	return gb
 }
 
-// Step executes one machine cycle of the Game Boy.
-// Returns the number of cycles that elapsed.
-func (gb *GameBoy) Step() int {
-	// TODO: Step the CPU
-	// TODO: Step other components (PPU, timers, etc.)
-	return 4 // Placeholder
+// Step executes one CPU instruction on the Game Boy.
+// Returns the number of cycles that elapsed, and any error raised by
+// the CPU (see processor.CPU.Step).
+func (gb *GameBoy) Step() (int, error) {
+	cycles, err := gb.CPU.Step()
+	// TODO: Step other components (PPU, timers, etc.) by cycles
+	return cycles, err
 }
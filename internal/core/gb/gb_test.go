@@ -0,0 +1,33 @@
+package gb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/antoniosarro/yagbc/internal/core/gb/cartridge"
+	"github.com/antoniosarro/yagbc/internal/core/gb/processor"
+)
+
+func TestStep(t *testing.T) {
+	gb := NewGameBoy()
+	rom, err := cartridge.NewROMOnly([]byte{0x00, 0xD3}) // NOP, illegal
+	if err != nil {
+		t.Fatal(err)
+	}
+	gb.Memory.InsertCartridge(rom)
+
+	cycles, err := gb.Step()
+	if cycles != 4 || err != nil {
+		t.Fatalf("NOP: expected 4 cycles and no error, got %d, %v", cycles, err)
+	}
+
+	// The lock-up reaches the caller through GameBoy.Step
+	_, err = gb.Step()
+	var illegal *processor.IllegalOpcodeError
+	if !errors.As(err, &illegal) || illegal.Opcode != 0xD3 || illegal.PC != 0x0001 {
+		t.Errorf("Expected illegal opcode 0xD3 at 0x0001, got %v", err)
+	}
+	if _, err := gb.Step(); !errors.Is(err, processor.ErrLocked) {
+		t.Errorf("Expected ErrLocked after lock-up, got %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+package gb
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/antoniosarro/yagbc/internal/core/gb/memory"
+)
+
+// InitValue overrides the value a CPU register or I/O register starts
+// with, to reproduce a specific hardware revision or satisfy a test
+// ROM that checks the boot state.
+type InitValue struct {
+	Name  string // CPU register (A, F, ..., AF, ..., SP, PC) or I/O register name (DIV, LCDC...)
+	Value uint16
+}
+
+// cpuRegisterBits gives the width of every CPU register an InitValue
+// can name.
+var cpuRegisterBits = map[string]int{
+	"A": 8, "F": 8, "B": 8, "C": 8, "D": 8, "E": 8, "H": 8, "L": 8,
+	"AF": 16, "BC": 16, "DE": 16, "HL": 16, "SP": 16, "PC": 16,
+}
+
+// ParseInitValues parses a comma-separated list of NAME=VALUE
+// overrides, e.g. "A=0x11,DIV=0xAB". Names are case-insensitive, and
+// values are decimal (even with a leading 0), or hex with a 0x prefix.
+func ParseInitValues(spec string) ([]InitValue, error) {
+	var values []InitValue
+	for _, field := range strings.Split(spec, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(field), "=")
+		if !ok {
+			return nil, fmt.Errorf("init value %q: expected NAME=VALUE", field)
+		}
+		name = strings.ToUpper(strings.TrimSpace(name))
+
+		bits, ok := cpuRegisterBits[name]
+		if !ok {
+			if _, ok := memory.LookupIORegisterName(name); !ok {
+				return nil, fmt.Errorf("init value %q: no register called %s", field, name)
+			}
+			bits = 8
+		}
+		value, base := strings.TrimSpace(value), 10
+		if strings.HasPrefix(value, "0x") || strings.HasPrefix(value, "0X") {
+			value, base = value[2:], 16
+		}
+		v, err := strconv.ParseUint(value, base, bits)
+		if err != nil {
+			return nil, fmt.Errorf("init value %q: not a %d-bit value", field, bits)
+		}
+		values = append(values, InitValue{Name: name, Value: uint16(v)})
+	}
+	return values, nil
+}
+
+// ApplyInitValues sets each register to its value, in order. Call it
+// after CPU.Reset, which would undo the CPU registers. The low 4 bits
+// of F do not exist and stay 0; I/O registers take any value, even in
+// read-only bits (see memory.MMU.SetIORegister).
+func (gb *GameBoy) ApplyInitValues(values []InitValue) {
+	r := gb.CPU.Registers
+	for _, iv := range values {
+		v8 := uint8(iv.Value)
+		switch iv.Name {
+		case "A":
+			r.A = v8
+		case "F":
+			r.F = v8 & 0xF0
+		case "B":
+			r.B = v8
+		case "C":
+			r.C = v8
+		case "D":
+			r.D = v8
+		case "E":
+			r.E = v8
+		case "H":
+			r.H = v8
+		case "L":
+			r.L = v8
+		case "AF":
+			r.SetAF(iv.Value)
+		case "BC":
+			r.SetBC(iv.Value)
+		case "DE":
+			r.SetDE(iv.Value)
+		case "HL":
+			r.SetHL(iv.Value)
+		case "SP":
+			r.SP = iv.Value
+		case "PC":
+			r.PC = iv.Value
+		default:
+			if reg, ok := memory.LookupIORegisterName(iv.Name); ok {
+				gb.Memory.SetIORegister(reg.Addr, v8)
+			}
+		}
+	}
+}
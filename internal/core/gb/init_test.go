@@ -0,0 +1,54 @@
+package gb
+
+import (
+	"testing"
+
+	"github.com/antoniosarro/yagbc/internal/core/gb/processor"
+)
+
+func TestParseInitValues(t *testing.T) {
+	values, err := ParseInitValues("A=0x11, div=0xAB,SP=65534,B=010,C=0X1F")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []InitValue{{"A", 0x11}, {"DIV", 0xAB}, {"SP", 0xFFFE}, {"B", 10}, {"C", 0x1F}}
+	if len(values) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, values)
+	}
+	for i := range want {
+		if values[i] != want[i] {
+			t.Errorf("Expected %v, got %v", want[i], values[i])
+		}
+	}
+
+	for _, spec := range []string{"A", "Q=1", "A=0x100", "DIV=0x100", "HL=0x10000", "A=x", "A=0b1", "A=0o7", "A=0x"} {
+		if _, err := ParseInitValues(spec); err == nil {
+			t.Errorf("%q: expected an error", spec)
+		}
+	}
+}
+
+func TestApplyInitValues(t *testing.T) {
+	gb := NewGameBoy()
+	gb.CPU.Reset(processor.ModelDMG)
+
+	values, err := ParseInitValues("A=0x11,F=0xFF,DE=0xFF56,PC=0x0150,DIV=0xAB,LY=0x90")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gb.ApplyInitValues(values)
+
+	r := gb.CPU.Registers
+	if r.A != 0x11 || r.F != 0xF0 || r.DE() != 0xFF56 || r.PC != 0x0150 {
+		t.Errorf("Expected A=0x11 F=0xF0 DE=0xFF56 PC=0x0150, got %+v", *r)
+	}
+	if r.C != 0x13 || r.SP != 0xFFFE {
+		t.Errorf("Expected the other registers left at their boot values, got %+v", *r)
+	}
+	if got := gb.Memory.Read(0xFF04); got != 0xAB {
+		t.Errorf("DIV: expected 0xAB, got 0x%02X", got)
+	}
+	if got := gb.Memory.Read(0xFF44); got != 0x90 {
+		t.Errorf("LY: expected 0x90, got 0x%02X", got)
+	}
+}
@@ -0,0 +1,135 @@
+package memory
+
+import "strings"
+
+// Component identifies the hardware block that owns an I/O register
+// (or, for the PPU, a region of the memory map).
+type Component string
+
+const (
+	ComponentJoypad     Component = "Joypad"
+	ComponentSerial     Component = "Serial"
+	ComponentTimer      Component = "Timer"
+	ComponentInterrupts Component = "Interrupts"
+	ComponentAPU        Component = "APU"
+	ComponentPPU        Component = "PPU"
+)
+
+// IORegister describes one memory-mapped I/O register (0xFF00-0xFF7F,
+// and IE at 0xFFFF).
+//
+// Bits outside Readable do not exist (or are write-only) and read as
+// 1. Bits outside Writable are ignored on writes: they are either
+// unused or driven by the hardware itself (e.g. the STAT mode bits).
+type IORegister struct {
+	Addr     uint16
+	Name     string
+	Readable uint8 // Bits that read back the stored value
+	Writable uint8 // Bits that CPU writes change
+	Reset    uint8 // Value read after the DMG boot ROM has run
+	Owner    Component
+}
+
+// Read returns what the CPU sees when the register holds stored.
+func (r IORegister) Read(stored uint8) uint8 {
+	return stored | ^r.Readable
+}
+
+// Write returns the new stored value after the CPU writes value to
+// a register holding stored.
+func (r IORegister) Write(stored, value uint8) uint8 {
+	return stored&^r.Writable | value&r.Writable
+}
+
+// IORegisters lists the DMG I/O registers in address order
+// (https://gbdev.io/pandocs/Hardware_Reg_List.html). It is the single
+// source of the bit masks used by memory implementations and of the
+// register list shown by debuggers.
+var IORegisters = []IORegister{
+	{0xFF00, "P1", 0x3F, 0x30, 0xCF, ComponentJoypad},
+	{0xFF01, "SB", 0xFF, 0xFF, 0x00, ComponentSerial},
+	{0xFF02, "SC", 0x81, 0x81, 0x7E, ComponentSerial},
+	{0xFF04, "DIV", 0xFF, 0xFF, 0xAB, ComponentTimer}, // Any write clears it
+	{0xFF05, "TIMA", 0xFF, 0xFF, 0x00, ComponentTimer},
+	{0xFF06, "TMA", 0xFF, 0xFF, 0x00, ComponentTimer},
+	{0xFF07, "TAC", 0x07, 0x07, 0xF8, ComponentTimer},
+	{AddrIF, "IF", 0x1F, 0x1F, 0xE1, ComponentInterrupts},
+	{0xFF10, "NR10", 0x7F, 0x7F, 0x80, ComponentAPU},
+	{0xFF11, "NR11", 0xC0, 0xFF, 0xBF, ComponentAPU},
+	{0xFF12, "NR12", 0xFF, 0xFF, 0xF3, ComponentAPU},
+	{0xFF13, "NR13", 0x00, 0xFF, 0xFF, ComponentAPU},
+	{0xFF14, "NR14", 0x40, 0xC7, 0xBF, ComponentAPU},
+	{0xFF16, "NR21", 0xC0, 0xFF, 0x3F, ComponentAPU},
+	{0xFF17, "NR22", 0xFF, 0xFF, 0x00, ComponentAPU},
+	{0xFF18, "NR23", 0x00, 0xFF, 0xFF, ComponentAPU},
+	{0xFF19, "NR24", 0x40, 0xC7, 0xBF, ComponentAPU},
+	{0xFF1A, "NR30", 0x80, 0x80, 0x7F, ComponentAPU},
+	{0xFF1B, "NR31", 0x00, 0xFF, 0xFF, ComponentAPU},
+	{0xFF1C, "NR32", 0x60, 0x60, 0x9F, ComponentAPU},
+	{0xFF1D, "NR33", 0x00, 0xFF, 0xFF, ComponentAPU},
+	{0xFF1E, "NR34", 0x40, 0xC7, 0xBF, ComponentAPU},
+	{0xFF20, "NR41", 0x00, 0x3F, 0xFF, ComponentAPU},
+	{0xFF21, "NR42", 0xFF, 0xFF, 0x00, ComponentAPU},
+	{0xFF22, "NR43", 0xFF, 0xFF, 0x00, ComponentAPU},
+	{0xFF23, "NR44", 0x40, 0xC0, 0xBF, ComponentAPU},
+	{0xFF24, "NR50", 0xFF, 0xFF, 0x77, ComponentAPU},
+	{0xFF25, "NR51", 0xFF, 0xFF, 0xF3, ComponentAPU},
+	{0xFF26, "NR52", 0x8F, 0x80, 0xF1, ComponentAPU}, // Bits 0-3: channel status
+	{0xFF30, "WAVE0", 0xFF, 0xFF, 0x00, ComponentAPU},
+	{0xFF31, "WAVE1", 0xFF, 0xFF, 0x00, ComponentAPU},
+	{0xFF32, "WAVE2", 0xFF, 0xFF, 0x00, ComponentAPU},
+	{0xFF33, "WAVE3", 0xFF, 0xFF, 0x00, ComponentAPU},
+	{0xFF34, "WAVE4", 0xFF, 0xFF, 0x00, ComponentAPU},
+	{0xFF35, "WAVE5", 0xFF, 0xFF, 0x00, ComponentAPU},
+	{0xFF36, "WAVE6", 0xFF, 0xFF, 0x00, ComponentAPU},
+	{0xFF37, "WAVE7", 0xFF, 0xFF, 0x00, ComponentAPU},
+	{0xFF38, "WAVE8", 0xFF, 0xFF, 0x00, ComponentAPU},
+	{0xFF39, "WAVE9", 0xFF, 0xFF, 0x00, ComponentAPU},
+	{0xFF3A, "WAVEA", 0xFF, 0xFF, 0x00, ComponentAPU},
+	{0xFF3B, "WAVEB", 0xFF, 0xFF, 0x00, ComponentAPU},
+	{0xFF3C, "WAVEC", 0xFF, 0xFF, 0x00, ComponentAPU},
+	{0xFF3D, "WAVED", 0xFF, 0xFF, 0x00, ComponentAPU},
+	{0xFF3E, "WAVEE", 0xFF, 0xFF, 0x00, ComponentAPU},
+	{0xFF3F, "WAVEF", 0xFF, 0xFF, 0x00, ComponentAPU},
+	{0xFF40, "LCDC", 0xFF, 0xFF, 0x91, ComponentPPU},
+	{0xFF41, "STAT", 0x7F, 0x78, 0x85, ComponentPPU}, // Bits 0-2: mode and LYC=LY
+	{0xFF42, "SCY", 0xFF, 0xFF, 0x00, ComponentPPU},
+	{0xFF43, "SCX", 0xFF, 0xFF, 0x00, ComponentPPU},
+	{0xFF44, "LY", 0xFF, 0x00, 0x00, ComponentPPU},
+	{0xFF45, "LYC", 0xFF, 0xFF, 0x00, ComponentPPU},
+	{0xFF46, "DMA", 0xFF, 0xFF, 0xFF, ComponentPPU},
+	{0xFF47, "BGP", 0xFF, 0xFF, 0xFC, ComponentPPU},
+	{0xFF48, "OBP0", 0xFF, 0xFF, 0xFF, ComponentPPU}, // Not set by the boot ROM
+	{0xFF49, "OBP1", 0xFF, 0xFF, 0xFF, ComponentPPU}, // Not set by the boot ROM
+	{0xFF4A, "WY", 0xFF, 0xFF, 0x00, ComponentPPU},
+	{0xFF4B, "WX", 0xFF, 0xFF, 0x00, ComponentPPU},
+	{AddrIE, "IE", 0xFF, 0xFF, 0x00, ComponentInterrupts},
+}
+
+// ioRegisterIndex maps addr-0xFF00 to the register's position in
+// IORegisters, plus one (0 = no register at that address).
+var ioRegisterIndex = func() (index [0x100]uint8) {
+	for i, r := range IORegisters {
+		index[r.Addr-0xFF00] = uint8(i + 1)
+	}
+	return index
+}()
+
+// LookupIORegister returns the I/O register at addr, if any.
+func LookupIORegister(addr uint16) (IORegister, bool) {
+	if addr < 0xFF00 || ioRegisterIndex[addr-0xFF00] == 0 {
+		return IORegister{}, false
+	}
+	return IORegisters[ioRegisterIndex[addr-0xFF00]-1], true
+}
+
+// LookupIORegisterName returns the I/O register called name (e.g.
+// "DIV"), ignoring case, if any.
+func LookupIORegisterName(name string) (IORegister, bool) {
+	for _, r := range IORegisters {
+		if strings.EqualFold(r.Name, name) {
+			return r, true
+		}
+	}
+	return IORegister{}, false
+}
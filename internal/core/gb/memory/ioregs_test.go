@@ -0,0 +1,64 @@
+package memory
+
+import "testing"
+
+func TestIORegisterMasks(t *testing.T) {
+	stat, ok := LookupIORegister(0xFF41)
+	if !ok || stat.Name != "STAT" || stat.Owner != ComponentPPU {
+		t.Fatalf("Expected STAT owned by the PPU, got %+v (found=%v)", stat, ok)
+	}
+
+	// Bit 7 is unused and reads as 1; bits 0-2 are not writable
+	stored := stat.Write(0x02, 0xFF)
+	if stored != 0x7A {
+		t.Errorf("STAT write: expected stored 0x7A, got 0x%02X", stored)
+	}
+	if got := stat.Read(stored); got != 0xFA {
+		t.Errorf("STAT read: expected 0xFA, got 0x%02X", got)
+	}
+}
+
+func TestIORegisterResetValues(t *testing.T) {
+	// Reset values are read values, so they agree with the read masks
+	for _, r := range IORegisters {
+		if r.Read(r.Reset) != r.Reset {
+			t.Errorf("%s: reset value 0x%02X has unreadable bits cleared (mask 0x%02X)", r.Name, r.Reset, r.Readable)
+		}
+	}
+}
+
+func TestIORegisterTable(t *testing.T) {
+	names := map[string]bool{}
+	for i, r := range IORegisters {
+		if r.Addr < 0xFF00 || (r.Addr >= 0xFF80 && r.Addr != AddrIE) {
+			t.Errorf("%s: 0x%04X is not an I/O address", r.Name, r.Addr)
+		}
+		if i > 0 && r.Addr <= IORegisters[i-1].Addr {
+			t.Errorf("%s: table is not in address order", r.Name)
+		}
+		if names[r.Name] {
+			t.Errorf("%s: duplicate name", r.Name)
+		}
+		names[r.Name] = true
+
+		if got, ok := LookupIORegister(r.Addr); !ok || got != r {
+			t.Errorf("LookupIORegister(0x%04X): expected %s, got %+v", r.Addr, r.Name, got)
+		}
+	}
+
+	for _, addr := range []uint16{0x0000, 0xFF03, 0xFF7F, 0xFF80} {
+		if r, ok := LookupIORegister(addr); ok {
+			t.Errorf("LookupIORegister(0x%04X): expected no register, got %s", addr, r.Name)
+		}
+	}
+}
+
+func TestLookupIORegisterName(t *testing.T) {
+	r, ok := LookupIORegisterName("div")
+	if !ok || r.Addr != 0xFF04 {
+		t.Errorf("Expected DIV at 0xFF04, got %+v, %v", r, ok)
+	}
+	if _, ok := LookupIORegisterName("NOPE"); ok {
+		t.Error("Expected no register called NOPE")
+	}
+}
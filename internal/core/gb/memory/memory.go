@@ -1,7 +1,7 @@
 // Package memory implements the Game Boy memory system.
 package memory
 
-import "fmt"
+import "github.com/antoniosarro/yagbc/internal/core/gb/cartridge"
 
 // Game Boy Memory Map (16-bit address space = 64KB)
 
@@ -11,17 +11,32 @@ type Memory interface {
 	Write(addr uint16, val uint8)
 }
 
+// Interrupt register addresses.
+const (
+	AddrIF uint16 = 0xFF0F // Interrupt Flag: which interrupts are requested
+	AddrIE uint16 = 0xFFFF // Interrupt Enable: which interrupts are allowed
+)
+
+// The I/O registers backed by BasicMemory. The others are unmapped
+// until the components owning them exist.
+var (
+	registerIF, _ = LookupIORegister(AddrIF)
+	registerIE, _ = LookupIORegister(AddrIE)
+)
+
 // BasicMemory is a simple implementation of the Game Boy memory system.
 // This is a simplified version for learning - it only includes:
-//   - ROM area (0x0000-0x7FFF): 32KB
+//   - The cartridge (0x0000-0x7FFF and 0xA000-0xBFFF): 32KB of ROM
+//     and no external RAM, unless another cartridge is inserted
 //   - WRAM (0xC000-0xDFFF): 8KB
 //   - HRAM (0xFF80-0xFFFE): 127 bytes
+//   - IF (0xFF0F) and IE (0xFFFF) interrupt registers
 //
 // Other regions will return 0xFF (common behavior for unmapped memory).
 type BasicMemory struct {
-	// ROM - Read Only Memory (game code)
-	// In a real Game Boy, this comes from the cartridge
-	rom [0x8000]uint8 // 32KB: 0x0000-0x7FFF
+	// The cartridge holds the ROM (game code), which the CPU can
+	// only read
+	cartridge cartridge.Cartridge
 
 	// WRAM - Work RAM (general purpose RAM)
 	wram [0x2000]uint8 // 8KB: 0xC000-0xDFFF
@@ -29,13 +44,19 @@ type BasicMemory struct {
 	// HRAM - High RAM (fast RAM on CPU die)
 	hram [0x7F]uint8 // 127 bytes: 0xFF80-0xFFFE
 
+	// Interrupt registers (needed by HALT to detect pending interrupts),
+	// masked as described by IORegisters
+	interruptFlag   uint8 // IF: 0xFF0F
+	interruptEnable uint8 // IE: 0xFFFF
+
 	// TODO Phase 2: Add VRAM, OAM, I/O registers, etc.
 }
 
-// NewBasicMemory creates a new BasicMemory instance.
-// All memory is initialized to 0x00.
+// NewBasicMemory creates a new BasicMemory instance, with an empty
+// ROM-only cartridge inserted. All memory is initialized to 0x00.
 func NewBasicMemory() *BasicMemory {
-	return &BasicMemory{}
+	empty, _ := cartridge.NewROMOnly(nil)
+	return &BasicMemory{cartridge: empty}
 	// Arrays are zero-initialized in Go, so all bytes start at 0x00
 }
 
@@ -43,7 +64,11 @@ func (m *BasicMemory) Read(addr uint16) uint8 {
 	switch {
 	// ROM Area: 0x0000 - 0x7FFF (32KB)
 	case addr <= 0x7FFF:
-		return m.rom[addr]
+		return m.cartridge.ReadROM(addr)
+
+	// External RAM: 0xA000 - 0xBFFF (on the cartridge, if any)
+	case addr >= 0xA000 && addr <= 0xBFFF:
+		return m.cartridge.ReadRAM(addr)
 
 	// WRAM: 0xC000 - 0xDFFF (8KB)
 	case addr >= 0xC000 && addr <= 0xDFFF:
@@ -60,6 +85,14 @@ func (m *BasicMemory) Read(addr uint16) uint8 {
 	case addr >= 0xFF80 && addr <= 0xFFFE:
 		return m.hram[addr-0xFF80]
 
+	// IF: the top 3 bits are unused and always read as 1
+	case addr == AddrIF:
+		return registerIF.Read(m.interruptFlag)
+
+	// IE: all 8 bits are readable and writable
+	case addr == AddrIE:
+		return registerIE.Read(m.interruptEnable)
+
 	// Unmapped regions return 0xFF
 	// This is typical behavior when reading from empty space
 	default:
@@ -72,10 +105,14 @@ func (m *BasicMemory) Read(addr uint16) uint8 {
 func (m *BasicMemory) Write(addr uint16, val uint8) {
 	switch {
 	// ROM Area: 0x0000 - 0x7FFF
-	// ROM is READ-ONLY, but we allow writes for loading programs
-	// In a real Game Boy, writes here control memory banking
+	// ROM is READ-ONLY: writes here control memory banking, and
+	// leave the ROM itself unchanged (load programs with LoadROM)
 	case addr <= 0x7FFF:
-		m.rom[addr] = val
+		m.cartridge.WriteROM(addr, val)
+
+	// External RAM: 0xA000 - 0xBFFF
+	case addr >= 0xA000 && addr <= 0xBFFF:
+		m.cartridge.WriteRAM(addr, val)
 
 	// WRAM: 0xC000 - 0xDFFF (8KB)
 	case addr >= 0xC000 && addr <= 0xDFFF:
@@ -89,6 +126,14 @@ func (m *BasicMemory) Write(addr uint16, val uint8) {
 	case addr >= 0xFF80 && addr <= 0xFFFE:
 		m.hram[addr-0xFF80] = val
 
+	// IF: only the 5 interrupt bits are stored
+	case addr == AddrIF:
+		m.interruptFlag = registerIF.Write(m.interruptFlag, val)
+
+	// IE
+	case addr == AddrIE:
+		m.interruptEnable = registerIE.Write(m.interruptEnable, val)
+
 	// Writes to unmapped regions are ignored
 	// (In a real emulator, we might log these for debugging)
 	default:
@@ -96,23 +141,20 @@ func (m *BasicMemory) Write(addr uint16, val uint8) {
 	}
 }
 
-// LoadROM loads a byte slice into ROM starting at address 0x0000.
-// This is a helper function for testing - in Phase 2, we'll load
-// actual ROM files from cartridges.
+// LoadROM inserts a ROM-only cartridge holding data, mapped from
+// address 0x0000. This is a helper for test programs and small ROMs;
+// larger cartridges need an MBC (see InsertCartridge).
 func (m *BasicMemory) LoadROM(data []byte) error {
-	if len(data) > len(m.rom) {
-		return fmt.Errorf("ROM data too large: %d bytes (max %d)", len(data), len(m.rom))
+	c, err := cartridge.NewROMOnly(data)
+	if err != nil {
+		return err
 	}
-
-	copy(m.rom[:], data)
+	m.cartridge = c
 	return nil
 }
 
-// DirectWrite writes directly to ROM without bounds checking.
-// ONLY use this for setting up test programs!
-// In a real Game Boy, ROM comes from the cartridge and can't be written.
-func (m *BasicMemory) DirectWrite(addr uint16, val uint8) {
-	if addr <= 0x7FFF {
-		m.rom[addr] = val
-	}
+// InsertCartridge replaces the cartridge mapped at 0x0000-0x7FFF and
+// 0xA000-0xBFFF.
+func (m *BasicMemory) InsertCartridge(c cartridge.Cartridge) {
+	m.cartridge = c
 }
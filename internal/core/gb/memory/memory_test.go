@@ -4,15 +4,21 @@ import "testing"
 
 func TestBasicMemoryROM(t *testing.T) {
 	mem := NewBasicMemory()
+	mem.LoadROM([]byte{0x00, 0x42})
 
-	// Write to ROM area
-	mem.Write(0x0100, 0x42)
+	// Writing to the ROM area does not change the ROM
+	mem.Write(0x0001, 0x99)
 
-	// Read it back
-	val := mem.Read(0x0100)
+	val := mem.Read(0x0001)
 	if val != 0x42 {
 		t.Errorf("Expected 0x42, got 0x%02X", val)
 	}
+
+	// No external RAM on a ROM-only cartridge
+	mem.Write(0xA000, 0x12)
+	if got := mem.Read(0xA000); got != 0xFF {
+		t.Errorf("Expected 0xFF without external RAM, got 0x%02X", got)
+	}
 }
 
 func TestBasicMemoryWRAM(t *testing.T) {
@@ -101,3 +107,30 @@ func TestLoadROM(t *testing.T) {
 		}
 	}
 }
+
+func TestInterruptRegisters(t *testing.T) {
+	mem := NewBasicMemory()
+
+	// Both registers start cleared; IF's unused top bits read as 1
+	if val := mem.Read(AddrIF); val != 0xE0 {
+		t.Errorf("IF at power-on: expected 0xE0, got 0x%02X", val)
+	}
+	if val := mem.Read(AddrIE); val != 0x00 {
+		t.Errorf("IE at power-on: expected 0x00, got 0x%02X", val)
+	}
+
+	mem.Write(AddrIF, 0xFF)
+	if val := mem.Read(AddrIF); val != 0xFF {
+		t.Errorf("IF: expected 0xFF, got 0x%02X", val)
+	}
+	mem.Write(AddrIF, 0x05)
+	if val := mem.Read(AddrIF); val != 0xE5 {
+		t.Errorf("IF: expected 0xE5, got 0x%02X", val)
+	}
+
+	// IE keeps all 8 bits
+	mem.Write(AddrIE, 0xAB)
+	if val := mem.Read(AddrIE); val != 0xAB {
+		t.Errorf("IE: expected 0xAB, got 0x%02X", val)
+	}
+}
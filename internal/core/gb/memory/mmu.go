@@ -0,0 +1,227 @@
+package memory
+
+import "github.com/antoniosarro/yagbc/internal/core/gb/cartridge"
+
+// MMU is the full Game Boy memory map. It holds WRAM and HRAM itself
+// and routes every other region to the component that owns it, once
+// that component is attached (see InsertCartridge and Attach):
+//
+//	0x0000-0x7FFF  ROM           -> Cartridge
+//	0x8000-0x9FFF  VRAM          -> PPU
+//	0xA000-0xBFFF  External RAM  -> Cartridge
+//	0xC000-0xDFFF  WRAM
+//	0xE000-0xFDFF  Echo RAM (mirror of 0xC000-0xDDFF)
+//	0xFE00-0xFE9F  OAM           -> PPU
+//	0xFEA0-0xFEFF  Not usable
+//	0xFF00-0xFF7F  I/O registers -> each register's Owner
+//	0xFF80-0xFFFE  HRAM
+//	0xFFFF         IE            -> Interrupts
+//
+// Until a component is attached, its region falls back to something
+// a program can still run against: VRAM and OAM are plain RAM, I/O
+// registers are stored with the masks from IORegisters, and without
+// a cartridge the ROM and external RAM areas read as 0xFF.
+type MMU struct {
+	cartridge cartridge.Cartridge
+	ppu       Memory
+
+	// io routes 0xFF00-0xFFFF (the I/O registers and IE) by addr-0xFF00.
+	// Registers without an attached owner are stored in ioValues.
+	io       [0x100]Memory
+	ioValues [0x100]uint8
+
+	vram [0x2000]uint8 // 0x8000-0x9FFF, until a PPU is attached
+	wram [0x2000]uint8 // 0xC000-0xDFFF
+	oam  [0xA0]uint8   // 0xFE00-0xFE9F, until a PPU is attached
+	hram [0x7F]uint8   // 0xFF80-0xFFFE
+}
+
+// NewMMU creates an MMU with no components attached. The I/O
+// registers start with the values the DMG boot ROM leaves in them.
+func NewMMU() *MMU {
+	m := &MMU{}
+	for _, r := range IORegisters {
+		m.ioValues[r.Addr-0xFF00] = r.Reset
+	}
+	return m
+}
+
+// InsertCartridge maps c at 0x0000-0x7FFF (ROM) and 0xA000-0xBFFF
+// (external RAM). Inserting nil empties the slot again.
+func (m *MMU) InsertCartridge(c cartridge.Cartridge) {
+	m.cartridge = c
+}
+
+// Cartridge returns the inserted cartridge, or nil.
+func (m *MMU) Cartridge() cartridge.Cartridge {
+	return m.cartridge
+}
+
+// Attach routes the regions owned by a component to dev:
+//   - ComponentPPU: VRAM, OAM and the PPU's I/O registers
+//   - any other component: its I/O registers
+//
+// dev receives the full 16-bit address of every access, as the CPU
+// made it; applying the bit masks of its registers is up to it.
+// Attaching nil detaches the component again.
+func (m *MMU) Attach(owner Component, dev Memory) {
+	if owner == ComponentPPU {
+		m.ppu = dev
+	}
+	for _, r := range IORegisters {
+		if r.Owner == owner {
+			m.io[r.Addr-0xFF00] = dev
+		}
+	}
+}
+
+func (m *MMU) Read(addr uint16) uint8 {
+	switch {
+	// ROM (0x0000-0x7FFF) and external RAM (0xA000-0xBFFF): the
+	// cartridge, or an open bus without one
+	case addr <= 0x7FFF:
+		if m.cartridge == nil {
+			return 0xFF
+		}
+		return m.cartridge.ReadROM(addr)
+	case addr >= 0xA000 && addr <= 0xBFFF:
+		if m.cartridge == nil {
+			return 0xFF
+		}
+		return m.cartridge.ReadRAM(addr)
+
+	// VRAM: 0x8000 - 0x9FFF
+	case addr <= 0x9FFF:
+		if m.ppu != nil {
+			return m.ppu.Read(addr)
+		}
+		return m.vram[addr-0x8000]
+
+	// WRAM: 0xC000 - 0xDFFF, mirrored at 0xE000 - 0xFDFF
+	case addr <= 0xDFFF:
+		return m.wram[addr-0xC000]
+	case addr <= 0xFDFF:
+		return m.wram[addr-0xE000]
+
+	// OAM: 0xFE00 - 0xFE9F
+	case addr <= 0xFE9F:
+		if m.ppu != nil {
+			return m.ppu.Read(addr)
+		}
+		return m.oam[addr-0xFE00]
+
+	// Not usable: 0xFEA0 - 0xFEFF reads 0x00 on the DMG while OAM
+	// is accessible
+	case addr <= 0xFEFF:
+		return 0x00
+
+	// HRAM: 0xFF80 - 0xFFFE
+	case addr >= 0xFF80 && addr <= 0xFFFE:
+		return m.hram[addr-0xFF80]
+
+	// I/O registers and IE
+	default:
+		return m.readIO(addr)
+	}
+}
+
+func (m *MMU) Write(addr uint16, val uint8) {
+	switch {
+	// ROM and external RAM: ROM writes program the cartridge's MBC
+	case addr <= 0x7FFF:
+		if m.cartridge != nil {
+			m.cartridge.WriteROM(addr, val)
+		}
+	case addr >= 0xA000 && addr <= 0xBFFF:
+		if m.cartridge != nil {
+			m.cartridge.WriteRAM(addr, val)
+		}
+
+	// VRAM: 0x8000 - 0x9FFF
+	case addr <= 0x9FFF:
+		if m.ppu != nil {
+			m.ppu.Write(addr, val)
+			return
+		}
+		m.vram[addr-0x8000] = val
+
+	// WRAM: 0xC000 - 0xDFFF, mirrored at 0xE000 - 0xFDFF
+	case addr <= 0xDFFF:
+		m.wram[addr-0xC000] = val
+	case addr <= 0xFDFF:
+		m.wram[addr-0xE000] = val
+
+	// OAM: 0xFE00 - 0xFE9F
+	case addr <= 0xFE9F:
+		if m.ppu != nil {
+			m.ppu.Write(addr, val)
+			return
+		}
+		m.oam[addr-0xFE00] = val
+
+	// Not usable: writes are ignored
+	case addr <= 0xFEFF:
+
+	// HRAM: 0xFF80 - 0xFFFE
+	case addr >= 0xFF80 && addr <= 0xFFFE:
+		m.hram[addr-0xFF80] = val
+
+	// I/O registers and IE
+	default:
+		m.writeIO(addr, val)
+	}
+}
+
+// SetIORegister sets the value an I/O register (or IE) holds, e.g. to
+// start from the state a particular hardware revision leaves it in.
+// Unlike a CPU write, it also sets read-only bits, as long as the
+// MMU holds the register itself; a register with an attached owner
+// gets a plain write.
+func (m *MMU) SetIORegister(addr uint16, val uint8) {
+	if addr < 0xFF00 {
+		return
+	}
+	index := addr - 0xFF00
+	if dev := m.io[index]; dev != nil {
+		dev.Write(addr, val)
+		return
+	}
+	if _, ok := LookupIORegister(addr); ok {
+		m.ioValues[index] = val
+	}
+}
+
+// readIO reads an I/O register (or IE) from its owner, or from the
+// MMU's own copy if no owner is attached. Addresses with no register
+// read as 0xFF.
+func (m *MMU) readIO(addr uint16) uint8 {
+	index := addr - 0xFF00
+	if dev := m.io[index]; dev != nil {
+		return dev.Read(addr)
+	}
+	r, ok := LookupIORegister(addr)
+	if !ok {
+		return 0xFF
+	}
+	return r.Read(m.ioValues[index])
+}
+
+// addrDIV is the divider register, which any write clears.
+const addrDIV uint16 = 0xFF04
+
+// writeIO writes an I/O register (or IE) through its owner, or to
+// the MMU's own copy if no owner is attached. Without a timer, a
+// write to DIV still clears it, whatever the value written.
+func (m *MMU) writeIO(addr uint16, val uint8) {
+	index := addr - 0xFF00
+	if dev := m.io[index]; dev != nil {
+		dev.Write(addr, val)
+		return
+	}
+	if addr == addrDIV {
+		val = 0
+	}
+	if r, ok := LookupIORegister(addr); ok {
+		m.ioValues[index] = r.Write(m.ioValues[index], val)
+	}
+}
@@ -0,0 +1,167 @@
+package memory
+
+import (
+	"fmt"
+	"testing"
+)
+
+// recorder is a component that logs every access it receives and
+// reads back the low byte of the address.
+type recorder struct {
+	accesses []string
+}
+
+func (r *recorder) Read(addr uint16) uint8 {
+	r.accesses = append(r.accesses, fmt.Sprintf("r %04X", addr))
+	return uint8(addr)
+}
+
+func (r *recorder) Write(addr uint16, val uint8) {
+	r.accesses = append(r.accesses, fmt.Sprintf("w %04X=%02X", addr, val))
+}
+
+// The recorder doubles as a cartridge, tagging RAM accesses
+func (r *recorder) ReadROM(addr uint16) uint8     { return r.Read(addr) }
+func (r *recorder) WriteROM(addr uint16, v uint8) { r.Write(addr, v) }
+func (r *recorder) ReadRAM(addr uint16) uint8 {
+	r.accesses = append(r.accesses, "RAM")
+	return r.Read(addr)
+}
+func (r *recorder) WriteRAM(addr uint16, v uint8) {
+	r.accesses = append(r.accesses, "RAM")
+	r.Write(addr, v)
+}
+
+func TestMMURAM(t *testing.T) {
+	mmu := NewMMU()
+	for _, addr := range []uint16{0x8000, 0x9FFF, 0xC000, 0xDFFF, 0xFE00, 0xFE9F, 0xFF80, 0xFFFE} {
+		mmu.Write(addr, 0x5A)
+		if got := mmu.Read(addr); got != 0x5A {
+			t.Errorf("0x%04X: expected 0x5A, got 0x%02X", addr, got)
+		}
+	}
+
+	// Echo RAM mirrors WRAM both ways
+	mmu.Write(0xE010, 0x11)
+	if got := mmu.Read(0xC010); got != 0x11 {
+		t.Errorf("Expected the echo write at 0xC010, got 0x%02X", got)
+	}
+	mmu.Write(0xDDFF, 0x22)
+	if got := mmu.Read(0xFDFF); got != 0x22 {
+		t.Errorf("Expected 0xDDFF mirrored at 0xFDFF, got 0x%02X", got)
+	}
+}
+
+func TestMMUUnmapped(t *testing.T) {
+	mmu := NewMMU()
+
+	// No cartridge: open bus, and writes go nowhere
+	mmu.Write(0x0100, 0x42)
+	mmu.Write(0xA000, 0x42)
+	for _, addr := range []uint16{0x0100, 0x7FFF, 0xA000, 0xBFFF} {
+		if got := mmu.Read(addr); got != 0xFF {
+			t.Errorf("0x%04X without a cartridge: expected 0xFF, got 0x%02X", addr, got)
+		}
+	}
+
+	mmu.Write(0xFEA0, 0x42)
+	if got := mmu.Read(0xFEA0); got != 0x00 {
+		t.Errorf("Expected the unusable area to read 0x00, got 0x%02X", got)
+	}
+
+	// 0xFF03 is not a register
+	mmu.Write(0xFF03, 0x42)
+	if got := mmu.Read(0xFF03); got != 0xFF {
+		t.Errorf("Expected 0xFF03 to read 0xFF, got 0x%02X", got)
+	}
+}
+
+func TestMMUIORegisters(t *testing.T) {
+	mmu := NewMMU()
+
+	// Boot ROM values, with unused bits reading as 1
+	if got := mmu.Read(AddrIF); got != 0xE1 {
+		t.Errorf("IF: expected 0xE1 after boot, got 0x%02X", got)
+	}
+	if got := mmu.Read(0xFF40); got != 0x91 {
+		t.Errorf("LCDC: expected 0x91 after boot, got 0x%02X", got)
+	}
+
+	// Writes only change the writable bits
+	mmu.Write(0xFF41, 0xFF) // STAT: mode bits are read-only
+	if got := mmu.Read(0xFF41); got != 0xFD {
+		t.Errorf("STAT: expected 0xFD, got 0x%02X", got)
+	}
+	mmu.Write(AddrIE, 0xAB)
+	if got := mmu.Read(AddrIE); got != 0xAB {
+		t.Errorf("IE: expected 0xAB, got 0x%02X", got)
+	}
+
+	// Any write clears DIV
+	mmu.Write(0xFF04, 0x12)
+	if got := mmu.Read(0xFF04); got != 0x00 {
+		t.Errorf("DIV: expected a write to clear it, got 0x%02X", got)
+	}
+}
+
+func TestMMUAttach(t *testing.T) {
+	mmu := NewMMU()
+	cartridge, ppu, timer := &recorder{}, &recorder{}, &recorder{}
+	mmu.InsertCartridge(cartridge)
+	if mmu.Cartridge() != cartridge {
+		t.Error("Expected Cartridge to return the inserted cartridge")
+	}
+	mmu.Attach(ComponentPPU, ppu)
+	mmu.Attach(ComponentTimer, timer)
+
+	mmu.Write(0x2000, 0x01) // MBC bank select
+	mmu.Read(0xA123)
+	mmu.Write(0x8010, 0x02)
+	mmu.Read(0xFE05)
+	mmu.Write(0xFF40, 0x03) // LCDC
+	mmu.Read(0xFF05)        // TIMA
+	mmu.Write(0xC000, 0x04) // WRAM stays in the MMU
+
+	want := map[*recorder]string{
+		cartridge: "[w 2000=01 RAM r A123]",
+		ppu:       "[w 8010=02 r FE05 w FF40=03]",
+		timer:     "[r FF05]",
+	}
+	for dev, accesses := range want {
+		if got := fmt.Sprint(dev.accesses); got != accesses {
+			t.Errorf("Expected accesses %s, got %s", accesses, got)
+		}
+	}
+	if got := mmu.Read(0x0042); got != 0x42 {
+		t.Errorf("Expected the cartridge's value 0x42, got 0x%02X", got)
+	}
+
+	// Detaching falls back to the MMU's own storage
+	mmu.Attach(ComponentPPU, nil)
+	mmu.Write(0x8010, 0x99)
+	if got := mmu.Read(0x8010); got != 0x99 || len(ppu.accesses) != 3 {
+		t.Errorf("Expected VRAM back in the MMU, got 0x%02X and %d PPU accesses", got, len(ppu.accesses))
+	}
+}
+
+func TestMMUSetIORegister(t *testing.T) {
+	mmu := NewMMU()
+
+	// Read-only bits can be set too
+	mmu.SetIORegister(0xFF44, 0x90) // LY
+	if got := mmu.Read(0xFF44); got != 0x90 {
+		t.Errorf("LY: expected 0x90, got 0x%02X", got)
+	}
+	mmu.Write(0xFF44, 0x00)
+	if got := mmu.Read(0xFF44); got != 0x90 {
+		t.Errorf("LY: expected CPU writes to be ignored, got 0x%02X", got)
+	}
+
+	// Attached owners get a plain write
+	timer := &recorder{}
+	mmu.Attach(ComponentTimer, timer)
+	mmu.SetIORegister(0xFF04, 0xAB)
+	if got := fmt.Sprint(timer.accesses); got != "[w FF04=AB]" {
+		t.Errorf("Expected a write to the timer, got %s", got)
+	}
+}
@@ -0,0 +1,73 @@
+package processor
+
+// ============================================================
+// Shadow call stack
+// ============================================================
+// The CPU keeps its own record of the calls in progress, next to
+// the real stack in memory: CALL, CALL cc, RST and interrupt
+// dispatch push a frame, and RET, RET cc and RETI pop it. A
+// debugger uses it to print backtraces and to implement step-over
+// and step-out on top of RunUntil:
+//
+//	// Step out of the current subroutine
+//	depth := cpu.CallDepth()
+//	cpu.RunUntil(func(cpu *processor.CPU) bool { return cpu.CallDepth() < depth })
+//
+// Programs do not always pair calls and returns: a routine may pop
+// its return address and jump elsewhere, or reload SP. Frames are
+// therefore matched by stack address: a return drops every frame
+// whose return address sat below the new SP, so the record heals at
+// the next return after such tricks.
+//
+// The record is debugging state only. It is not part of snapshots
+// and does not affect execution.
+
+// maxCallDepth bounds the shadow call stack. A program that calls
+// without ever returning (e.g. one that resets SP in its main loop)
+// would otherwise grow it forever; the oldest frames are dropped.
+const maxCallDepth = 256
+
+// CallFrame is one call in progress on the shadow call stack.
+type CallFrame struct {
+	CallPC    uint16 // Address of the CALL/RST, or the interrupted PC
+	Target    uint16 // Address called (the interrupt vector for interrupts)
+	ReturnPC  uint16 // Return address pushed on the stack
+	SP        uint16 // Where the return address was pushed
+	Interrupt bool   // Frame pushed by interrupt dispatch
+}
+
+// CallStack returns a copy of the shadow call stack, outermost call
+// first.
+func (cpu *CPU) CallStack() []CallFrame {
+	return append([]CallFrame(nil), cpu.callStack...)
+}
+
+// CallDepth returns the number of calls in progress.
+func (cpu *CPU) CallDepth() int {
+	return len(cpu.callStack)
+}
+
+// pushCall records a call to target, right after its return address
+// was pushed.
+func (cpu *CPU) pushCall(callPC, target uint16, interrupt bool) {
+	if len(cpu.callStack) == maxCallDepth {
+		cpu.callStack = append(cpu.callStack[:0], cpu.callStack[1:]...)
+	}
+	cpu.callStack = append(cpu.callStack, CallFrame{
+		CallPC:    callPC,
+		Target:    target,
+		ReturnPC:  cpu.Registers.PC,
+		SP:        cpu.Registers.SP,
+		Interrupt: interrupt,
+	})
+}
+
+// popCall records a return, right after the return address was
+// popped: every frame pushed below the new SP has ended.
+func (cpu *CPU) popCall() {
+	n := len(cpu.callStack)
+	for n > 0 && cpu.callStack[n-1].SP < cpu.Registers.SP {
+		n--
+	}
+	cpu.callStack = cpu.callStack[:n]
+}
@@ -0,0 +1,135 @@
+package processor
+
+import (
+	"fmt"
+	"testing"
+)
+
+// callMap renders the shadow call stack as "call->target" pairs.
+func callMap(cpu *CPU) string {
+	frames := ""
+	for _, f := range cpu.CallStack() {
+		frames += fmt.Sprintf("[%04X->%04X ret %04X]", f.CallPC, f.Target, f.ReturnPC)
+	}
+	return frames
+}
+
+func TestCallStackNested(t *testing.T) {
+	// Program:
+	//   0x0000: LD SP, 0xD000; CALL 0x0010; HALT
+	//   0x0008: RET
+	//   0x0010: RST 08H; RET
+	program := make([]byte, 0x12)
+	copy(program, []byte{0x31, 0x00, 0xD0, 0xCD, 0x10, 0x00, 0x76})
+	program[0x08] = 0xC9
+	copy(program[0x10:], []byte{0xCF, 0xC9})
+	cpu := setupCPU(program)
+
+	want := []string{
+		"",                      // LD SP
+		"[0003->0010 ret 0006]", // CALL
+		"[0003->0010 ret 0006][0010->0008 ret 0011]", // RST
+		"[0003->0010 ret 0006]",                      // RET
+		"",                                           // RET
+	}
+	for i, frames := range want {
+		step(t, cpu)
+		if got := callMap(cpu); got != frames {
+			t.Errorf("Step %d: expected call stack %q, got %q", i+1, frames, got)
+		}
+		if cpu.CallDepth() != len(cpu.CallStack()) {
+			t.Errorf("Step %d: CallDepth %d does not match %d frames", i+1, cpu.CallDepth(), len(cpu.CallStack()))
+		}
+	}
+	if cpu.Registers.PC != 0x0006 {
+		t.Errorf("Expected PC=0x0006, got PC=0x%04X", cpu.Registers.PC)
+	}
+}
+
+func TestCallStackInterrupt(t *testing.T) {
+	// Program: HALT at 0x0100, RETI at the VBlank vector
+	program := make([]byte, 0x0101)
+	program[0x40] = 0xD9
+	program[0x0100] = 0x76
+	cpu := setupCPU(program)
+	cpu.Registers.PC = 0x0100
+	cpu.Registers.SP = 0xD000
+	cpu.IME = true
+	cpu.Memory.Write(0xFFFF, InterruptVBlank)
+
+	step(t, cpu) // HALT
+	cpu.RequestInterrupt(InterruptVBlank)
+	step(t, cpu) // Dispatch
+
+	stack := cpu.CallStack()
+	if len(stack) != 1 {
+		t.Fatalf("Expected 1 frame, got %q", callMap(cpu))
+	}
+	want := CallFrame{CallPC: 0x0101, Target: 0x0040, ReturnPC: 0x0101, SP: 0xCFFE, Interrupt: true}
+	if stack[0] != want {
+		t.Errorf("Expected frame %+v, got %+v", want, stack[0])
+	}
+
+	step(t, cpu) // RETI
+	if cpu.CallDepth() != 0 {
+		t.Errorf("Expected an empty call stack after RETI, got %q", callMap(cpu))
+	}
+}
+
+func TestCallStackUnbalanced(t *testing.T) {
+	// A routine that drops its return address and jumps back, leaving
+	// a stale frame until the next return pops past it:
+	//   0x0000: LD SP, 0xD000; CALL 0x0010
+	//   0x0006: CALL 0x0020; HALT
+	//   0x0010: INC SP; INC SP; JP 0x0006
+	//   0x0020: RET
+	program := make([]byte, 0x21)
+	copy(program, []byte{0x31, 0x00, 0xD0, 0xCD, 0x10, 0x00, 0xCD, 0x20, 0x00, 0x76})
+	copy(program[0x10:], []byte{0x33, 0x33, 0xC3, 0x06, 0x00})
+	program[0x20] = 0xC9
+	cpu := setupCPU(program)
+
+	for range 6 { // LD SP, CALL, INC SP, INC SP, JP, CALL
+		step(t, cpu)
+	}
+	if cpu.CallDepth() != 2 {
+		t.Fatalf("Expected the stale frame and the new one, got %q", callMap(cpu))
+	}
+
+	step(t, cpu) // RET
+	if cpu.CallDepth() != 0 {
+		t.Errorf("Expected the RET to clear the stale frame, got %q", callMap(cpu))
+	}
+}
+
+func TestCallStackDepthLimit(t *testing.T) {
+	// Program: CALL 0x0000 (recurses forever)
+	cpu := setupCPU([]byte{0xCD, 0x00, 0x00})
+	cpu.Registers.SP = 0xD000
+
+	for range maxCallDepth + 10 {
+		step(t, cpu)
+	}
+	stack := cpu.CallStack()
+	if len(stack) != maxCallDepth {
+		t.Fatalf("Expected %d frames, got %d", maxCallDepth, len(stack))
+	}
+	if top := stack[len(stack)-1]; top.SP != 0xD000-2*(maxCallDepth+10) {
+		t.Errorf("Expected the newest frame at SP=0x%04X, got 0x%04X", 0xD000-2*(maxCallDepth+10), top.SP)
+	}
+}
+
+func TestRestoreClearsCallStack(t *testing.T) {
+	// Program: CALL 0x0003
+	cpu := setupCPU([]byte{0xCD, 0x03, 0x00})
+	cpu.Registers.SP = 0xD000
+	snapshot := cpu.Snapshot()
+
+	step(t, cpu)
+	if err := cpu.Restore(snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if cpu.CallDepth() != 0 {
+		t.Errorf("Expected Restore to clear the call stack, got %q", callMap(cpu))
+	}
+}
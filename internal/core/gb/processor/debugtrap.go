@@ -0,0 +1,73 @@
+package processor
+
+// ============================================================
+// 0x40: LD B, B - Breakpoint trap
+// 0x52: LD D, D - Debug message trap
+// ============================================================
+// Copying a register to itself does nothing, so homebrew tools
+// (BGB, no$gmb, Emulicious, the Mooneye test ROMs) borrowed these
+// two opcodes as debug traps that cost 4 cycles on hardware:
+//
+//   - LD B, B is a source breakpoint: OnBreakpoint is called with PC
+//     on the next instruction
+//   - LD D, D followed by an inline message logs it: OnDebugMessage
+//     is called with the text. The message is skipped over by a JR,
+//     and tagged so a stray LD D, D is not mistaken for one:
+//
+//	LD D, D
+//	JR .end      ; 0x18, length of the rest
+//	DW $6464     ; Signature
+//	DW $0000     ; Inline text
+//	DB "message"
+//	.end:
+//
+// With the hooks unset, both are plain no-ops.
+//
+// Flags: None affected
+// Cycles: 4
+// Bytes: 1
+
+// Debug message layout, as offsets from the JR after LD D, D.
+const (
+	debugMessageSignature = 0x6464
+	debugMessageInline    = 0x0000
+	debugMessageText      = 6 // JR (2) + signature (2) + format (2)
+)
+
+// opLD_B_B implements 0x40: LD B, B.
+func opLD_B_B(cpu *CPU) {
+	if cpu.OnBreakpoint != nil {
+		cpu.OnBreakpoint(cpu)
+	}
+}
+
+// opLD_D_D implements 0x52: LD D, D.
+func opLD_D_D(cpu *CPU) {
+	if cpu.OnDebugMessage == nil {
+		return
+	}
+	if msg, ok := cpu.debugMessage(cpu.Registers.PC); ok {
+		cpu.OnDebugMessage(cpu, msg)
+	}
+}
+
+// debugMessage decodes the message at pc, right after an LD D, D.
+// It reads memory directly, without taking any cycles: the hardware
+// never reads the message, the JR skips it.
+func (cpu *CPU) debugMessage(pc uint16) (string, bool) {
+	peekWord := func(addr uint16) uint16 {
+		return uint16(cpu.Memory.Read(addr)) | uint16(cpu.Memory.Read(addr+1))<<8
+	}
+	if cpu.Memory.Read(pc) != 0x18 || // JR e
+		peekWord(pc+2) != debugMessageSignature || peekWord(pc+4) != debugMessageInline {
+		return "", false
+	}
+
+	// The text runs up to the JR target (capped, should it jump back)
+	end := pc + 2 + uint16(int8(cpu.Memory.Read(pc+1)))
+	var text []byte
+	for addr := pc + debugMessageText; addr != end && len(text) < 128; addr++ {
+		text = append(text, cpu.Memory.Read(addr))
+	}
+	return string(text), true
+}
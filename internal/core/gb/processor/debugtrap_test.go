@@ -0,0 +1,48 @@
+package processor
+
+import "testing"
+
+func TestBreakpoint(t *testing.T) {
+	// Program: LD B, B
+	cpu := setupCPU([]byte{0x40})
+	var hits []uint16
+	cpu.OnBreakpoint = func(cpu *CPU) { hits = append(hits, cpu.Registers.PC) }
+
+	if cycles := step(t, cpu); cycles != 4 {
+		t.Errorf("Expected 4 cycles, got %d", cycles)
+	}
+	if len(hits) != 1 || hits[0] != 0x0001 {
+		t.Errorf("Expected one breakpoint with PC at 0x0001, got %v", hits)
+	}
+}
+
+func TestDebugMessage(t *testing.T) {
+	// Program: LD D, D; JR .end; DW $6464, $0000; DB "Hi!"; .end: LD D, D
+	program := []byte{0x52, 0x18, 0x07, 0x64, 0x64, 0x00, 0x00, 'H', 'i', '!', 0x52}
+	cpu := setupCPU(program)
+	var msgs []string
+	cpu.OnDebugMessage = func(cpu *CPU, msg string) { msgs = append(msgs, msg) }
+
+	step(t, cpu) // LD D, D
+	step(t, cpu) // JR over the message
+	if cpu.Registers.PC != 0x000A {
+		t.Fatalf("Expected the JR to skip the message, PC = 0x%04X", cpu.Registers.PC)
+	}
+	step(t, cpu) // LD D, D with no message after it
+
+	if len(msgs) != 1 || msgs[0] != "Hi!" {
+		t.Errorf("Expected the message %q only, got %q", "Hi!", msgs)
+	}
+	if regs := *cpu.Registers; regs.D != 0 || regs.B != 0 {
+		t.Errorf("Expected the traps to leave registers alone, got %+v", regs)
+	}
+}
+
+func TestDebugMessageSignature(t *testing.T) {
+	// Program: LD D, D; JR +4; DW $6363: wrong signature
+	cpu := setupCPU([]byte{0x52, 0x18, 0x04, 0x63, 0x63, 0x00, 0x00})
+	cpu.OnDebugMessage = func(cpu *CPU, msg string) {
+		t.Errorf("Expected no message, got %q", msg)
+	}
+	step(t, cpu)
+}
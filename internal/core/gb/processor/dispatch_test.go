@@ -0,0 +1,109 @@
+package processor
+
+import "testing"
+
+// benchProgram is an endless loop mixing 8-bit ALU, CB-prefixed and
+// (conditional) jump instructions.
+var benchProgram = []byte{
+	0x80,       // 0x0000: ADD A, B
+	0x05,       // 0x0001: DEC B
+	0xCB, 0x37, // 0x0002: SWAP A
+	0xCB, 0x40, // 0x0004: BIT 0, B
+	0x20, 0x00, // 0x0006: JR NZ, +0
+	0x18, 0xF6, // 0x0008: JR -10 (back to 0x0000)
+}
+
+// BenchmarkStep measures the whole Step path, reporting emulated
+// instructions per second.
+func BenchmarkStep(b *testing.B) {
+	cpu := setupCPU(benchProgram)
+	for b.Loop() {
+		cpu.Step()
+	}
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "instructions/s")
+}
+
+// cbBenchProgram is every CB-prefixed opcode, without the prefixes:
+// BenchmarkDispatch feeds the bytes straight to the CB dispatch.
+var cbBenchProgram = func() []byte {
+	program := make([]byte, 256)
+	for i := range program {
+		program[i] = uint8(i)
+	}
+	return program
+}()
+
+// BenchmarkDispatch compares running instructions through the
+// Execute functions of the opcode tables with the generated switches
+// Step uses: benchProgram's unprefixed ones, and every CB-prefixed
+// one.
+func BenchmarkDispatch(b *testing.B) {
+	for _, bench := range []struct {
+		name    string
+		program []byte
+		run     func(cpu *CPU, opcode uint8)
+	}{
+		{"table", benchProgram, func(cpu *CPU, opcode uint8) { opcodeTable[opcode].Execute(cpu) }},
+		{"switch", benchProgram, (*CPU).execute},
+		{"cb/table", cbBenchProgram, func(cpu *CPU, opcode uint8) { cbTable[opcode].Execute(cpu) }},
+		{"cb/switch", cbBenchProgram, (*CPU).executeCB},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			cpu := setupCPU(bench.program)
+			end := uint16(len(bench.program))
+			for b.Loop() {
+				if cpu.Registers.PC >= end {
+					cpu.Registers.PC = 0 // Loop the CB opcodes
+				}
+				bench.run(cpu, cpu.fetchByte())
+			}
+			b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "instructions/s")
+		})
+	}
+}
+
+// TestDispatchMatchesTable runs every opcode through both the table
+// and the switch from the same state, and expects the same result.
+func TestDispatchMatchesTable(t *testing.T) {
+	for _, prefixed := range []bool{false, true} {
+		for i := range 256 {
+			opcode := uint8(i)
+			if opcode == 0xCB && !prefixed {
+				continue // dispatch decodes the prefix before execute
+			}
+			var cpus [2]*CPU
+			var mems [2]*flatMemory
+			for j := range cpus {
+				mems[j] = &flatMemory{}
+				copy(mems[j].data[0x1000:], []byte{0x34, 0x12, 0xFE})
+				mems[j].data[0xC000] = 0x5A
+				cpus[j] = NewCPU(mems[j])
+				*cpus[j].Registers = Registers{
+					A: 0x81, F: FlagZ | FlagC, B: 0x01, C: 0x10, D: 0x7F, E: 0xFF, H: 0xC0, L: 0x00,
+					SP: 0xD000, PC: 0x1000,
+				}
+				cpus[j].opcode = opcode
+			}
+
+			if prefixed {
+				cbTable[opcode].Execute(cpus[0])
+				cpus[1].executeCB(opcode)
+			} else {
+				opcodeTable[opcode].Execute(cpus[0])
+				cpus[1].execute(opcode)
+			}
+
+			name := InstructionInfo(opcode, prefixed).Mnemonic
+			if *cpus[0].Registers != *cpus[1].Registers {
+				t.Errorf("%s: table gave %+v, switch gave %+v", name, *cpus[0].Registers, *cpus[1].Registers)
+			}
+			if cpus[0].branchTaken != cpus[1].branchTaken || cpus[0].IME != cpus[1].IME ||
+				cpus[0].Halted != cpus[1].Halted || cpus[0].Locked != cpus[1].Locked {
+				t.Errorf("%s: table and switch left the CPU in different states", name)
+			}
+			if mems[0].data != mems[1].data {
+				t.Errorf("%s: table and switch left memory different", name)
+			}
+		}
+	}
+}
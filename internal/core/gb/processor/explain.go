@@ -0,0 +1,272 @@
+package processor
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ============================================================
+// Explain mode
+// ============================================================
+// Setting cpu.OnExplain makes the CPU describe every instruction
+// it executes, in plain words:
+//
+//	0x0004  ADD A, B  (4 cycles)
+//	  Adds the source operand to A.
+//	  A: 0x0F -> 0x10
+//	  H: 0 -> 1  carry from bit 3 into bit 4
+//
+// Each Explanation records the registers before and after the
+// instruction and every memory access it made (instruction
+// fetches aside), so a frontend can also present it its own way.
+// Interrupt dispatches and halted/stopped Steps execute no
+// instruction and are not explained.
+
+// MemoryAccess is one data read or write made by an instruction.
+type MemoryAccess struct {
+	Addr  uint16
+	Value uint8
+	Write bool // True for a write, false for a read
+}
+
+// Explanation describes one executed instruction, as passed to
+// cpu.OnExplain.
+type Explanation struct {
+	PC          uint16      // Address the instruction was fetched from
+	Text        string      // Disassembly with operands, e.g. "LD A, 0x42"
+	Instruction Instruction // Metadata of the instruction
+	Cycles      int         // Cycles the instruction took
+	BranchTaken bool        // For conditional instructions: did the condition hold?
+
+	Before Registers      // Registers before the instruction
+	After  Registers      // Registers after the instruction
+	Memory []MemoryAccess // Data accesses, in order
+}
+
+// beginExplanation starts recording the instruction about to be
+// fetched at PC.
+func (cpu *CPU) beginExplanation() {
+	text, info := Disassemble(cpu.Memory, cpu.Registers.PC)
+	cpu.explanation = &Explanation{
+		PC:          cpu.Registers.PC,
+		Text:        text,
+		Instruction: info,
+		Before:      *cpu.Registers,
+	}
+}
+
+// finishExplanation completes the recording with the outcome of the
+// instruction and hands it to OnExplain.
+func (cpu *CPU) finishExplanation(cycles int) {
+	e := cpu.explanation
+	cpu.explanation = nil
+	e.Cycles = cycles
+	e.BranchTaken = cpu.branchTaken
+	e.After = *cpu.Registers
+	cpu.OnExplain(cpu, e)
+}
+
+// recordAccess adds a memory access to the instruction being
+// explained, if any.
+func (cpu *CPU) recordAccess(addr uint16, value uint8, write bool) {
+	if cpu.explanation != nil {
+		cpu.explanation.Memory = append(cpu.explanation.Memory, MemoryAccess{addr, value, write})
+	}
+}
+
+// operationDescriptions says what each operation does, keyed by the
+// first word of the mnemonic.
+var operationDescriptions = map[string]string{
+	"NOP":    "Does nothing for one M-cycle.",
+	"LD":     "Copies the source operand (right) into the destination (left).",
+	"LDH":    "Copies between A and the I/O page at 0xFF00-0xFFFF.",
+	"PUSH":   "Pushes a register pair onto the stack.",
+	"POP":    "Pops a register pair off the stack.",
+	"ADD":    "Adds the source operand to the destination.",
+	"ADC":    "Adds the source operand plus the carry flag to A.",
+	"SUB":    "Subtracts the source operand from A.",
+	"SBC":    "Subtracts the source operand and the carry flag from A.",
+	"AND":    "Bitwise AND of A with the source operand, stored in A.",
+	"OR":     "Bitwise OR of A with the source operand, stored in A.",
+	"XOR":    "Bitwise XOR of A with the source operand, stored in A.",
+	"CP":     "Compares A with the source operand: subtracts without storing the result.",
+	"INC":    "Adds 1 to the operand.",
+	"DEC":    "Subtracts 1 from the operand.",
+	"DAA":    "Adjusts A back to binary-coded decimal after an addition or subtraction.",
+	"CPL":    "Inverts every bit of A.",
+	"SCF":    "Sets the carry flag.",
+	"CCF":    "Flips the carry flag.",
+	"RLCA":   "Rotates A left; bit 7 goes into C and wraps around to bit 0.",
+	"RRCA":   "Rotates A right; bit 0 goes into C and wraps around to bit 7.",
+	"RLA":    "Rotates A left through the carry flag.",
+	"RRA":    "Rotates A right through the carry flag.",
+	"RLC":    "Rotates the operand left; bit 7 goes into C and wraps around to bit 0.",
+	"RRC":    "Rotates the operand right; bit 0 goes into C and wraps around to bit 7.",
+	"RL":     "Rotates the operand left through the carry flag.",
+	"RR":     "Rotates the operand right through the carry flag.",
+	"SLA":    "Shifts the operand left (multiplies by 2); bit 7 goes into C.",
+	"SRA":    "Shifts the operand right keeping its sign bit; bit 0 goes into C.",
+	"SRL":    "Shifts the operand right (unsigned divide by 2); bit 0 goes into C.",
+	"SWAP":   "Exchanges the high and low nibbles of the operand.",
+	"BIT":    "Tests one bit of the operand: Z is set if the bit is 0.",
+	"RES":    "Clears one bit of the operand.",
+	"SET":    "Sets one bit of the operand.",
+	"JP":     "Jumps to the address.",
+	"JR":     "Jumps by a signed offset from the next instruction.",
+	"CALL":   "Pushes the return address and jumps to the subroutine.",
+	"RET":    "Pops the return address off the stack into PC.",
+	"RETI":   "Returns from an interrupt handler and enables interrupts.",
+	"RST":    "Calls one of the fixed restart vectors.",
+	"HALT":   "Halts the CPU until an interrupt is pending.",
+	"STOP":   "Enters stop mode until a button is pressed.",
+	"DI":     "Disables interrupts (IME = 0).",
+	"EI":     "Enables interrupts (IME = 1) after the next instruction.",
+	"PREFIX": "Selects the 0xCB instruction table for the next byte.",
+}
+
+// shiftOperations are the rotates and shifts, whose carry flag
+// receives the bit shifted out.
+var shiftOperations = map[string]bool{
+	"RLCA": true, "RRCA": true, "RLA": true, "RRA": true,
+	"RLC": true, "RRC": true, "RL": true, "RR": true,
+	"SLA": true, "SRA": true, "SRL": true,
+}
+
+// operation returns the first word of a mnemonic, e.g. "LD" for
+// "LD A, B".
+func operation(mnemonic string) string {
+	op, _, _ := strings.Cut(mnemonic, " ")
+	return op
+}
+
+// Description says in one sentence what the instruction does.
+func (e *Explanation) Description() string {
+	op := operation(e.Instruction.Mnemonic)
+	switch {
+	case strings.HasPrefix(op, "ILLEGAL_"):
+		return "Illegal opcode: the CPU locks up."
+	case !e.Instruction.Implemented:
+		return "Not implemented by this emulator."
+	}
+	return operationDescriptions[op]
+}
+
+// flagReason explains why a flag changed to the given value.
+func (e *Explanation) flagReason(flag uint8, effect FlagEffect, set bool) string {
+	op := operation(e.Instruction.Mnemonic)
+	switch {
+	case effect == FlagSet:
+		return "always set by " + op
+	case effect == FlagReset:
+		return "always reset by " + op
+	case op == "POP":
+		return "restored from the stack"
+	}
+
+	// H and C mean borrows after a subtraction, carries otherwise
+	subtract := e.After.F&FlagN != 0
+	wide := strings.HasPrefix(e.Instruction.Mnemonic, "ADD HL")
+	switch flag {
+	case FlagZ:
+		if op == "BIT" {
+			return pick(set, "the tested bit is 0", "the tested bit is 1")
+		}
+		return pick(set, "the result is zero", "the result is not zero")
+	case FlagN:
+		return pick(set, "the operation is a subtraction", "the operation is not a subtraction")
+	case FlagH:
+		switch {
+		case wide:
+			return pick(set, "carry from bit 11 into bit 12", "no carry from bit 11")
+		case subtract:
+			return pick(set, "borrow from bit 4", "no borrow from bit 4")
+		}
+		return pick(set, "carry from bit 3 into bit 4", "no carry from bit 3")
+	default:
+		switch {
+		case op == "CCF":
+			return "the carry flag was flipped"
+		case op == "DAA":
+			return pick(set, "the BCD result overflowed", "the BCD result fits")
+		case shiftOperations[op]:
+			return pick(set, "the bit shifted out was 1", "the bit shifted out was 0")
+		case wide:
+			return pick(set, "carry out of bit 15", "no carry out of bit 15")
+		case subtract:
+			return pick(set, "the subtraction borrowed", "the subtraction did not borrow")
+		}
+		return pick(set, "carry out of bit 7", "no carry out of bit 7")
+	}
+}
+
+// pick returns a if cond holds, b otherwise.
+func pick(cond bool, a, b string) string {
+	if cond {
+		return a
+	}
+	return b
+}
+
+// String renders the explanation as indented, human-readable lines.
+func (e *Explanation) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "0x%04X  %s  (%d cycles)\n", e.PC, e.Text, e.Cycles)
+	fmt.Fprintf(&b, "  %s\n", e.Description())
+
+	if e.Instruction.Conditional {
+		fmt.Fprintf(&b, "  %s\n", pick(e.BranchTaken, "Condition held: branch taken", "Condition failed: branch not taken"))
+	}
+
+	// Registers
+	before, after := e.Before, e.After
+	regs := []struct {
+		name          string
+		before, after uint8
+	}{
+		{"A", before.A, after.A}, {"B", before.B, after.B},
+		{"C", before.C, after.C}, {"D", before.D, after.D},
+		{"E", before.E, after.E}, {"H", before.H, after.H},
+		{"L", before.L, after.L},
+	}
+	for _, r := range regs {
+		if r.before != r.after {
+			fmt.Fprintf(&b, "  %s: 0x%02X -> 0x%02X\n", r.name, r.before, r.after)
+		}
+	}
+	if before.SP != after.SP {
+		fmt.Fprintf(&b, "  SP: 0x%04X -> 0x%04X\n", before.SP, after.SP)
+	}
+	if next := e.PC + uint16(e.Instruction.Bytes); after.PC != next {
+		fmt.Fprintf(&b, "  PC: jumped to 0x%04X\n", after.PC)
+	}
+
+	// Flags
+	flags := []struct {
+		name   string
+		mask   uint8
+		effect FlagEffect
+	}{
+		{"Z", FlagZ, e.Instruction.Flags.Z}, {"N", FlagN, e.Instruction.Flags.N},
+		{"H", FlagH, e.Instruction.Flags.H}, {"C", FlagC, e.Instruction.Flags.C},
+	}
+	for _, f := range flags {
+		was, set := before.F&f.mask != 0, after.F&f.mask != 0
+		if was != set {
+			fmt.Fprintf(&b, "  %s: %d -> %d  %s\n", f.name, bit(was), bit(set), e.flagReason(f.mask, f.effect, set))
+		}
+	}
+
+	// Memory
+	for _, m := range e.Memory {
+		fmt.Fprintf(&b, "  %s 0x%04X = 0x%02X\n", pick(m.Write, "write", "read "), m.Addr, m.Value)
+	}
+	return b.String()
+}
+
+// bit converts a flag state to 0 or 1 for display.
+func bit(set bool) int {
+	if set {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,159 @@
+package processor
+
+import "testing"
+
+// explainAll runs n instructions on cpu and returns their
+// explanations.
+func explainAll(t *testing.T, cpu *CPU, n int) []*Explanation {
+	t.Helper()
+	var explanations []*Explanation
+	cpu.OnExplain = func(cpu *CPU, e *Explanation) {
+		explanations = append(explanations, e)
+	}
+	for range n {
+		step(t, cpu)
+	}
+	return explanations
+}
+
+func TestExplainString(t *testing.T) {
+	tests := []struct {
+		name    string
+		program []byte
+		setup   func(cpu *CPU)
+		want    string
+	}{
+		{
+			name:    "LD A, n",
+			program: []byte{0x3E, 0x42},
+			want: "0x0000  LD A, 0x42  (8 cycles)\n" +
+				"  Copies the source operand (right) into the destination (left).\n" +
+				"  A: 0x00 -> 0x42\n",
+		},
+		{
+			name:    "ADD A, B with half-carry",
+			program: []byte{0x80},
+			setup:   func(cpu *CPU) { cpu.Registers.A, cpu.Registers.B = 0x0F, 0x01 },
+			want: "0x0000  ADD A, B  (4 cycles)\n" +
+				"  Adds the source operand to the destination.\n" +
+				"  A: 0x0F -> 0x10\n" +
+				"  H: 0 -> 1  carry from bit 3 into bit 4\n",
+		},
+		{
+			name:    "XOR A, A",
+			program: []byte{0xAF},
+			setup:   func(cpu *CPU) { cpu.Registers.A, cpu.Registers.F = 0x5A, FlagC },
+			want: "0x0000  XOR A, A  (4 cycles)\n" +
+				"  Bitwise XOR of A with the source operand, stored in A.\n" +
+				"  A: 0x5A -> 0x00\n" +
+				"  Z: 0 -> 1  the result is zero\n" +
+				"  C: 1 -> 0  always reset by XOR\n",
+		},
+		{
+			name:    "SUB A, n with borrow",
+			program: []byte{0xD6, 0x01},
+			want: "0x0000  SUB A, 0x01  (8 cycles)\n" +
+				"  Subtracts the source operand from A.\n" +
+				"  A: 0x00 -> 0xFF\n" +
+				"  N: 0 -> 1  always set by SUB\n" +
+				"  H: 0 -> 1  borrow from bit 4\n" +
+				"  C: 0 -> 1  the subtraction borrowed\n",
+		},
+		{
+			name:    "SRL B",
+			program: []byte{0xCB, 0x38},
+			setup:   func(cpu *CPU) { cpu.Registers.B = 0x01 },
+			want: "0x0000  SRL B  (8 cycles)\n" +
+				"  Shifts the operand right (unsigned divide by 2); bit 0 goes into C.\n" +
+				"  B: 0x01 -> 0x00\n" +
+				"  Z: 0 -> 1  the result is zero\n" +
+				"  C: 0 -> 1  the bit shifted out was 1\n",
+		},
+		{
+			name:    "INC (HL)",
+			program: []byte{0x34},
+			setup: func(cpu *CPU) {
+				cpu.Registers.SetHL(0xC000)
+				cpu.Memory.Write(0xC000, 0x0F)
+			},
+			want: "0x0000  INC (HL)  (12 cycles)\n" +
+				"  Adds 1 to the operand.\n" +
+				"  H: 0 -> 1  carry from bit 3 into bit 4\n" +
+				"  read  0xC000 = 0x0F\n" +
+				"  write 0xC000 = 0x10\n",
+		},
+		{
+			name:    "JR NZ taken",
+			program: []byte{0x20, 0xFE},
+			want: "0x0000  JR NZ, -2  (12 cycles)\n" +
+				"  Jumps by a signed offset from the next instruction.\n" +
+				"  Condition held: branch taken\n" +
+				"  PC: jumped to 0x0000\n",
+		},
+		{
+			name:    "CALL nn",
+			program: []byte{0xCD, 0x34, 0x12},
+			setup:   func(cpu *CPU) { cpu.Registers.SP = 0xD000 },
+			want: "0x0000  CALL 0x1234  (24 cycles)\n" +
+				"  Pushes the return address and jumps to the subroutine.\n" +
+				"  SP: 0xD000 -> 0xCFFE\n" +
+				"  PC: jumped to 0x1234\n" +
+				"  write 0xCFFF = 0x00\n" +
+				"  write 0xCFFE = 0x03\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu := setupCPU(tt.program)
+			if tt.setup != nil {
+				tt.setup(cpu)
+			}
+
+			explanations := explainAll(t, cpu, 1)
+			if got := explanations[0].String(); got != tt.want {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestExplainSkipsInterruptDispatch(t *testing.T) {
+	// Program: NOP
+	cpu := setupCPU([]byte{0x00})
+	cpu.Registers.SP = 0xD000
+	cpu.IME = true
+	cpu.Memory.Write(0xFFFF, InterruptVBlank)
+	cpu.RequestInterrupt(InterruptVBlank)
+
+	if explanations := explainAll(t, cpu, 1); len(explanations) != 0 {
+		t.Errorf("Expected no explanation for an interrupt dispatch, got %d", len(explanations))
+	}
+	if cpu.explanation != nil {
+		t.Error("Expected no explanation left in progress")
+	}
+}
+
+func TestDisassemble(t *testing.T) {
+	tests := []struct {
+		program []byte
+		want    string
+	}{
+		{[]byte{0x00}, "NOP"},
+		{[]byte{0x06, 0x7F}, "LD B, 0x7F"},
+		{[]byte{0x21, 0x00, 0xC0}, "LD HL, 0xC000"},
+		{[]byte{0xE0, 0x44}, "LDH (0x44), A"},
+		{[]byte{0x18, 0x05}, "JR +5"},
+		{[]byte{0xF8, 0xFD}, "LD HL, SP-3"},
+		{[]byte{0xCB, 0x7C}, "BIT 7, H"},
+	}
+
+	for _, tt := range tests {
+		cpu := setupCPU(tt.program)
+		text, info := Disassemble(cpu.Memory, 0x0000)
+		if text != tt.want || info.Bytes != len(tt.program) {
+			t.Errorf("Disassemble(% X): expected %q (%d bytes), got %q (%d bytes)",
+				tt.program, tt.want, len(tt.program), text, info.Bytes)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package processor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/antoniosarro/yagbc/internal/core/gb/memory"
+)
+
+// fuzzStepLimit bounds how many instructions a fuzzed ROM may run.
+// Random bytes easily form infinite loops (e.g. JP to itself), so
+// the harness stops after a fixed budget instead of waiting for the
+// program to end.
+const fuzzStepLimit = 10_000
+
+// FuzzExecuteROM loads arbitrary bytes as a ROM image and runs them,
+// checking that no input can make the emulator panic or hang.
+//
+// Run with: go test -fuzz=FuzzExecuteROM ./internal/core/gb/processor
+func FuzzExecuteROM(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{0x3E, 0x05, 0x06, 0x03, 0x80})             // LD A, 5; LD B, 3; ADD A, B
+	f.Add([]byte{0xC3, 0x00, 0x00})                         // JP 0x0000 (infinite loop)
+	f.Add([]byte{0x21, 0xFF, 0xFF, 0x2A, 0x22, 0x32, 0x3A}) // HL loads around 0xFFFF
+	f.Add([]byte{0x31, 0x00, 0x00, 0x08, 0xFF, 0xFF})       // LD (0xFFFF), SP
+
+	f.Fuzz(func(t *testing.T, rom []byte) {
+		mem := memory.NewBasicMemory()
+		if err := mem.LoadROM(rom); err != nil {
+			if len(rom) <= 0x8000 {
+				t.Fatalf("LoadROM rejected a %d byte ROM: %v", len(rom), err)
+			}
+			return
+		}
+
+		cpu := NewCPU(mem)
+		for range fuzzStepLimit {
+			cycles, err := cpu.Step()
+			if cycles <= 0 {
+				t.Fatalf("Step returned %d cycles at PC=0x%04X", cycles, cpu.Registers.PC)
+			}
+			// Random bytes are bound to hit an illegal opcode eventually
+			if errors.Is(err, ErrLocked) {
+				return
+			}
+			if err != nil {
+				t.Fatalf("Step failed at PC=0x%04X: %v", cpu.Registers.PC, err)
+			}
+		}
+	})
+}
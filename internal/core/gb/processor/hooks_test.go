@@ -0,0 +1,57 @@
+package processor
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestInstructionHooks(t *testing.T) {
+	// Program: LD A, 0x42; PREFIX CB; SWAP A; HALT
+	cpu := setupCPU([]byte{0x3E, 0x42, 0xCB, 0x37, 0x76})
+	var events []string
+	cpu.OnBeforeInstruction = func(cpu *CPU, e InstructionEvent) {
+		events = append(events, fmt.Sprintf("before %04X %02X", e.PC, e.Opcode))
+	}
+	cpu.OnAfterInstruction = func(cpu *CPU, e InstructionEvent) {
+		events = append(events, fmt.Sprintf("after %04X %02X %d (total %d)", e.PC, e.Opcode, e.Cycles, cpu.TotalCycles))
+	}
+
+	for range 4 {
+		step(t, cpu) // The last Step is halted: no instruction, no hooks
+	}
+
+	want := []string{
+		"before 0000 3E", "after 0000 3E 8 (total 8)",
+		"before 0002 CB", "after 0002 CB 8 (total 16)",
+		"before 0004 76", "after 0004 76 4 (total 20)",
+	}
+	if fmt.Sprint(events) != fmt.Sprint(want) {
+		t.Errorf("Expected %q, got %q", want, events)
+	}
+}
+
+func TestBeforeInstructionCanPatchState(t *testing.T) {
+	// Program: INC A
+	cpu := setupCPU([]byte{0x3C})
+	cpu.OnBeforeInstruction = func(cpu *CPU, e InstructionEvent) {
+		cpu.Registers.A = 0x98 // e.g. a cheat freezing a value
+	}
+
+	step(t, cpu)
+	if cpu.Registers.A != 0x99 {
+		t.Errorf("Expected A=0x99, got 0x%02X", cpu.Registers.A)
+	}
+}
+
+func TestBeforeInstructionDoesNotTick(t *testing.T) {
+	// Program: NOP
+	cpu := setupCPU([]byte{0x00})
+	cpu.OnBeforeInstruction = func(cpu *CPU, e InstructionEvent) {}
+	ticks := 0
+	cpu.Tick = func() { ticks++ }
+
+	step(t, cpu)
+	if ticks != 1 {
+		t.Errorf("Expected 1 tick for NOP, got %d", ticks)
+	}
+}
@@ -0,0 +1,90 @@
+package processor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/antoniosarro/yagbc/internal/core/gb/memory"
+)
+
+// Instruction describes one SM83 instruction, as returned by
+// InstructionInfo. It is meant for tools built on top of the
+// emulator: disassemblers, assemblers, debuggers and tutorials.
+type Instruction struct {
+	Opcode   uint8  // Opcode byte (the byte after 0xCB if Prefixed)
+	Prefixed bool   // Is this a 0xCB-prefixed instruction?
+	Mnemonic string // e.g. "LD A, B" ("UNKNOWN_0x.." if not implemented)
+	Bytes    int    // Length, including the opcode and any 0xCB prefix
+
+	// Cycles is the instruction's duration in T-cycles. For conditional
+	// instructions it is the branch-not-taken duration, and CyclesTaken
+	// the branch-taken one; otherwise both are the same.
+	Cycles      int
+	CyclesTaken int
+	Conditional bool
+
+	Flags       FlagEffects // Effect on Z, N, H and C
+	Implemented bool        // False if the CPU treats this opcode as unknown
+}
+
+// InstructionInfo returns the metadata of an instruction: the opcode
+// byte and whether it follows a 0xCB prefix.
+//
+// Example:
+//
+//	info := processor.InstructionInfo(0x80, false)
+//	// info.Mnemonic = "ADD A, B", info.Flags.String() = "Z0HC"
+func InstructionInfo(opcode uint8, prefixed bool) Instruction {
+	op := opcodeTable[opcode]
+	if prefixed {
+		op = cbTable[opcode]
+	}
+
+	info := Instruction{
+		Opcode:      opcode,
+		Prefixed:    prefixed,
+		Mnemonic:    op.Mnemonic,
+		Bytes:       op.Bytes,
+		Cycles:      op.Cycles,
+		CyclesTaken: op.Cycles,
+		Conditional: op.Conditional(),
+		Flags:       op.Flags,
+		Implemented: !strings.HasPrefix(op.Mnemonic, "UNKNOWN_"),
+	}
+	if info.Conditional {
+		info.CyclesTaken = op.CyclesTaken
+	}
+	return info
+}
+
+// Disassemble decodes the instruction at addr without executing it,
+// returning its mnemonic with the operand placeholders (n, nn, e8)
+// replaced by the actual values, and its metadata.
+//
+// Example:
+//
+//	// Memory at 0x0100: 3E 42
+//	text, info := processor.Disassemble(mem, 0x0100)
+//	// text = "LD A, 0x42", info.Bytes = 2
+func Disassemble(mem memory.Memory, addr uint16) (string, Instruction) {
+	opcode := mem.Read(addr)
+	if opcode == 0xCB {
+		info := InstructionInfo(mem.Read(addr+1), true)
+		return info.Mnemonic, info
+	}
+
+	info := InstructionInfo(opcode, false)
+	text := info.Mnemonic
+	switch {
+	case info.Bytes == 3:
+		nn := uint16(mem.Read(addr+2))<<8 | uint16(mem.Read(addr+1))
+		text = strings.Replace(text, "nn", fmt.Sprintf("0x%04X", nn), 1)
+	case info.Bytes == 2 && strings.Contains(text, "e8"):
+		// Signed offset: "SP+e8" becomes "SP+5" or "SP-3"
+		e := fmt.Sprintf("%+d", int8(mem.Read(addr+1)))
+		text = strings.Replace(strings.Replace(text, "+e8", e, 1), "e8", e, 1)
+	case info.Bytes == 2:
+		text = strings.Replace(text, "n", fmt.Sprintf("0x%02X", mem.Read(addr+1)), 1)
+	}
+	return text, info
+}
@@ -0,0 +1,107 @@
+package processor
+
+import "testing"
+
+func TestInstructionInfo(t *testing.T) {
+	tests := []struct {
+		name     string
+		opcode   uint8
+		prefixed bool
+		want     Instruction
+	}{
+		{"ADD A, B", 0x80, false, Instruction{
+			Opcode: 0x80, Mnemonic: "ADD A, B", Bytes: 1, Cycles: 4, CyclesTaken: 4,
+			Flags:       FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagAffected, C: FlagAffected},
+			Implemented: true,
+		}},
+		{"JR NZ, e8", 0x20, false, Instruction{
+			Opcode: 0x20, Mnemonic: "JR NZ, e8", Bytes: 2, Cycles: 8, CyclesTaken: 12, Conditional: true,
+			Implemented: true,
+		}},
+		{"BIT 7, H", 0x7C, true, Instruction{
+			Opcode: 0x7C, Prefixed: true, Mnemonic: "BIT 7, H", Bytes: 2, Cycles: 8, CyclesTaken: 8,
+			Flags:       FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet},
+			Implemented: true,
+		}},
+		{"SCF", 0x37, false, Instruction{
+			Opcode: 0x37, Mnemonic: "SCF", Bytes: 1, Cycles: 4, CyclesTaken: 4,
+			Flags:       FlagEffects{N: FlagReset, H: FlagReset, C: FlagSet},
+			Implemented: true,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InstructionInfo(tt.opcode, tt.prefixed); got != tt.want {
+				t.Errorf("InstructionInfo(0x%02X, %v):\n got %+v\nwant %+v", tt.opcode, tt.prefixed, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstructionInfoUnknown(t *testing.T) {
+	opcode := unknownOpcode(t)
+	if InstructionInfo(opcode, false).Implemented {
+		t.Errorf("Expected 0x%02X to be reported as not implemented", opcode)
+	}
+}
+
+func TestFlagEffectsString(t *testing.T) {
+	tests := []struct {
+		flags FlagEffects
+		want  string
+	}{
+		{FlagEffects{}, "----"},
+		{FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagAffected, C: FlagAffected}, "Z0HC"},
+		{FlagEffects{N: FlagSet, H: FlagSet}, "-11-"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.flags.String(); got != tt.want {
+			t.Errorf("Expected %q, got %q", tt.want, got)
+		}
+	}
+}
+
+// TestFlagEffectsMatchBehavior runs every implemented instruction from
+// a range of starting states and checks that flags documented as
+// unaffected, reset or set really behave that way.
+func TestFlagEffectsMatchBehavior(t *testing.T) {
+	masks := [4]uint8{FlagZ, FlagN, FlagH, FlagC}
+
+	check := func(t *testing.T, info Instruction, program []byte) {
+		effects := [4]FlagEffect{info.Flags.Z, info.Flags.N, info.Flags.H, info.Flags.C}
+		for _, f := range []uint8{0x00, 0xF0, 0x50, 0xA0} {
+			for _, a := range []uint8{0x00, 0x01, 0x0F, 0x80, 0xFF} {
+				cpu := setupCPU(program)
+				cpu.Registers.SetAF(uint16(a)<<8 | uint16(f))
+				cpu.Registers.SetBC(0x0FF0)
+				cpu.Registers.SetHL(0xC000)
+				cpu.Registers.SP = 0xD000
+				cpu.Step()
+
+				for i, effect := range effects {
+					before, after := f&masks[i] != 0, cpu.Registers.F&masks[i] != 0
+					switch {
+					case effect == FlagNotAffected && before != after,
+						effect == FlagReset && after,
+						effect == FlagSet && !after:
+						t.Fatalf("%s with F=0x%02X A=0x%02X: flag %c is %v, documented as %q",
+							info.Mnemonic, f, a, "ZNHC"[i], after, info.Flags.String())
+					}
+				}
+			}
+		}
+	}
+
+	for opcode := range 256 {
+		info := InstructionInfo(uint8(opcode), false)
+		if !info.Implemented || uint8(opcode) == 0xCB {
+			continue
+		}
+		check(t, info, []byte{uint8(opcode), 0x01, 0x00})
+	}
+	for opcode := range 256 {
+		check(t, InstructionInfo(uint8(opcode), true), []byte{0xCB, uint8(opcode)})
+	}
+}
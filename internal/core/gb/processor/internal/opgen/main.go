@@ -0,0 +1,303 @@
+// Command opgen generates the processor's opcode tables from the
+// machine-readable opcode database in opcodes.json.
+//
+// The database follows the layout of the gbops opcode tables: two
+// arrays of 256 entries, "Unprefixed" and "CBPrefixed", indexed by
+// opcode byte. Each entry gives the mnemonic, length and T-cycle
+// counts and flag effects; a "Handler" names the hand-written Go
+// function that implements it:
+//
+//	{"Name": "NOP", "Length": 1, "TCyclesBranch": 4, "TCyclesNoBranch": 4,
+//	 "Flags": {"Z": "-", "N": "-", "H": "-", "C": "-"}, "Handler": "opNOP"}
+//
+// Each flag is "-" (not affected), "0" (reset), "1" (set) or its own
+// letter (depends on the result).
+//
+// Handler is pasted into the generated code as the Execute value,
+// so it can be a function name or a call that returns one (e.g.
+// "cbBIT(3, 6)"). Entries without a Handler are not implemented
+// yet and are wired to opUnknown.
+//
+// Besides the tables, opgen writes the switch statements Step
+// dispatches through (execute and executeCB): each case calls its
+// Handler directly, which the compiler can inline, where a call
+// through Execute is always an indirect call. The CB handlers that
+// build a closure are expanded into the code they run, with the
+// operand spelled out (see directCall), so no case builds or calls
+// a closure. The 0xCB prefix gets no case in execute: Step decodes
+// it first.
+//
+// Usage (see the go:generate directive in opcodes.go):
+//
+//	go run ./internal/opgen -in opcodes.json -out opcodes_gen.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Entry describes one instruction in the opcode database.
+type Entry struct {
+	Name            string // Mnemonic, e.g. "LD A, B"
+	Length          int    // Bytes, including the opcode (and the 0xCB prefix)
+	TCyclesBranch   int    // Cycles when a conditional branch is taken
+	TCyclesNoBranch int    // Cycles otherwise
+	Flags           Flags
+	Handler         string `json:",omitempty"` // Go expression for Execute
+}
+
+// Flags holds the effect on each flag, in gbops notation.
+type Flags struct {
+	Z, N, H, C string
+}
+
+// Database holds both opcode tables.
+type Database struct {
+	Unprefixed []Entry
+	CBPrefixed []Entry
+}
+
+func main() {
+	in := flag.String("in", "opcodes.json", "opcode database to read")
+	out := flag.String("out", "opcodes_gen.go", "Go file to write")
+	flag.Parse()
+
+	f, err := os.Open(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	db, err := Load(f)
+	if err != nil {
+		log.Fatalf("%s: %v", *in, err)
+	}
+	src, err := Generate(db)
+	if err != nil {
+		log.Fatalf("%s: %v", *in, err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// Load decodes and validates an opcode database.
+func Load(r io.Reader) (*Database, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+
+	var db Database
+	if err := dec.Decode(&db); err != nil {
+		return nil, err
+	}
+	if err := validate("Unprefixed", db.Unprefixed); err != nil {
+		return nil, err
+	}
+	if err := validate("CBPrefixed", db.CBPrefixed); err != nil {
+		return nil, err
+	}
+	return &db, nil
+}
+
+func validate(table string, entries []Entry) error {
+	if len(entries) != 256 {
+		return fmt.Errorf("%s: expected 256 entries, got %d", table, len(entries))
+	}
+	for i, e := range entries {
+		switch {
+		case e.Name == "":
+			return fmt.Errorf("%s[0x%02X]: missing Name", table, i)
+		case e.Length < 1 || e.Length > 3:
+			return fmt.Errorf("%s[0x%02X] %s: Length %d out of range", table, i, e.Name, e.Length)
+		case e.TCyclesNoBranch <= 0 || e.TCyclesNoBranch%4 != 0:
+			return fmt.Errorf("%s[0x%02X] %s: TCyclesNoBranch %d is not a positive multiple of 4",
+				table, i, e.Name, e.TCyclesNoBranch)
+		case e.TCyclesBranch < e.TCyclesNoBranch || e.TCyclesBranch%4 != 0:
+			return fmt.Errorf("%s[0x%02X] %s: TCyclesBranch %d is invalid", table, i, e.Name, e.TCyclesBranch)
+		}
+		for _, flag := range []struct{ name, value string }{
+			{"Z", e.Flags.Z}, {"N", e.Flags.N}, {"H", e.Flags.H}, {"C", e.Flags.C},
+		} {
+			if _, ok := flagEffect(flag.name, flag.value); !ok {
+				return fmt.Errorf("%s[0x%02X] %s: invalid %s flag effect %q", table, i, e.Name, flag.name, flag.value)
+			}
+		}
+	}
+	return nil
+}
+
+// flagEffect maps a flag's gbops notation to the processor's FlagEffect
+// constant, or "" for the zero value (not affected).
+func flagEffect(flag, value string) (string, bool) {
+	switch value {
+	case "-":
+		return "", true
+	case "0":
+		return "FlagReset", true
+	case "1":
+		return "FlagSet", true
+	case flag:
+		return "FlagAffected", true
+	}
+	return "", false
+}
+
+// flagsLiteral returns the FlagEffects literal for f, or "" if no
+// flag is affected.
+func flagsLiteral(f Flags) string {
+	var fields []string
+	for _, flag := range []struct{ name, value string }{
+		{"Z", f.Z}, {"N", f.N}, {"H", f.H}, {"C", f.C},
+	} {
+		if effect, _ := flagEffect(flag.name, flag.value); effect != "" {
+			fields = append(fields, flag.name+": "+effect)
+		}
+	}
+	if len(fields) == 0 {
+		return ""
+	}
+	return "FlagEffects{" + strings.Join(fields, ", ") + "}"
+}
+
+// Generate returns the formatted Go source of opcodes_gen.go.
+func Generate(db *Database) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by opgen from opcodes.json; DO NOT EDIT.\n\n")
+	buf.WriteString("package processor\n\n")
+
+	buf.WriteString("// opcodeTable maps each opcode byte (0x00-0xFF) to its implementation.\n")
+	writeTable(&buf, "opcodeTable", db.Unprefixed)
+
+	buf.WriteString("\n// cbTable maps the byte following a 0xCB prefix to its implementation.\n")
+	writeTable(&buf, "cbTable", db.CBPrefixed)
+
+	buf.WriteString("\n// execute runs the instruction for an unprefixed opcode, like\n")
+	buf.WriteString("// opcodeTable[opcode].Execute but without the indirect call.\n")
+	buf.WriteString("// The 0xCB prefix is decoded by dispatch and has no case here.\n")
+	if err := writeSwitch(&buf, "execute", db.Unprefixed); err != nil {
+		return nil, err
+	}
+
+	buf.WriteString("\n// executeCB runs the instruction for the byte following a 0xCB\n")
+	buf.WriteString("// prefix, like cbTable[opcode].Execute but without the indirect call.\n")
+	if err := writeSwitch(&buf, "executeCB", db.CBPrefixed); err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+func writeTable(buf *bytes.Buffer, name string, entries []Entry) {
+	fmt.Fprintf(buf, "var %s = [256]Opcode{\n", name)
+	for i, e := range entries {
+		if e.Handler == "" {
+			// Not implemented yet: behaves like a 1-byte NOP
+			fmt.Fprintf(buf, "\t0x%02X: {Mnemonic: \"UNKNOWN_0x%02X\", Bytes: 1, Cycles: 4, Execute: opUnknown}, // %s\n",
+				i, i, e.Name)
+			continue
+		}
+
+		fmt.Fprintf(buf, "\t0x%02X: {Mnemonic: %q, Bytes: %d, Cycles: %d, ", i, e.Name, e.Length, e.TCyclesNoBranch)
+		if e.TCyclesBranch != e.TCyclesNoBranch {
+			fmt.Fprintf(buf, "CyclesTaken: %d, ", e.TCyclesBranch)
+		}
+		if flags := flagsLiteral(e.Flags); flags != "" {
+			fmt.Fprintf(buf, "Flags: %s, ", flags)
+		}
+		fmt.Fprintf(buf, "Execute: %s},\n", e.Handler)
+	}
+	buf.WriteString("}\n")
+}
+
+// prefixHandler is the Handler of the 0xCB prefix, which Step
+// decodes before calling execute.
+const prefixHandler = "opPrefixCB"
+
+// Handlers that return a closure, and the direct calls they expand
+// to in the switches.
+var (
+	cbShiftHandler = regexp.MustCompile(`^cbShift\(\(\*CPU\)\.(\w+), ([0-7])\)$`)
+	cbBitHandler   = regexp.MustCompile(`^cb(BIT|RES|SET)\(([0-7]), ([0-7])\)$`)
+	funcHandler    = regexp.MustCompile(`^\w+$`)
+)
+
+// regFields names the Registers field of each 8-bit operand, in the
+// SM83 encoding order; index 6 is the byte at (HL).
+var regFields = [8]string{"B", "C", "D", "E", "H", "L", "", "A"}
+
+// directCall returns the statement a switch case runs for handler:
+// a plain call of a handler function, or what a cbShift, cbBIT,
+// cbRES or cbSET closure does, spelled out for its operand.
+func directCall(handler string) (string, error) {
+	if m := cbShiftHandler.FindStringSubmatch(handler); m != nil {
+		op, r := m[1], m[2][0]-'0'
+		if r == 6 {
+			return fmt.Sprintf("cpu.write(cpu.Registers.HL(), cpu.%s(cpu.read(cpu.Registers.HL())))", op), nil
+		}
+		return fmt.Sprintf("cpu.Registers.%s = cpu.%s(cpu.Registers.%[1]s)", regFields[r], op), nil
+	}
+	if m := cbBitHandler.FindStringSubmatch(handler); m != nil {
+		mask, r := 1<<(m[2][0]-'0'), m[3][0]-'0'
+		operand := "cpu.Registers." + regFields[r]
+		if r == 6 {
+			operand = "cpu.read(cpu.Registers.HL())"
+		}
+		var update string // The new operand value, for RES and SET
+		switch m[1] {
+		case "BIT":
+			return fmt.Sprintf("cpu.testBit(%s, 0x%02X)", operand, mask), nil
+		case "RES":
+			update = "&^"
+		case "SET":
+			update = "|"
+		}
+		if r == 6 {
+			return fmt.Sprintf("cpu.write(cpu.Registers.HL(), %s%s0x%02X)", operand, update, mask), nil
+		}
+		return fmt.Sprintf("%s %s= 0x%02X", operand, update, mask), nil
+	}
+	if funcHandler.MatchString(handler) {
+		return handler + "(cpu)", nil
+	}
+	return "", fmt.Errorf("handler %q: cannot be called directly", handler)
+}
+
+// writeSwitch writes a CPU method that dispatches on the opcode byte.
+// Opcodes sharing a Handler (i.e. the unimplemented ones) share a
+// case, listed at the first of them.
+func writeSwitch(buf *bytes.Buffer, name string, entries []Entry) error {
+	var handlers []string
+	opcodes := map[string][]string{}
+	for i, e := range entries {
+		if e.Handler == prefixHandler {
+			continue
+		}
+		call := "opUnknown(cpu)"
+		if e.Handler != "" {
+			var err error
+			if call, err = directCall(e.Handler); err != nil {
+				return fmt.Errorf("%s: opcode 0x%02X: %w", name, i, err)
+			}
+		}
+		if _, ok := opcodes[call]; !ok {
+			handlers = append(handlers, call)
+		}
+		opcodes[call] = append(opcodes[call], fmt.Sprintf("0x%02X", i))
+	}
+
+	fmt.Fprintf(buf, "func (cpu *CPU) %s(opcode uint8) {\n\tswitch opcode {\n", name)
+	for _, call := range handlers {
+		fmt.Fprintf(buf, "\tcase %s:\n\t\t%s\n", strings.Join(opcodes[call], ", "), call)
+	}
+	buf.WriteString("\t}\n}\n")
+	return nil
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestGeneratedCodeUpToDate fails when opcodes.json was edited without
+// re-running go generate.
+func TestGeneratedCodeUpToDate(t *testing.T) {
+	f, err := os.Open("../../opcodes.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	db, err := Load(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := Generate(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile("../../opcodes_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("opcodes_gen.go is out of date; run go generate ./internal/core/gb/processor")
+	}
+}
+
+// noFlags is the Flags field of an instruction that changes no flag.
+const noFlags = `"Flags": {"Z": "-", "N": "-", "H": "-", "C": "-"}`
+
+// database returns a valid JSON database with every entry set to NOP,
+// after letting edit change the first unprefixed entry.
+func database(edit string) string {
+	nop := `{"Name": "NOP", "Length": 1, "TCyclesBranch": 4, "TCyclesNoBranch": 4, ` + noFlags + `, "Handler": "opNOP"}`
+	entries := make([]string, 256)
+	for i := range entries {
+		entries[i] = nop
+	}
+	unprefixed := append([]string{edit}, entries[1:]...)
+	return `{"Unprefixed": [` + strings.Join(unprefixed, ",") +
+		`], "CBPrefixed": [` + strings.Join(entries, ",") + `]}`
+}
+
+func TestLoadValidation(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry string
+		err   string
+	}{
+		{"valid", `{"Name": "NOP", "Length": 1, "TCyclesBranch": 4, "TCyclesNoBranch": 4, ` + noFlags + `}`, ""},
+		{"missing name", `{"Length": 1, "TCyclesBranch": 4, "TCyclesNoBranch": 4, ` + noFlags + `}`, "missing Name"},
+		{"bad length", `{"Name": "X", "Length": 4, "TCyclesBranch": 4, "TCyclesNoBranch": 4, ` + noFlags + `}`, "Length 4"},
+		{"bad cycles", `{"Name": "X", "Length": 1, "TCyclesBranch": 6, "TCyclesNoBranch": 6, ` + noFlags + `}`, "TCyclesNoBranch 6"},
+		{"taken faster", `{"Name": "X", "Length": 1, "TCyclesBranch": 4, "TCyclesNoBranch": 8, ` + noFlags + `}`, "TCyclesBranch 4"},
+		{"missing flags", `{"Name": "X", "Length": 1, "TCyclesBranch": 4, "TCyclesNoBranch": 4}`, "invalid Z flag effect"},
+		{"bad flag", `{"Name": "X", "Length": 1, "TCyclesBranch": 4, "TCyclesNoBranch": 4, "Flags": {"Z": "-", "N": "-", "H": "Z", "C": "-"}}`, "invalid H flag effect"},
+		{"unknown field", `{"Name": "X", "Length": 1, "TCyclesBranch": 4, "TCyclesNoBranch": 4, ` + noFlags + `, "Cycles": 4}`, "unknown field"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(strings.NewReader(database(tt.entry)))
+			if tt.err == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("Expected error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+
+	if _, err := Load(strings.NewReader(`{"Unprefixed": [], "CBPrefixed": []}`)); err == nil {
+		t.Error("Expected an error for a table without 256 entries")
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	entry := `{"Name": "JR NZ, e8", "Length": 2, "TCyclesBranch": 12, "TCyclesNoBranch": 8, ` + noFlags + `, "Handler": "opJR_NZ_e8"}`
+	db, err := Load(strings.NewReader(database(entry)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Leave one entry unimplemented
+	db.Unprefixed[1] = Entry{Name: "LD BC, nn", Length: 3, TCyclesBranch: 12, TCyclesNoBranch: 12}
+	// And one that changes flags
+	db.Unprefixed[3] = Entry{
+		Name: "INC B", Length: 1, TCyclesBranch: 4, TCyclesNoBranch: 4,
+		Flags: Flags{Z: "Z", N: "0", H: "H", C: "-"}, Handler: "opINC_B",
+	}
+
+	// And CB handlers that build closures in the table
+	db.CBPrefixed[0x00] = Entry{Name: "RLC B", Length: 2, TCyclesBranch: 8, TCyclesNoBranch: 8, Handler: "cbShift((*CPU).rlc, 0)"}
+	db.CBPrefixed[0x5E] = Entry{Name: "BIT 3, (HL)", Length: 2, TCyclesBranch: 12, TCyclesNoBranch: 12, Handler: "cbBIT(3, 6)"}
+	// And the prefix
+	db.Unprefixed[0xCB] = Entry{Name: "PREFIX CB", Length: 1, TCyclesBranch: 4, TCyclesNoBranch: 4, Handler: "opPrefixCB"}
+
+	src, err := Generate(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		`0x00: {Mnemonic: "JR NZ, e8", Bytes: 2, Cycles: 8, CyclesTaken: 12, Execute: opJR_NZ_e8},`,
+		`0x01: {Mnemonic: "UNKNOWN_0x01", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD BC, nn`,
+		`0x02: {Mnemonic: "NOP", Bytes: 1, Cycles: 4, Execute: opNOP},`,
+		`0x03: {Mnemonic: "INC B", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagAffected}, Execute: opINC_B},`,
+		"func (cpu *CPU) execute(opcode uint8) {\n\tswitch opcode {\n\tcase 0x00:\n\t\topJR_NZ_e8(cpu)\n\tcase 0x01:\n\t\topUnknown(cpu)\n",
+		"\tcase 0x03:\n\t\topINC_B(cpu)\n",
+		"func (cpu *CPU) executeCB(opcode uint8) {\n\tswitch opcode {\n\tcase 0x00:\n\t\tcpu.Registers.B = cpu.rlc(cpu.Registers.B)\n\tcase 0x01, 0x02,",
+		"\tcase 0x5E:\n\t\tcpu.testBit(cpu.read(cpu.Registers.HL()), 0x08)\n",
+		`0xCB: {Mnemonic: "PREFIX CB", Bytes: 1, Cycles: 4, Execute: opPrefixCB},`,
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("Expected generated code to contain:\n%s", want)
+		}
+	}
+	if strings.Contains(string(src), "opPrefixCB(cpu)") {
+		t.Error("Expected no switch case for the 0xCB prefix")
+	}
+}
+
+func TestGenerateRejectsIndirectHandlers(t *testing.T) {
+	nop := `{"Name": "NOP", "Length": 1, "TCyclesBranch": 4, "TCyclesNoBranch": 4, ` + noFlags + `, "Handler": "opNOP"}`
+	db, err := Load(strings.NewReader(database(nop)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.CBPrefixed[0x00].Handler = "cbMystery(0)"
+	if _, err := Generate(db); err == nil {
+		t.Error("Expected an error for a handler that cannot be called directly")
+	}
+}
@@ -0,0 +1,99 @@
+package processor
+
+import "github.com/antoniosarro/yagbc/internal/core/gb/memory"
+
+// Interrupt bits, as laid out in the IE (0xFFFF) and IF (0xFF0F) registers.
+// When several interrupts are pending at once, the lowest bit wins.
+const (
+	InterruptVBlank  uint8 = 0b00001 // Bit 0: PPU entered VBlank (highest priority)
+	InterruptLCDStat uint8 = 0b00010 // Bit 1: STAT condition (LYC=LY, mode change)
+	InterruptTimer   uint8 = 0b00100 // Bit 2: TIMA overflowed
+	InterruptSerial  uint8 = 0b01000 // Bit 3: Serial transfer completed
+	InterruptJoypad  uint8 = 0b10000 // Bit 4: A button was pressed (lowest priority)
+)
+
+// interruptMask covers the five interrupt bits; the upper bits of IF/IE
+// do not correspond to any source.
+const interruptMask uint8 = 0x1F
+
+// interruptVectors maps each interrupt bit (0-4) to the address of its handler.
+var interruptVectors = [5]uint16{
+	0x0040, // VBlank
+	0x0048, // LCD STAT
+	0x0050, // Timer
+	0x0058, // Serial
+	0x0060, // Joypad
+}
+
+// interruptDispatchCycles is the cost of servicing an interrupt:
+// 2 wait M-cycles, 2 M-cycles to push PC and 1 to jump to the vector.
+const interruptDispatchCycles = 20
+
+// RequestInterrupt raises one or more interrupts by setting their bits
+// in IF. Hardware components (PPU, timer, serial, joypad) call this; the
+// CPU services the request on its next Step if IE and IME allow it.
+func (cpu *CPU) RequestInterrupt(interrupt uint8) {
+	flags := cpu.Memory.Read(memory.AddrIF)
+	cpu.Memory.Write(memory.AddrIF, flags|(interrupt&interruptMask))
+}
+
+// pendingInterrupts returns the interrupts that are both requested (IF)
+// and enabled (IE). A non-zero result wakes a halted CPU, regardless of IME.
+func (cpu *CPU) pendingInterrupts() uint8 {
+	return cpu.Memory.Read(memory.AddrIE) & cpu.Memory.Read(memory.AddrIF) & interruptMask
+}
+
+// serviceInterrupt dispatches the highest-priority pending interrupt,
+// if IME is set. Dispatching:
+//  1. Clears IME, so the handler is not interrupted itself
+//  2. Pushes PC onto the stack (like CALL)
+//  3. Picks the interrupt, and acknowledges it by clearing its bit
+//     in IF
+//  4. Jumps to the interrupt's vector
+//
+// The interrupt is picked between the two bytes of the push, from IE
+// and IF as they are then. If SP was 0x0000, the high byte of PC has
+// just been written to IE at 0xFFFF, which can change the pick, or
+// leave nothing to service: dispatch then jumps to 0x0000 and clears
+// no IF bit (mooneye's ie_push).
+//
+// Returns the cycles taken, or 0 if no interrupt was serviced.
+func (cpu *CPU) serviceInterrupt() int {
+	if !cpu.IME || cpu.pendingInterrupts() == 0 {
+		return 0
+	}
+
+	cpu.IME = false
+	cpu.eiDelay = 0
+	cpu.idle() // Wait states
+	cpu.idle()
+
+	// HALT bug after EI: the fetch that fails to increment PC has
+	// not happened yet, so the return address is the HALT itself
+	if cpu.haltBug {
+		cpu.Registers.PC--
+		cpu.haltBug = false
+	}
+	pc := cpu.Registers.PC
+
+	cpu.Registers.SP--
+	cpu.write(cpu.Registers.SP, uint8(pc>>8)) // High byte, maybe into IE
+	pending := cpu.pendingInterrupts()
+	cpu.Registers.SP--
+	cpu.write(cpu.Registers.SP, uint8(pc)) // Low byte
+
+	var vector uint16 // 0x0000 if the push cancelled every interrupt
+	for bit, v := range interruptVectors {
+		interrupt := uint8(1) << bit
+		if pending&interrupt != 0 {
+			flags := cpu.Memory.Read(memory.AddrIF)
+			cpu.Memory.Write(memory.AddrIF, flags&^interrupt)
+			vector = v
+			break
+		}
+	}
+
+	cpu.pushCall(pc, vector, true)
+	cpu.Registers.PC = vector
+	return interruptDispatchCycles
+}
@@ -0,0 +1,256 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/antoniosarro/yagbc/internal/core/gb/memory"
+)
+
+func TestRequestInterrupt(t *testing.T) {
+	cpu := setupCPU([]byte{0x00})
+
+	cpu.RequestInterrupt(InterruptTimer)
+	cpu.RequestInterrupt(InterruptJoypad)
+
+	if val := cpu.Memory.Read(memory.AddrIF) & interruptMask; val != InterruptTimer|InterruptJoypad {
+		t.Errorf("Expected IF=0x%02X, got 0x%02X", InterruptTimer|InterruptJoypad, val)
+	}
+}
+
+func TestInterruptDispatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		interrupt uint8
+		vector    uint16
+	}{
+		{"VBlank", InterruptVBlank, 0x0040},
+		{"LCD STAT", InterruptLCDStat, 0x0048},
+		{"Timer", InterruptTimer, 0x0050},
+		{"Serial", InterruptSerial, 0x0058},
+		{"Joypad", InterruptJoypad, 0x0060},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu := setupCPUAt(0x1234, []byte{0x00})
+			cpu.Registers.SP = 0xD000
+			cpu.IME = true
+			cpu.Memory.Write(memory.AddrIE, 0xFF)
+			cpu.RequestInterrupt(tt.interrupt)
+
+			cycles := step(t, cpu)
+
+			if cycles != 20 {
+				t.Errorf("Expected 20 cycles, got %d", cycles)
+			}
+			if cpu.Registers.PC != tt.vector {
+				t.Errorf("Expected PC=0x%04X, got PC=0x%04X", tt.vector, cpu.Registers.PC)
+			}
+			if cpu.IME {
+				t.Error("Expected IME to be cleared by dispatch")
+			}
+			if cpu.Memory.Read(memory.AddrIF)&tt.interrupt != 0 {
+				t.Error("Expected the serviced interrupt to be cleared in IF")
+			}
+			if cpu.Registers.SP != 0xCFFE {
+				t.Errorf("Expected SP=0xCFFE, got SP=0x%04X", cpu.Registers.SP)
+			}
+			if ret := cpu.popWord(); ret != 0x1234 {
+				t.Errorf("Expected return address 0x1234 on stack, got 0x%04X", ret)
+			}
+		})
+	}
+}
+
+func TestInterruptPriority(t *testing.T) {
+	cpu := setupCPU([]byte{0x00})
+	cpu.Registers.SP = 0xD000
+	cpu.IME = true
+	cpu.Memory.Write(memory.AddrIE, 0xFF)
+	cpu.RequestInterrupt(InterruptJoypad | InterruptTimer | InterruptLCDStat)
+
+	cpu.Step()
+
+	if cpu.Registers.PC != 0x0048 {
+		t.Errorf("Expected LCD STAT (0x0048) to win, got PC=0x%04X", cpu.Registers.PC)
+	}
+	remaining := cpu.Memory.Read(memory.AddrIF) & interruptMask
+	if remaining != InterruptJoypad|InterruptTimer {
+		t.Errorf("Expected lower-priority requests to stay in IF, got 0x%02X", remaining)
+	}
+}
+
+func TestInterruptNotServiced(t *testing.T) {
+	tests := []struct {
+		name string
+		ime  bool
+		ie   uint8
+	}{
+		{"IME disabled", false, InterruptVBlank},
+		{"not enabled in IE", true, InterruptTimer},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Program: NOP
+			cpu := setupCPU([]byte{0x00})
+			cpu.IME = tt.ime
+			cpu.Memory.Write(memory.AddrIE, tt.ie)
+			cpu.RequestInterrupt(InterruptVBlank)
+
+			cycles := step(t, cpu)
+
+			if cycles != 4 || cpu.Registers.PC != 0x0001 {
+				t.Errorf("Expected NOP to run (4 cycles, PC=0x0001), got %d cycles, PC=0x%04X",
+					cycles, cpu.Registers.PC)
+			}
+			if cpu.Memory.Read(memory.AddrIF)&InterruptVBlank == 0 {
+				t.Error("Expected the request to stay pending in IF")
+			}
+		})
+	}
+}
+
+func TestInterruptAfterEI(t *testing.T) {
+	// Program: EI, NOP, NOP
+	cpu := setupCPU([]byte{0xFB, 0x00, 0x00})
+	cpu.Registers.SP = 0xD000
+	cpu.Memory.Write(memory.AddrIE, InterruptVBlank)
+	cpu.RequestInterrupt(InterruptVBlank)
+
+	cpu.Step() // EI
+	cpu.Step() // NOP - still runs, IME becomes 1 afterwards
+	if cpu.Registers.PC != 0x0002 {
+		t.Fatalf("Expected the instruction after EI to run, got PC=0x%04X", cpu.Registers.PC)
+	}
+
+	cpu.Step() // Interrupt dispatch
+	if cpu.Registers.PC != 0x0040 {
+		t.Errorf("Expected dispatch to 0x0040, got PC=0x%04X", cpu.Registers.PC)
+	}
+}
+
+func TestInterruptWakesHalt(t *testing.T) {
+	// Program: HALT, NOP
+	cpu := setupCPU([]byte{0x76, 0x00})
+	cpu.Registers.SP = 0xD000
+	cpu.IME = true
+	cpu.Memory.Write(memory.AddrIE, InterruptTimer)
+
+	cpu.Step()
+	cpu.Step()
+	if !cpu.Halted {
+		t.Fatal("Expected CPU to be halted")
+	}
+
+	cpu.RequestInterrupt(InterruptTimer)
+	cycles := step(t, cpu)
+
+	if cpu.Halted {
+		t.Fatal("Expected the interrupt to wake the CPU")
+	}
+	if cycles != 24 {
+		t.Errorf("Expected 24 cycles (dispatch + wake-up), got %d", cycles)
+	}
+	if cpu.Registers.PC != 0x0050 {
+		t.Errorf("Expected PC=0x0050, got PC=0x%04X", cpu.Registers.PC)
+	}
+	// The handler returns to the instruction after HALT
+	if ret := cpu.popWord(); ret != 0x0001 {
+		t.Errorf("Expected return address 0x0001, got 0x%04X", ret)
+	}
+}
+
+func TestHaltWakeWithoutIME(t *testing.T) {
+	// Program: HALT, LDH A, (0x0F) - a game polling IF after waking
+	cpu := setupCPU([]byte{0x76, 0xF0, 0x0F})
+	cpu.Memory.Write(memory.AddrIE, InterruptVBlank)
+
+	step(t, cpu)
+	cpu.RequestInterrupt(InterruptVBlank)
+	step(t, cpu) // Wakes up and runs LDH
+
+	if cpu.Halted {
+		t.Fatal("Expected the pending interrupt to wake the CPU")
+	}
+	if cpu.Registers.PC != 0x0003 {
+		t.Errorf("Expected execution to carry on to PC=0x0003, got PC=0x%04X", cpu.Registers.PC)
+	}
+	// Not serviced: the request is still there for the game to see
+	if cpu.Registers.A&InterruptVBlank == 0 {
+		t.Errorf("Expected IF to still hold the VBlank request, got A=0x%02X", cpu.Registers.A)
+	}
+}
+
+func TestEIHaltWithPendingInterrupt(t *testing.T) {
+	// Program: EI, HALT, INC A; RETI at the VBlank vector
+	program := make([]byte, 0x41)
+	copy(program, []byte{0xFB, 0x76, 0x3C})
+	program[0x40] = 0xD9
+	cpu := setupCPU(program)
+	cpu.Registers.SP = 0xD000
+	cpu.Memory.Write(memory.AddrIE, InterruptVBlank)
+	cpu.RequestInterrupt(InterruptVBlank)
+
+	step(t, cpu) // EI
+	step(t, cpu) // HALT: IME is still 0, so the HALT bug triggers
+	step(t, cpu) // Dispatch
+
+	if cpu.Registers.PC != 0x0040 {
+		t.Fatalf("Expected dispatch to 0x0040, got PC=0x%04X", cpu.Registers.PC)
+	}
+	// The handler returns to the HALT, not past it
+	if ret := uint16(cpu.Memory.Read(0xCFFF))<<8 | uint16(cpu.Memory.Read(0xCFFE)); ret != 0x0001 {
+		t.Errorf("Expected return address 0x0001, got 0x%04X", ret)
+	}
+
+	step(t, cpu) // RETI
+	step(t, cpu) // HALT again, with IME=1 and nothing pending
+	if !cpu.Halted || cpu.Registers.PC != 0x0002 || cpu.Registers.A != 0x00 {
+		t.Errorf("Expected HALT to run again and halt at PC=0x0002, got Halted=%v PC=0x%04X A=0x%02X",
+			cpu.Halted, cpu.Registers.PC, cpu.Registers.A)
+	}
+}
+
+// TestInterruptIEPush mirrors mooneye's ie_push: with SP at 0x0000,
+// the high byte of PC is pushed into IE, before the interrupt is
+// picked.
+func TestInterruptIEPush(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested uint8
+		vector    uint16
+		flags     uint8 // IF afterwards
+	}{
+		// IE becomes 0x02 (LCD STAT), so nothing is left to service
+		{"cancelled", InterruptTimer, 0x0000, InterruptTimer},
+		// IE becomes 0x02, so the lower-priority STAT interrupt wins
+		{"retargeted", InterruptTimer | InterruptLCDStat, 0x0048, InterruptTimer},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu := setupCPUAt(0x0234, []byte{0x00})
+			cpu.Registers.SP = 0x0000
+			cpu.IME = true
+			cpu.Memory.Write(memory.AddrIE, InterruptTimer)
+			cpu.RequestInterrupt(tt.requested)
+
+			if cycles := step(t, cpu); cycles != 20 {
+				t.Errorf("Expected 20 cycles, got %d", cycles)
+			}
+			if cpu.Registers.PC != tt.vector {
+				t.Errorf("Expected PC=0x%04X, got PC=0x%04X", tt.vector, cpu.Registers.PC)
+			}
+			if got := cpu.Memory.Read(memory.AddrIE); got != 0x02 {
+				t.Errorf("Expected the pushed high byte 0x02 in IE, got 0x%02X", got)
+			}
+			if got := cpu.Memory.Read(memory.AddrIF) & interruptMask; got != tt.flags {
+				t.Errorf("Expected IF=0x%02X, got 0x%02X", tt.flags, got)
+			}
+			if cpu.IME || cpu.Registers.SP != 0xFFFE {
+				t.Errorf("Expected IME cleared and SP=0xFFFE, got %v, 0x%04X", cpu.IME, cpu.Registers.SP)
+			}
+		})
+	}
+}
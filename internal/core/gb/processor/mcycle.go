@@ -0,0 +1,51 @@
+package processor
+
+// ============================================================
+// M-cycle mode
+// ============================================================
+// By default Step runs a whole instruction at once and reports
+// how many cycles it took, which is all a simple frontend needs.
+// Real hardware is finer-grained: every instruction is a sequence
+// of M-cycles (4 T-cycles each), and each M-cycle performs at
+// most one memory access. Whether a PPU mode change, a DMA
+// transfer or an interrupt request lands before or after a
+// given access depends on that sequence.
+//
+// Setting cpu.Tick enables M-cycle mode. All CPU memory traffic
+// goes through read/write below (or fetchByte for instruction
+// bytes), which call Tick right before each access, so the other
+// components can be stepped in lockstep with the CPU:
+//
+//	PUSH BC (16 cycles)
+//	  M1: Tick, read opcode
+//	  M2: Tick              (internal: SP decrement)
+//	  M3: Tick, write B to SP-1
+//	  M4: Tick, write C to SP-2
+//
+// Internal M-cycles that must come before an access are ticked
+// with idle(); any remaining ones are ticked when the instruction
+// finishes, so Tick is always called exactly cycles/4 times per
+// Step.
+
+// read performs a CPU memory read, taking one M-cycle.
+func (cpu *CPU) read(addr uint16) uint8 {
+	cpu.idle()
+	value := cpu.Memory.Read(addr)
+	cpu.recordAccess(addr, value, false)
+	return value
+}
+
+// write performs a CPU memory write, taking one M-cycle.
+func (cpu *CPU) write(addr uint16, value uint8) {
+	cpu.idle()
+	cpu.Memory.Write(addr, value)
+	cpu.recordAccess(addr, value, true)
+}
+
+// idle advances by one M-cycle without touching memory.
+func (cpu *CPU) idle() {
+	if cpu.Tick != nil {
+		cpu.Tick()
+		cpu.ticked += 4
+	}
+}
@@ -0,0 +1,187 @@
+package processor
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/antoniosarro/yagbc/internal/core/gb/memory"
+)
+
+// busLog records Tick calls and memory accesses in order, so tests
+// can check which M-cycle each access happened in.
+type busLog struct {
+	memory.Memory
+	events []string
+}
+
+func (b *busLog) Read(addr uint16) uint8 {
+	b.events = append(b.events, fmt.Sprintf("R%04X", addr))
+	return b.Memory.Read(addr)
+}
+
+func (b *busLog) Write(addr uint16, value uint8) {
+	b.events = append(b.events, fmt.Sprintf("W%04X", addr))
+	b.Memory.Write(addr, value)
+}
+
+// setupLoggedCPU returns a CPU in M-cycle mode whose bus activity is
+// recorded in the returned log. Every M-cycle starts with "|".
+func setupLoggedCPU(program []byte) (*CPU, *busLog) {
+	mem := memory.NewBasicMemory()
+	mem.LoadROM(program)
+	bus := &busLog{Memory: mem}
+	cpu := NewCPU(bus)
+	cpu.Tick = func() { bus.events = append(bus.events, "|") }
+	return cpu, bus
+}
+
+func TestMCycleTickCount(t *testing.T) {
+	for _, prefixed := range []bool{false, true} {
+		for opcode := range 256 {
+			info := InstructionInfo(uint8(opcode), prefixed)
+			if !info.Implemented || (!prefixed && opcode == 0xCB) {
+				continue
+			}
+			program := []byte{uint8(opcode), 0x01, 0x00}
+			if prefixed {
+				program = []byte{0xCB, uint8(opcode)}
+			}
+
+			// Both flag states, so conditional branches go both ways
+			for _, flags := range []uint8{0x00, 0xF0} {
+				mem := memory.NewBasicMemory()
+				mem.LoadROM(program)
+				cpu := NewCPU(mem)
+				cpu.Registers.F = flags
+				cpu.Registers.SP = 0xD000
+
+				ticks := 0
+				cpu.Tick = func() { ticks++ }
+				cycles, _ := cpu.Step() // Illegal opcodes lock up, still 4 cycles
+
+				if ticks*4 != cycles {
+					t.Errorf("%s (F=0x%02X): %d ticks for %d cycles", info.Mnemonic, flags, ticks, cycles)
+				}
+			}
+		}
+	}
+}
+
+func TestMCycleAccessOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		program []byte
+		setup   func(cpu *CPU)
+		want    string
+	}{
+		{
+			name:    "PUSH BC",
+			program: []byte{0xC5},
+			setup:   func(cpu *CPU) { cpu.Registers.SP = 0xD000 },
+			want:    "| R0000 | | WCFFF | WCFFE",
+		},
+		{
+			name:    "POP BC",
+			program: []byte{0xC1},
+			setup:   func(cpu *CPU) { cpu.Registers.SP = 0xCFFE },
+			want:    "| R0000 | RCFFE | RCFFF",
+		},
+		{
+			name:    "CALL nn",
+			program: []byte{0xCD, 0x00, 0x02},
+			setup:   func(cpu *CPU) { cpu.Registers.SP = 0xD000 },
+			want:    "| R0000 | R0001 | R0002 | | WCFFF | WCFFE",
+		},
+		{
+			name:    "RET Z taken",
+			program: []byte{0xC8},
+			setup: func(cpu *CPU) {
+				cpu.Registers.SP = 0xCFFE
+				cpu.Registers.F = FlagZ
+			},
+			want: "| R0000 | | RCFFE | RCFFF |",
+		},
+		{
+			name:    "JR e8",
+			program: []byte{0x18, 0x02},
+			want:    "| R0000 | R0001 |",
+		},
+		{
+			name:    "INC (HL)",
+			program: []byte{0x34},
+			setup:   func(cpu *CPU) { cpu.Registers.SetHL(0xC000) },
+			want:    "| R0000 | RC000 | WC000",
+		},
+		{
+			name:    "RES 0, (HL)",
+			program: []byte{0xCB, 0x86},
+			setup:   func(cpu *CPU) { cpu.Registers.SetHL(0xC000) },
+			want:    "| R0000 | R0001 | RC000 | WC000",
+		},
+		{
+			name:    "LD (nn), SP",
+			program: []byte{0x08, 0x00, 0xC0},
+			want:    "| R0000 | R0001 | R0002 | WC000 | WC001",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu, bus := setupLoggedCPU(tt.program)
+			if tt.setup != nil {
+				tt.setup(cpu)
+			}
+
+			cpu.Step()
+
+			if got := strings.Join(bus.events, " "); got != tt.want {
+				t.Errorf("Expected bus activity %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestMCycleInterruptDispatch(t *testing.T) {
+	cpu, bus := setupLoggedCPU([]byte{0x00})
+	cpu.Registers.SP = 0xD000
+	cpu.IME = true
+	cpu.Memory.Write(memory.AddrIE, InterruptVBlank)
+	cpu.RequestInterrupt(InterruptVBlank)
+	bus.events = nil
+
+	cycles := step(t, cpu)
+
+	// IE/IF checks are not CPU bus cycles; only the push is. The
+	// interrupt is picked (and acknowledged in IF) after the high byte
+	// is pushed
+	want := "RFFFF RFF0F | | | WCFFF RFFFF RFF0F | WCFFE RFF0F WFF0F |"
+	if got := strings.Join(bus.events, " "); got != want {
+		t.Errorf("Expected bus activity %q, got %q", want, got)
+	}
+	if cycles != 20 {
+		t.Errorf("Expected 20 cycles, got %d", cycles)
+	}
+}
+
+func TestMCycleHaltedWake(t *testing.T) {
+	cpu := setupCPU([]byte{0x76, 0x00})
+	cpu.Registers.SP = 0xD000
+	cpu.IME = true
+	cpu.Memory.Write(memory.AddrIE, InterruptTimer)
+
+	ticks := 0
+	cpu.Tick = func() { ticks++ }
+
+	cpu.Step() // HALT
+	cpu.Step() // Halted
+	if ticks != 2 {
+		t.Fatalf("Expected 2 ticks, got %d", ticks)
+	}
+
+	cpu.RequestInterrupt(InterruptTimer)
+	ticks = 0
+	if cycles := step(t, cpu); cycles != 24 || ticks != 6 {
+		t.Errorf("Expected 24 cycles in 6 ticks, got %d cycles in %d ticks", cycles, ticks)
+	}
+}
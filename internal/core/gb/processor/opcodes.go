@@ -1,111 +1,131 @@
 package processor
 
-import "fmt"
+import (
+	"fmt"
+	"log"
+)
 
 // Opcode represents a single CPU instruction.
 type Opcode struct {
-	Mnemonic string     // Human-readable name (e.g., "LD A, B")
-	Bytes    int        // Number of bytes (including opcode)
-	Cycles   int        // Number of CPU cycles
-	Execute  func(*CPU) // Function that performs the operation
+	Mnemonic string // Human-readable name (e.g., "LD A, B")
+	Bytes    int    // Number of bytes (including opcode)
+	Cycles   int    // Number of CPU cycles (branch NOT taken, if conditional)
+
+	// Execute performs the operation. It is a reference
+	// implementation only: Step runs instructions through the
+	// generated execute/executeCB switches (see dispatch), and
+	// TestDispatchMatchesTable checks that both agree. Calling
+	// Execute directly skips the decoding and timing dispatch and
+	// Step apply, e.g. the 0xCB entry runs a prefixed instruction
+	// with no cycles counted, so emulation must not use it.
+	Execute func(*CPU)
+
+	// CyclesTaken is the cycle count of a conditional instruction
+	// whose branch is taken (0 for instructions that don't branch).
+	CyclesTaken int
+
+	// Flags describes how the instruction changes Z, N, H and C.
+	Flags FlagEffects
 }
 
-// opcodeTable maps each opcode byte (0x00-0xFF) to its implementation.
-var opcodeTable [256]Opcode
+// FlagEffect describes what an instruction does to one flag.
+type FlagEffect uint8
 
-// init initializes the opcode table.
-// This runs automatically when the package is imported.
-func init() {
-	initOpcodes()
+const (
+	FlagNotAffected FlagEffect = iota // Left as it was ("-")
+	FlagReset                         // Always cleared ("0")
+	FlagSet                           // Always set ("1")
+	FlagAffected                      // Depends on the result ("Z", "N", "H" or "C")
+)
+
+// FlagEffects lists an instruction's effect on each flag.
+type FlagEffects struct {
+	Z, N, H, C FlagEffect
 }
 
-// initOpcodes registers all opcodes in the lookup table.
-func initOpcodes() {
-	// Initialize all opcodes as "UNKNOWN" first
-	for i := range 256 {
-		opcodeTable[i] = Opcode{
-			Mnemonic: fmt.Sprintf("UNKNOWN_0x%02X", i),
-			Bytes:    1,
-			Cycles:   4,
-			Execute:  opUnknown,
+// String returns the effects in the usual opcode-table notation,
+// e.g. "Z0HC" for ADD A, B: the flag's letter means affected, 0/1
+// means reset/set and - means not affected.
+func (f FlagEffects) String() string {
+	effects := [4]FlagEffect{f.Z, f.N, f.H, f.C}
+	out := []byte("ZNHC")
+	for i, effect := range effects {
+		switch effect {
+		case FlagNotAffected:
+			out[i] = '-'
+		case FlagReset:
+			out[i] = '0'
+		case FlagSet:
+			out[i] = '1'
 		}
 	}
+	return string(out)
+}
 
-	// 0x00: NOP - No Operation
-	opcodeTable[0x00] = Opcode{
-		Mnemonic: "NOP",
-		Bytes:    1,
-		Cycles:   4,
-		Execute:  opNOP,
-	}
-
-	// 0x3E: LD A, n - Load immediate 8-bit value into A
-	opcodeTable[0x3E] = Opcode{
-		Mnemonic: "LD A, n",
-		Bytes:    2,
-		Cycles:   8,
-		Execute:  opLD_A_n,
-	}
-
-	// 0x06: LD B, n - Load immediate 8-bit value into B
-	opcodeTable[0x06] = Opcode{
-		Mnemonic: "LD B, n",
-		Bytes:    2,
-		Cycles:   8,
-		Execute:  opLD_B_n,
-	}
+// Conditional reports whether the instruction's timing depends on a
+// branch being taken.
+func (op Opcode) Conditional() bool {
+	return op.CyclesTaken != 0
+}
 
-	// 0x0E: LD C, n - Load immediate 8-bit value into C
-	opcodeTable[0x0E] = Opcode{
-		Mnemonic: "LD C, n",
-		Bytes:    2,
-		Cycles:   8,
-		Execute:  opLD_C_n,
-	}
+// The opcode tables (opcodeTable and cbTable) and the switches Step
+// dispatches through (execute and executeCB) are generated from the
+// opcode database in opcodes.json: it lists every instruction's
+// mnemonic, length and cycle counts, and names the function below
+// (or in opcodes_*.go) that implements it. To add an instruction,
+// write its function, set its "Handler" in opcodes.json, and run
+// go generate.
+//
+//go:generate go run ./internal/opgen -in opcodes.json -out opcodes_gen.go
 
-	// 0x78: LD A, B - Copy register B into A
-	opcodeTable[0x78] = Opcode{
-		Mnemonic: "LD A, B",
-		Bytes:    1,
-		Cycles:   4,
-		Execute:  opLD_A_B,
-	}
+// ============================================================
+// OPCODE IMPLEMENTATIONS
+// ============================================================
 
-	// 0x79: LD A, C - Copy register C into A
-	opcodeTable[0x79] = Opcode{
-		Mnemonic: "LD A, C",
-		Bytes:    1,
-		Cycles:   4,
-		Execute:  opLD_A_C,
-	}
+// UnknownOpcodeMode selects what the CPU does when it reaches an
+// opcode that has no implementation yet.
+type UnknownOpcodeMode int
 
-	// 0x80: ADD A, B - Add B to A
-	opcodeTable[0x80] = Opcode{
-		Mnemonic: "ADD A, B",
-		Bytes:    1,
-		Cycles:   4,
-		Execute:  opADD_A_B,
-	}
+const (
+	UnknownOpcodeIgnore      UnknownOpcodeMode = iota // Treat it as NOP (default)
+	UnknownOpcodeLog                                  // Log it, then treat it as NOP
+	UnknownOpcodePanic                                // Panic with an *UnknownOpcodeError
+	UnknownOpcodeReturnError                          // Return an *UnknownOpcodeError from Step
+)
 
-	// 0xC3: JP nn - Jump to 16-bit address
-	opcodeTable[0xC3] = Opcode{
-		Mnemonic: "JP nn",
-		Bytes:    3,
-		Cycles:   16,
-		Execute:  opJP_nn,
-	}
+// UnknownOpcodeError describes an unimplemented opcode and where it was hit.
+type UnknownOpcodeError struct {
+	Opcode uint8  // The opcode byte
+	PC     uint16 // Address the opcode was fetched from
 }
 
-// ============================================================
-// OPCODE IMPLEMENTATIONS
-// ============================================================
+func (e *UnknownOpcodeError) Error() string {
+	return fmt.Sprintf("unknown opcode 0x%02X at 0x%04X", e.Opcode, e.PC)
+}
 
-// opUnknown is called for unimplemented opcodes.
-// In a real emulator, this would be an error, but for learning
-// we'll just do nothing (like NOP).
+// opUnknown is called for unimplemented opcodes. What happens next
+// depends on cpu.UnknownOpcodes; by default it does nothing (like NOP).
+// OnUnknownOpcode, if set, is always called first, whatever the mode.
+//
+// In UnknownOpcodeReturnError mode, PC is moved back onto the opcode, so the
+// CPU stays parked on it: a debugger sees exactly where execution went
+// off the rails, and every further Step reports the same error.
 func opUnknown(cpu *CPU) {
-	// For now, treat unknown opcodes as NOP
-	// In Phase 2, we might want to panic or log this
+	err := &UnknownOpcodeError{Opcode: cpu.opcode, PC: cpu.instructionPC}
+
+	if cpu.OnUnknownOpcode != nil {
+		cpu.OnUnknownOpcode(cpu, err)
+	}
+
+	switch cpu.UnknownOpcodes {
+	case UnknownOpcodeLog:
+		log.Printf("processor: %v", err)
+	case UnknownOpcodePanic:
+		panic(err)
+	case UnknownOpcodeReturnError:
+		cpu.Registers.PC = cpu.instructionPC
+		cpu.err = err
+	}
 }
 
 // ============================================================
@@ -189,36 +209,6 @@ func opLD_A_C(cpu *CPU) {
 	cpu.Registers.A = cpu.Registers.C
 }
 
-// ============================================================
-// 0x80: ADD A, B - Add B to A
-// ============================================================
-// Adds register B to register A and stores result in A.
-// B remains unchanged.
-//
-// Flags affected:
-//
-//	Z: Set if result is zero
-//	N: Reset (0) - this is an addition
-//	H: Set if carry from bit 3 to bit 4
-//	C: Set if carry from bit 7 (overflow)
-//
-// Cycles: 4
-// Bytes: 1
-func opADD_A_B(cpu *CPU) {
-	a := cpu.Registers.A
-	b := cpu.Registers.B
-	result := a + b
-
-	// Calculate flags
-	cpu.Registers.SetFlagZ(result == 0)                // Zero flag
-	cpu.Registers.SetFlagN(false)                      // Addition, so N=0
-	cpu.Registers.SetFlagH((a&0x0F)+(b&0x0F) > 0x0F)   // Half-carry
-	cpu.Registers.SetFlagC(uint16(a)+uint16(b) > 0xFF) // Carry
-
-	// Store result
-	cpu.Registers.A = result
-}
-
 // ============================================================
 // 0xC3: JP nn - Jump to 16-bit address
 // ============================================================
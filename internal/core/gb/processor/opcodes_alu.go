@@ -0,0 +1,406 @@
+package processor
+
+// carryBit returns the C flag as a number (0 or 1), ready to be
+// used as the carry-in of ADC/SBC.
+func (cpu *CPU) carryBit() uint8 {
+	if cpu.Registers.GetFlagC() {
+		return 1
+	}
+	return 0
+}
+
+// ============================================================
+// ADD A, x - 8-bit addition into A
+// ============================================================
+// Adds a value to register A and stores the result in A.
+// The source can be any 8-bit register, the byte at (HL), or an
+// immediate byte. The source is never modified.
+//
+// Every variant shares the same flag logic, implemented once in
+// addWithCarry so the ADD and ADC families can't drift apart.
+//
+// Flags affected:
+//
+//	Z: Set if result is zero
+//	N: Reset (0) - this is an addition
+//	H: Set if carry from bit 3 to bit 4
+//	C: Set if carry from bit 7 (overflow)
+//
+// Cycles: 4 for registers, 8 for (HL) and n
+// Bytes: 1 for registers and (HL), 2 for n
+
+// add8 adds value to A and updates the flags.
+func (cpu *CPU) add8(value uint8) {
+	cpu.addWithCarry(value, 0)
+}
+
+// addWithCarry computes A = A + value + carry and updates the flags.
+// carry must be 0 or 1. Both the half-carry and the carry include the
+// incoming carry, so e.g. 0x0F + 0x00 + 1 sets H even though neither
+// operand alone overflows the low nibble.
+func (cpu *CPU) addWithCarry(value, carry uint8) {
+	a := cpu.Registers.A
+	result := a + value + carry
+
+	cpu.Registers.SetFlags(
+		result == 0,                        // Zero flag
+		false,                              // Addition, so N=0
+		(a&0x0F)+(value&0x0F)+carry > 0x0F, // Half-carry
+		uint16(a)+uint16(value)+uint16(carry) > 0xFF, // Carry
+	)
+
+	cpu.Registers.A = result
+}
+
+func opADD_A_B(cpu *CPU) { cpu.add8(cpu.Registers.B) }
+func opADD_A_C(cpu *CPU) { cpu.add8(cpu.Registers.C) }
+func opADD_A_D(cpu *CPU) { cpu.add8(cpu.Registers.D) }
+func opADD_A_E(cpu *CPU) { cpu.add8(cpu.Registers.E) }
+func opADD_A_H(cpu *CPU) { cpu.add8(cpu.Registers.H) }
+func opADD_A_L(cpu *CPU) { cpu.add8(cpu.Registers.L) }
+func opADD_A_A(cpu *CPU) { cpu.add8(cpu.Registers.A) }
+
+// opADD_A_HLmem implements 0x86: ADD A, (HL).
+func opADD_A_HLmem(cpu *CPU) { cpu.add8(cpu.read(cpu.Registers.HL())) }
+
+// opADD_A_n implements 0xC6: ADD A, n.
+func opADD_A_n(cpu *CPU) { cpu.add8(cpu.fetchByte()) }
+
+// ============================================================
+// ADC A, x - 8-bit addition with carry into A
+// ============================================================
+// Like ADD, but also adds the current carry flag (0 or 1).
+// Chaining ADD on the low bytes with ADC on the high bytes is
+// how programs add numbers wider than 8 bits.
+//
+// Example:
+//
+//	A = 0xE1, B = 0x0F, C flag = 1
+//	ADC A, B  ->  A = 0xF1, H = 1 (0x1 + 0xF + 1 > 0xF)
+//
+// Flags affected:
+//
+//	Z: Set if result is zero
+//	N: Reset (0)
+//	H: Set if carry from bit 3, counting the incoming carry
+//	C: Set if carry from bit 7, counting the incoming carry
+//
+// Cycles: 4 for registers, 8 for (HL) and n
+// Bytes: 1 for registers and (HL), 2 for n
+
+// adc8 adds value plus the carry flag to A and updates the flags.
+func (cpu *CPU) adc8(value uint8) {
+	cpu.addWithCarry(value, cpu.carryBit())
+}
+
+func opADC_A_B(cpu *CPU) { cpu.adc8(cpu.Registers.B) }
+func opADC_A_C(cpu *CPU) { cpu.adc8(cpu.Registers.C) }
+func opADC_A_D(cpu *CPU) { cpu.adc8(cpu.Registers.D) }
+func opADC_A_E(cpu *CPU) { cpu.adc8(cpu.Registers.E) }
+func opADC_A_H(cpu *CPU) { cpu.adc8(cpu.Registers.H) }
+func opADC_A_L(cpu *CPU) { cpu.adc8(cpu.Registers.L) }
+func opADC_A_A(cpu *CPU) { cpu.adc8(cpu.Registers.A) }
+
+// opADC_A_HLmem implements 0x8E: ADC A, (HL).
+func opADC_A_HLmem(cpu *CPU) { cpu.adc8(cpu.read(cpu.Registers.HL())) }
+
+// opADC_A_n implements 0xCE: ADC A, n.
+func opADC_A_n(cpu *CPU) { cpu.adc8(cpu.fetchByte()) }
+
+// ============================================================
+// SBC A, x - 8-bit subtraction with carry (borrow) from A
+// ============================================================
+// Subtracts a value AND the current carry flag from A. Here the
+// carry flag acts as a "borrow" left over from a previous
+// subtraction, so SUB on the low bytes followed by SBC on the
+// high bytes subtracts multi-byte numbers.
+//
+// Example:
+//
+//	A = 0x10, B = 0x0F, C flag = 1
+//	SBC A, B  ->  A = 0x00, Z = 1, H = 1 (0x0 < 0xF + 1)
+//
+// Flags affected:
+//
+//	Z: Set if result is zero
+//	N: Set (1) - this is a subtraction
+//	H: Set if borrow from bit 4, counting the incoming carry
+//	C: Set if borrow (value + carry > A)
+//
+// Cycles: 4 for registers, 8 for (HL) and n
+// Bytes: 1 for registers and (HL), 2 for n
+
+// sbc8 subtracts value and the carry flag from A and updates the flags.
+func (cpu *CPU) sbc8(value uint8) {
+	cpu.Registers.A = cpu.subWithCarry(value, cpu.carryBit())
+}
+
+// subWithCarry computes A - value - carry, updates the flags and
+// returns the result without storing it, so that compare-style
+// instructions can reuse it. carry must be 0 or 1.
+func (cpu *CPU) subWithCarry(value, carry uint8) uint8 {
+	a := cpu.Registers.A
+	result := a - value - carry
+
+	cpu.Registers.SetFlags(
+		result == 0,                             // Zero flag
+		true,                                    // Subtraction, so N=1
+		a&0x0F < (value&0x0F)+carry,             // Half-borrow
+		uint16(a) < uint16(value)+uint16(carry), // Borrow
+	)
+
+	return result
+}
+
+func opSBC_A_B(cpu *CPU) { cpu.sbc8(cpu.Registers.B) }
+func opSBC_A_C(cpu *CPU) { cpu.sbc8(cpu.Registers.C) }
+func opSBC_A_D(cpu *CPU) { cpu.sbc8(cpu.Registers.D) }
+func opSBC_A_E(cpu *CPU) { cpu.sbc8(cpu.Registers.E) }
+func opSBC_A_H(cpu *CPU) { cpu.sbc8(cpu.Registers.H) }
+func opSBC_A_L(cpu *CPU) { cpu.sbc8(cpu.Registers.L) }
+func opSBC_A_A(cpu *CPU) { cpu.sbc8(cpu.Registers.A) }
+
+// opSBC_A_HLmem implements 0x9E: SBC A, (HL).
+func opSBC_A_HLmem(cpu *CPU) { cpu.sbc8(cpu.read(cpu.Registers.HL())) }
+
+// opSBC_A_n implements 0xDE: SBC A, n.
+func opSBC_A_n(cpu *CPU) { cpu.sbc8(cpu.fetchByte()) }
+
+// ============================================================
+// SUB A, x - 8-bit subtraction from A
+// ============================================================
+// Subtracts a value from register A and stores the result in A.
+// Shares its flag logic with SBC (with no incoming borrow).
+//
+// Example:
+//
+//	A = 0x3E, B = 0x3E
+//	SUB A, B  ->  A = 0x00, Z = 1, N = 1
+//
+// Flags affected:
+//
+//	Z: Set if result is zero
+//	N: Set (1) - this is a subtraction
+//	H: Set if borrow from bit 4 (low nibble of value > low nibble of A)
+//	C: Set if borrow (value > A)
+//
+// Cycles: 4 for registers, 8 for (HL) and n
+// Bytes: 1 for registers and (HL), 2 for n
+
+// sub8 subtracts value from A and updates the flags.
+func (cpu *CPU) sub8(value uint8) {
+	cpu.Registers.A = cpu.subWithCarry(value, 0)
+}
+
+func opSUB_A_B(cpu *CPU) { cpu.sub8(cpu.Registers.B) }
+func opSUB_A_C(cpu *CPU) { cpu.sub8(cpu.Registers.C) }
+func opSUB_A_D(cpu *CPU) { cpu.sub8(cpu.Registers.D) }
+func opSUB_A_E(cpu *CPU) { cpu.sub8(cpu.Registers.E) }
+func opSUB_A_H(cpu *CPU) { cpu.sub8(cpu.Registers.H) }
+func opSUB_A_L(cpu *CPU) { cpu.sub8(cpu.Registers.L) }
+func opSUB_A_A(cpu *CPU) { cpu.sub8(cpu.Registers.A) }
+
+// opSUB_A_HLmem implements 0x96: SUB A, (HL).
+func opSUB_A_HLmem(cpu *CPU) { cpu.sub8(cpu.read(cpu.Registers.HL())) }
+
+// opSUB_A_n implements 0xD6: SUB A, n.
+func opSUB_A_n(cpu *CPU) { cpu.sub8(cpu.fetchByte()) }
+
+// ============================================================
+// CP A, x - Compare A with a value
+// ============================================================
+// Performs SUB but throws the result away, keeping only the
+// flags. This is how every comparison and loop condition is
+// written on the Game Boy:
+//
+//	Z = 1  ->  A == value
+//	C = 1  ->  A <  value
+//	both 0 ->  A >  value
+//
+// A is never modified.
+//
+// Flags affected:
+//
+//	Z: Set if A == value
+//	N: Set (1)
+//	H: Set if borrow from bit 4
+//	C: Set if A < value
+//
+// Cycles: 4 for registers, 8 for (HL) and n
+// Bytes: 1 for registers and (HL), 2 for n
+
+// cp8 compares A with value, updating only the flags.
+func (cpu *CPU) cp8(value uint8) {
+	cpu.subWithCarry(value, 0)
+}
+
+func opCP_A_B(cpu *CPU) { cpu.cp8(cpu.Registers.B) }
+func opCP_A_C(cpu *CPU) { cpu.cp8(cpu.Registers.C) }
+func opCP_A_D(cpu *CPU) { cpu.cp8(cpu.Registers.D) }
+func opCP_A_E(cpu *CPU) { cpu.cp8(cpu.Registers.E) }
+func opCP_A_H(cpu *CPU) { cpu.cp8(cpu.Registers.H) }
+func opCP_A_L(cpu *CPU) { cpu.cp8(cpu.Registers.L) }
+func opCP_A_A(cpu *CPU) { cpu.cp8(cpu.Registers.A) }
+
+// opCP_A_HLmem implements 0xBE: CP A, (HL).
+func opCP_A_HLmem(cpu *CPU) { cpu.cp8(cpu.read(cpu.Registers.HL())) }
+
+// opCP_A_n implements 0xFE: CP A, n.
+func opCP_A_n(cpu *CPU) { cpu.cp8(cpu.fetchByte()) }
+
+// ============================================================
+// AND / XOR / OR A, x - Bitwise logic into A
+// ============================================================
+// Combine A with a value bit by bit and store the result in A.
+// Common idioms:
+//
+//	AND A, 0x0F  ->  keep only the low nibble
+//	OR A, A      ->  test A for zero without changing it
+//	XOR A, A     ->  the shortest way to set A = 0
+//
+// Carry and subtract are always cleared. H is the odd one out:
+// AND always SETS H (a quirk inherited from the Z80 design),
+// while XOR and OR always clear it.
+//
+// Flags affected:
+//
+//	Z: Set if result is zero
+//	N: Reset (0)
+//	H: Set (1) for AND, reset (0) for XOR and OR
+//	C: Reset (0)
+//
+// Cycles: 4 for registers, 8 for (HL) and n
+// Bytes: 1 for registers and (HL), 2 for n
+
+// and8 ANDs value into A and updates the flags.
+func (cpu *CPU) and8(value uint8) {
+	cpu.Registers.A &= value
+	cpu.Registers.SetFlags(cpu.Registers.A == 0, false, true, false)
+}
+
+// xor8 XORs value into A and updates the flags.
+func (cpu *CPU) xor8(value uint8) {
+	cpu.Registers.A ^= value
+	cpu.Registers.SetFlags(cpu.Registers.A == 0, false, false, false)
+}
+
+// or8 ORs value into A and updates the flags.
+func (cpu *CPU) or8(value uint8) {
+	cpu.Registers.A |= value
+	cpu.Registers.SetFlags(cpu.Registers.A == 0, false, false, false)
+}
+
+func opAND_A_B(cpu *CPU) { cpu.and8(cpu.Registers.B) }
+func opAND_A_C(cpu *CPU) { cpu.and8(cpu.Registers.C) }
+func opAND_A_D(cpu *CPU) { cpu.and8(cpu.Registers.D) }
+func opAND_A_E(cpu *CPU) { cpu.and8(cpu.Registers.E) }
+func opAND_A_H(cpu *CPU) { cpu.and8(cpu.Registers.H) }
+func opAND_A_L(cpu *CPU) { cpu.and8(cpu.Registers.L) }
+func opAND_A_A(cpu *CPU) { cpu.and8(cpu.Registers.A) }
+
+// opAND_A_HLmem implements 0xA6: AND A, (HL).
+func opAND_A_HLmem(cpu *CPU) { cpu.and8(cpu.read(cpu.Registers.HL())) }
+
+// opAND_A_n implements 0xE6: AND A, n.
+func opAND_A_n(cpu *CPU) { cpu.and8(cpu.fetchByte()) }
+
+func opXOR_A_B(cpu *CPU) { cpu.xor8(cpu.Registers.B) }
+func opXOR_A_C(cpu *CPU) { cpu.xor8(cpu.Registers.C) }
+func opXOR_A_D(cpu *CPU) { cpu.xor8(cpu.Registers.D) }
+func opXOR_A_E(cpu *CPU) { cpu.xor8(cpu.Registers.E) }
+func opXOR_A_H(cpu *CPU) { cpu.xor8(cpu.Registers.H) }
+func opXOR_A_L(cpu *CPU) { cpu.xor8(cpu.Registers.L) }
+func opXOR_A_A(cpu *CPU) { cpu.xor8(cpu.Registers.A) }
+
+// opXOR_A_HLmem implements 0xAE: XOR A, (HL).
+func opXOR_A_HLmem(cpu *CPU) { cpu.xor8(cpu.read(cpu.Registers.HL())) }
+
+// opXOR_A_n implements 0xEE: XOR A, n.
+func opXOR_A_n(cpu *CPU) { cpu.xor8(cpu.fetchByte()) }
+
+func opOR_A_B(cpu *CPU) { cpu.or8(cpu.Registers.B) }
+func opOR_A_C(cpu *CPU) { cpu.or8(cpu.Registers.C) }
+func opOR_A_D(cpu *CPU) { cpu.or8(cpu.Registers.D) }
+func opOR_A_E(cpu *CPU) { cpu.or8(cpu.Registers.E) }
+func opOR_A_H(cpu *CPU) { cpu.or8(cpu.Registers.H) }
+func opOR_A_L(cpu *CPU) { cpu.or8(cpu.Registers.L) }
+func opOR_A_A(cpu *CPU) { cpu.or8(cpu.Registers.A) }
+
+// opOR_A_HLmem implements 0xB6: OR A, (HL).
+func opOR_A_HLmem(cpu *CPU) { cpu.or8(cpu.read(cpu.Registers.HL())) }
+
+// opOR_A_n implements 0xF6: OR A, n.
+func opOR_A_n(cpu *CPU) { cpu.or8(cpu.fetchByte()) }
+
+// ============================================================
+// INC x / DEC x - 8-bit increment and decrement
+// ============================================================
+// Adds or subtracts 1 from a register or from the byte at (HL).
+//
+// Unlike ADD/SUB, these leave the carry flag ALONE. That lets a
+// loop counter be decremented in the middle of a multi-byte
+// ADC/SBC chain without destroying the carry being propagated.
+//
+// The half-carry is easy to predict because the operand is 1:
+//
+//	INC: H = 1 when the low nibble was 0xF (0x?F -> 0x?0)
+//	DEC: H = 1 when the low nibble was 0x0 (0x?0 -> 0x?F)
+//
+// The (HL) forms read, modify and write back memory, which is
+// why they take 12 cycles instead of 4.
+//
+// Flags affected:
+//
+//	Z: Set if result is zero
+//	N: Reset (0) for INC, set (1) for DEC
+//	H: Set on carry from / borrow into bit 4
+//	C: Not affected
+//
+// Cycles: 4 for registers, 12 for (HL)
+// Bytes: 1
+
+// inc8 returns value+1 and updates Z, N and H (C is preserved).
+func (cpu *CPU) inc8(value uint8) uint8 {
+	result := value + 1
+	cpu.Registers.SetFlagZ(result == 0)
+	cpu.Registers.SetFlagN(false)
+	cpu.Registers.SetFlagH(value&0x0F == 0x0F)
+	return result
+}
+
+// dec8 returns value-1 and updates Z, N and H (C is preserved).
+func (cpu *CPU) dec8(value uint8) uint8 {
+	result := value - 1
+	cpu.Registers.SetFlagZ(result == 0)
+	cpu.Registers.SetFlagN(true)
+	cpu.Registers.SetFlagH(value&0x0F == 0x00)
+	return result
+}
+
+func opINC_B(cpu *CPU) { cpu.Registers.B = cpu.inc8(cpu.Registers.B) }
+func opINC_C(cpu *CPU) { cpu.Registers.C = cpu.inc8(cpu.Registers.C) }
+func opINC_D(cpu *CPU) { cpu.Registers.D = cpu.inc8(cpu.Registers.D) }
+func opINC_E(cpu *CPU) { cpu.Registers.E = cpu.inc8(cpu.Registers.E) }
+func opINC_H(cpu *CPU) { cpu.Registers.H = cpu.inc8(cpu.Registers.H) }
+func opINC_L(cpu *CPU) { cpu.Registers.L = cpu.inc8(cpu.Registers.L) }
+func opINC_A(cpu *CPU) { cpu.Registers.A = cpu.inc8(cpu.Registers.A) }
+
+func opDEC_B(cpu *CPU) { cpu.Registers.B = cpu.dec8(cpu.Registers.B) }
+func opDEC_C(cpu *CPU) { cpu.Registers.C = cpu.dec8(cpu.Registers.C) }
+func opDEC_D(cpu *CPU) { cpu.Registers.D = cpu.dec8(cpu.Registers.D) }
+func opDEC_E(cpu *CPU) { cpu.Registers.E = cpu.dec8(cpu.Registers.E) }
+func opDEC_H(cpu *CPU) { cpu.Registers.H = cpu.dec8(cpu.Registers.H) }
+func opDEC_L(cpu *CPU) { cpu.Registers.L = cpu.dec8(cpu.Registers.L) }
+func opDEC_A(cpu *CPU) { cpu.Registers.A = cpu.dec8(cpu.Registers.A) }
+
+// opINC_HLmem implements 0x34: INC (HL).
+func opINC_HLmem(cpu *CPU) {
+	hl := cpu.Registers.HL()
+	cpu.write(hl, cpu.inc8(cpu.read(hl)))
+}
+
+// opDEC_HLmem implements 0x35: DEC (HL).
+func opDEC_HLmem(cpu *CPU) {
+	hl := cpu.Registers.HL()
+	cpu.write(hl, cpu.dec8(cpu.read(hl)))
+}
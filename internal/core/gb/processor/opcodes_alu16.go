@@ -0,0 +1,93 @@
+package processor
+
+// ============================================================
+// INC rr / DEC rr - 16-bit increment and decrement
+// ============================================================
+// Adds or subtracts 1 from a register pair (or SP), wrapping at
+// 0xFFFF/0x0000. These are pointer-stepping instructions, so
+// unlike their 8-bit cousins they do not touch ANY flag - a
+// "DEC BC" loop counter has to be tested with "LD A, B; OR C".
+//
+// Flags: None affected
+// Cycles: 8
+// Bytes: 1
+
+func opINC_BC(cpu *CPU) { cpu.Registers.SetBC(cpu.Registers.BC() + 1) }
+func opINC_DE(cpu *CPU) { cpu.Registers.SetDE(cpu.Registers.DE() + 1) }
+func opINC_HL(cpu *CPU) { cpu.Registers.SetHL(cpu.Registers.HL() + 1) }
+func opINC_SP(cpu *CPU) { cpu.Registers.SP++ }
+
+func opDEC_BC(cpu *CPU) { cpu.Registers.SetBC(cpu.Registers.BC() - 1) }
+func opDEC_DE(cpu *CPU) { cpu.Registers.SetDE(cpu.Registers.DE() - 1) }
+func opDEC_HL(cpu *CPU) { cpu.Registers.SetHL(cpu.Registers.HL() - 1) }
+func opDEC_SP(cpu *CPU) { cpu.Registers.SP-- }
+
+// ============================================================
+// ADD HL, rr - 16-bit addition into HL
+// ============================================================
+// Adds a register pair (or SP) to HL. Typically used to index
+// into tables: "LD DE, offset; ADD HL, DE".
+//
+// The flags follow the 16-bit result, so the "half" carry is the
+// carry out of bit 11 (the low nibble of H), not bit 3. Z is left
+// untouched - a 16-bit add never reports a zero result.
+//
+// Example:
+//
+//	HL = 0x0FFF, BC = 0x0001
+//	ADD HL, BC  ->  HL = 0x1000, H = 1
+//
+// Flags affected:
+//
+//	Z: Not affected
+//	N: Reset (0)
+//	H: Set if carry from bit 11
+//	C: Set if carry from bit 15
+//
+// Cycles: 8
+// Bytes: 1
+
+// addHL adds value to HL and updates N, H and C (Z is preserved).
+func (cpu *CPU) addHL(value uint16) {
+	hl := cpu.Registers.HL()
+
+	cpu.Registers.SetFlagN(false)
+	cpu.Registers.SetFlagH((hl&0x0FFF)+(value&0x0FFF) > 0x0FFF)
+	cpu.Registers.SetFlagC(uint32(hl)+uint32(value) > 0xFFFF)
+
+	cpu.Registers.SetHL(hl + value)
+}
+
+func opADD_HL_BC(cpu *CPU) { cpu.addHL(cpu.Registers.BC()) }
+func opADD_HL_DE(cpu *CPU) { cpu.addHL(cpu.Registers.DE()) }
+func opADD_HL_HL(cpu *CPU) { cpu.addHL(cpu.Registers.HL()) }
+func opADD_HL_SP(cpu *CPU) { cpu.addHL(cpu.Registers.SP) }
+
+// ============================================================
+// 0xE8: ADD SP, e8 - Add signed offset to SP
+// ============================================================
+// Moves the stack pointer by a signed 8-bit offset (-128..+127).
+// Used to allocate ("ADD SP, -4") or free ("ADD SP, 4") room for
+// local variables on the stack.
+//
+// Shares its flag rules with LD HL, SP+e8: H and C come from
+// the unsigned addition of the offset byte to the low byte of SP,
+// even for negative offsets, and Z is always cleared.
+//
+// Example:
+//
+//	SP = 0xFFFE, Memory: [0xE8] [0xFC] (-4)
+//	Result: SP = 0xFFFA, H = 1, C = 1 (0xFE + 0xFC carries)
+//
+// Flags affected:
+//
+//	Z: Reset (0)
+//	N: Reset (0)
+//	H: Set if carry from bit 3 of the low-byte addition
+//	C: Set if carry from bit 7 of the low-byte addition
+//
+// Cycles: 16
+// Bytes: 2
+func opADD_SP_e8(cpu *CPU) {
+	cpu.Registers.SP = cpu.addSPOffset()
+}
@@ -0,0 +1,162 @@
+package processor
+
+import "testing"
+
+// pairTarget describes a 16-bit register operand used by the
+// 16-bit arithmetic tests.
+type pairTarget struct {
+	name string
+	set  func(r *Registers, value uint16)
+	get  func(r *Registers) uint16
+}
+
+var (
+	pairBC = pairTarget{"BC", (*Registers).SetBC, (*Registers).BC}
+	pairDE = pairTarget{"DE", (*Registers).SetDE, (*Registers).DE}
+	pairHL = pairTarget{"HL", (*Registers).SetHL, (*Registers).HL}
+	pairSP = pairTarget{"SP",
+		func(r *Registers, v uint16) { r.SP = v },
+		func(r *Registers) uint16 { return r.SP }}
+)
+
+func TestOpINC_DEC_rr(t *testing.T) {
+	tests := []struct {
+		opcode uint8
+		pair   pairTarget
+		value  uint16
+		result uint16
+	}{
+		{0x03, pairBC, 0x12FF, 0x1300}, // Carry from low into high byte
+		{0x13, pairDE, 0xFFFF, 0x0000}, // Wraps around
+		{0x23, pairHL, 0xC000, 0xC001},
+		{0x33, pairSP, 0xFFFE, 0xFFFF},
+		{0x0B, pairBC, 0x1300, 0x12FF}, // Borrow from high byte
+		{0x1B, pairDE, 0x0000, 0xFFFF}, // Wraps around
+		{0x2B, pairHL, 0xC001, 0xC000},
+		{0x3B, pairSP, 0xFFFE, 0xFFFD},
+	}
+
+	for _, tt := range tests {
+		t.Run(opcodeTable[tt.opcode].Mnemonic, func(t *testing.T) {
+			cpu := setupCPU([]byte{tt.opcode})
+			tt.pair.set(cpu.Registers, tt.value)
+			cpu.Registers.F = 0xA0 // Arbitrary flags that must survive
+
+			cycles := step(t, cpu)
+
+			if cycles != 8 {
+				t.Errorf("Expected 8 cycles, got %d", cycles)
+			}
+			if got := tt.pair.get(cpu.Registers); got != tt.result {
+				t.Errorf("Expected %s=0x%04X, got 0x%04X", tt.pair.name, tt.result, got)
+			}
+			if cpu.Registers.F != 0xA0 {
+				t.Errorf("Flags should be unchanged, got F=0x%02X", cpu.Registers.F)
+			}
+		})
+	}
+}
+
+func TestOpADD_HL_rr(t *testing.T) {
+	operands := []struct {
+		opcode uint8
+		pair   pairTarget
+	}{
+		{0x09, pairBC},
+		{0x19, pairDE},
+		{0x39, pairSP},
+	}
+	cases := []struct {
+		name      string
+		hl, value uint16
+		result    uint16
+		h, c      bool
+	}{
+		{"no carries", 0x1234, 0x0101, 0x1335, false, false},
+		// Bit 3 carries don't count for 16-bit adds
+		{"low nibble carry ignored", 0x000F, 0x0001, 0x0010, false, false},
+		{"low byte carry ignored", 0x00FF, 0x0001, 0x0100, false, false},
+		{"carry from bit 11", 0x0FFF, 0x0001, 0x1000, true, false},
+		{"carry from bit 15", 0xF000, 0x1000, 0x0000, false, true},
+		{"both carries", 0xFFFF, 0x0001, 0x0000, true, true},
+	}
+
+	for _, op := range operands {
+		for _, tc := range cases {
+			for _, zeroIn := range []bool{false, true} {
+				cpu := setupCPU([]byte{op.opcode})
+				cpu.Registers.SetHL(tc.hl)
+				op.pair.set(cpu.Registers, tc.value)
+				cpu.Registers.SetFlags(zeroIn, true, false, false)
+
+				cycles := step(t, cpu)
+
+				if cycles != 8 {
+					t.Errorf("ADD HL, %s: expected 8 cycles, got %d", op.pair.name, cycles)
+				}
+				if cpu.Registers.HL() != tc.result {
+					t.Errorf("ADD HL, %s %s: expected HL=0x%04X, got 0x%04X", op.pair.name, tc.name, tc.result, cpu.Registers.HL())
+				}
+				// Z keeps whatever value it had, even when HL becomes 0
+				if cpu.Registers.GetFlagZ() != zeroIn || cpu.Registers.GetFlagN() ||
+					cpu.Registers.GetFlagH() != tc.h || cpu.Registers.GetFlagC() != tc.c {
+					t.Errorf("ADD HL, %s %s (Z=%v): wrong flags F=0x%02X", op.pair.name, tc.name, zeroIn, cpu.Registers.F)
+				}
+			}
+		}
+	}
+}
+
+func TestOpADD_HL_HL(t *testing.T) {
+	// ADD HL, HL doubles HL (a 16-bit shift left)
+	cpu := setupCPU([]byte{0x29})
+	cpu.Registers.SetHL(0x8800)
+
+	cpu.Step()
+
+	if cpu.Registers.HL() != 0x1000 {
+		t.Errorf("Expected HL=0x1000, got HL=0x%04X", cpu.Registers.HL())
+	}
+	checkFlags(t, cpu.Registers, false, false, true, true)
+}
+
+func TestOpADD_SP_e8(t *testing.T) {
+	tests := []struct {
+		name   string
+		sp     uint16
+		offset uint8
+		result uint16
+		h, c   bool
+	}{
+		{"positive", 0xFFF0, 0x08, 0xFFF8, false, false},
+		{"positive half-carry", 0xFFF8, 0x08, 0x0000, true, true},
+		{"carry into high byte", 0xC0F0, 0x10, 0xC100, false, true},
+		// Negative offsets still compute flags on the unsigned bytes
+		{"minus one", 0xFFFE, 0xFF, 0xFFFD, true, true},
+		{"minus four", 0xFFFE, 0xFC, 0xFFFA, true, true},
+		{"negative without carries", 0xC000, 0xF0, 0xBFF0, false, false},
+		{"minus 128", 0xC080, 0x80, 0xC000, false, true},
+		{"negative wraps below zero", 0x0000, 0xFF, 0xFFFF, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu := setupCPU([]byte{0xE8, tt.offset})
+			cpu.Registers.SP = tt.sp
+			cpu.Registers.SetFlags(true, true, false, false) // Z and N must be cleared
+
+			cycles := step(t, cpu)
+
+			if cycles != 16 {
+				t.Errorf("Expected 16 cycles, got %d", cycles)
+			}
+			if cpu.Registers.SP != tt.result {
+				t.Errorf("Expected SP=0x%04X, got SP=0x%04X", tt.result, cpu.Registers.SP)
+			}
+			if cpu.Registers.PC != 2 {
+				t.Errorf("Expected PC=2, got PC=%d", cpu.Registers.PC)
+			}
+			checkFlags(t, cpu.Registers, false, false, tt.h, tt.c)
+		})
+	}
+}
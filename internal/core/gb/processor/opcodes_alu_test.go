@@ -0,0 +1,638 @@
+package processor
+
+import "testing"
+
+// aluSource describes how an ALU test case puts its operand in place.
+// Each entry sets up the operand for one opcode of a register family
+// on a freshly created CPU.
+type aluSource struct {
+	name   string
+	offset uint8 // Opcode offset within the family (0=B ... 6=(HL), 7=A)
+	cycles int
+	set    func(cpu *CPU, value uint8)
+}
+
+// aluSources lists the operands of the 0x80-0xBF register families.
+// The (HL) source places the value in WRAM and points HL at it.
+// A (offset 7) is left out because its operand is A itself, so each
+// family tests it separately.
+var aluSources = []aluSource{
+	{"B", 0, 4, func(cpu *CPU, v uint8) { cpu.Registers.B = v }},
+	{"C", 1, 4, func(cpu *CPU, v uint8) { cpu.Registers.C = v }},
+	{"D", 2, 4, func(cpu *CPU, v uint8) { cpu.Registers.D = v }},
+	{"E", 3, 4, func(cpu *CPU, v uint8) { cpu.Registers.E = v }},
+	{"H", 4, 4, func(cpu *CPU, v uint8) { cpu.Registers.H = v }},
+	{"L", 5, 4, func(cpu *CPU, v uint8) { cpu.Registers.L = v }},
+	{"(HL)", 6, 8, func(cpu *CPU, v uint8) {
+		cpu.Registers.SetHL(0xC000)
+		cpu.Memory.Write(0xC000, v)
+	}},
+}
+
+// checkFlags reports any flag that doesn't match the expected value.
+func checkFlags(t *testing.T, regs *Registers, z, n, h, c bool) {
+	t.Helper()
+	if regs.GetFlagZ() != z {
+		t.Errorf("Expected Z=%v, got Z=%v", z, regs.GetFlagZ())
+	}
+	if regs.GetFlagN() != n {
+		t.Errorf("Expected N=%v, got N=%v", n, regs.GetFlagN())
+	}
+	if regs.GetFlagH() != h {
+		t.Errorf("Expected H=%v, got H=%v", h, regs.GetFlagH())
+	}
+	if regs.GetFlagC() != c {
+		t.Errorf("Expected C=%v, got C=%v", c, regs.GetFlagC())
+	}
+}
+
+func TestOpADD_A_r(t *testing.T) {
+	// 0x3A + 0xC6 = 0x100: zero result with both carries
+	for _, src := range aluSources {
+		t.Run(src.name, func(t *testing.T) {
+			cpu := setupCPU([]byte{0x80 + src.offset})
+			cpu.Registers.A = 0x3A
+			src.set(cpu, 0xC6)
+
+			cycles := step(t, cpu)
+
+			if cycles != src.cycles {
+				t.Errorf("Expected %d cycles, got %d", src.cycles, cycles)
+			}
+			if cpu.Registers.A != 0x00 {
+				t.Errorf("Expected A=0x00, got A=0x%02X", cpu.Registers.A)
+			}
+			checkFlags(t, cpu.Registers, true, false, true, true)
+		})
+	}
+}
+
+func TestOpADD_A_A(t *testing.T) {
+	// ADD A, A doubles A: 0x88 + 0x88 = 0x110
+	cpu := setupCPU([]byte{0x87})
+	cpu.Registers.A = 0x88
+
+	cpu.Step()
+
+	if cpu.Registers.A != 0x10 {
+		t.Errorf("Expected A=0x10, got A=0x%02X", cpu.Registers.A)
+	}
+	checkFlags(t, cpu.Registers, false, false, true, true)
+}
+
+func TestOpADD_A_n(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, n    uint8
+		result  uint8
+		z, h, c bool
+	}{
+		{"no flags", 0x12, 0x34, 0x46, false, false, false},
+		{"half-carry", 0x0F, 0x01, 0x10, false, true, false},
+		{"carry", 0xF0, 0x20, 0x10, false, false, true},
+		{"zero with carry", 0xFF, 0x01, 0x00, true, true, true},
+		{"zero without carry", 0x00, 0x00, 0x00, true, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu := setupCPU([]byte{0xC6, tt.n})
+			cpu.Registers.A = tt.a
+			cpu.Registers.SetFlagN(true) // ADD must clear N
+
+			cycles := step(t, cpu)
+
+			if cycles != 8 {
+				t.Errorf("Expected 8 cycles, got %d", cycles)
+			}
+			if cpu.Registers.PC != 2 {
+				t.Errorf("Expected PC=2, got PC=%d", cpu.Registers.PC)
+			}
+			if cpu.Registers.A != tt.result {
+				t.Errorf("Expected A=0x%02X, got A=0x%02X", tt.result, cpu.Registers.A)
+			}
+			checkFlags(t, cpu.Registers, tt.z, false, tt.h, tt.c)
+		})
+	}
+}
+
+func TestOpADC_A_r(t *testing.T) {
+	// 0x3A + 0xC5 + carry = 0x100: the incoming carry causes the wrap
+	for _, src := range aluSources {
+		t.Run(src.name, func(t *testing.T) {
+			cpu := setupCPU([]byte{0x88 + src.offset})
+			cpu.Registers.A = 0x3A
+			src.set(cpu, 0xC5)
+			cpu.Registers.SetFlagC(true)
+
+			cycles := step(t, cpu)
+
+			if cycles != src.cycles {
+				t.Errorf("Expected %d cycles, got %d", src.cycles, cycles)
+			}
+			if cpu.Registers.A != 0x00 {
+				t.Errorf("Expected A=0x00, got A=0x%02X", cpu.Registers.A)
+			}
+			checkFlags(t, cpu.Registers, true, false, true, true)
+		})
+	}
+}
+
+func TestOpADC_A_n(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, n    uint8
+		carryIn bool
+		result  uint8
+		z, h, c bool
+	}{
+		{"no carry in", 0x12, 0x34, false, 0x46, false, false, false},
+		{"carry in", 0x12, 0x34, true, 0x47, false, false, false},
+		// Neither operand overflows the low nibble; the carry-in does
+		{"half-carry from carry in", 0x0F, 0x00, true, 0x10, false, true, false},
+		{"carry from carry in", 0xFF, 0x00, true, 0x00, true, true, true},
+		{"both maxed", 0xFF, 0xFF, true, 0xFF, false, true, true},
+		{"zero", 0x00, 0x00, false, 0x00, true, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu := setupCPU([]byte{0xCE, tt.n})
+			cpu.Registers.A = tt.a
+			cpu.Registers.SetFlags(false, true, false, tt.carryIn)
+
+			cycles := step(t, cpu)
+
+			if cycles != 8 {
+				t.Errorf("Expected 8 cycles, got %d", cycles)
+			}
+			if cpu.Registers.A != tt.result {
+				t.Errorf("Expected A=0x%02X, got A=0x%02X", tt.result, cpu.Registers.A)
+			}
+			checkFlags(t, cpu.Registers, tt.z, false, tt.h, tt.c)
+		})
+	}
+}
+
+func TestOpADC_A_A(t *testing.T) {
+	// 0x80 + 0x80 + 1 = 0x101
+	cpu := setupCPU([]byte{0x8F})
+	cpu.Registers.A = 0x80
+	cpu.Registers.SetFlagC(true)
+
+	cpu.Step()
+
+	if cpu.Registers.A != 0x01 {
+		t.Errorf("Expected A=0x01, got A=0x%02X", cpu.Registers.A)
+	}
+	checkFlags(t, cpu.Registers, false, false, false, true)
+}
+
+func TestOpSBC_A_r(t *testing.T) {
+	// 0x10 - 0x0F - carry = 0x00: borrow from the low nibble, none overall
+	for _, src := range aluSources {
+		t.Run(src.name, func(t *testing.T) {
+			cpu := setupCPU([]byte{0x98 + src.offset})
+			cpu.Registers.A = 0x10
+			src.set(cpu, 0x0F)
+			cpu.Registers.SetFlagC(true)
+
+			cycles := step(t, cpu)
+
+			if cycles != src.cycles {
+				t.Errorf("Expected %d cycles, got %d", src.cycles, cycles)
+			}
+			if cpu.Registers.A != 0x00 {
+				t.Errorf("Expected A=0x00, got A=0x%02X", cpu.Registers.A)
+			}
+			checkFlags(t, cpu.Registers, true, true, true, false)
+		})
+	}
+}
+
+func TestOpSBC_A_n(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, n    uint8
+		carryIn bool
+		result  uint8
+		z, h, c bool
+	}{
+		{"no borrow", 0x46, 0x34, false, 0x12, false, false, false},
+		{"borrow in", 0x46, 0x34, true, 0x11, false, false, false},
+		// 0x0 - 0x0 - 1 borrows from both the nibble and the byte
+		{"borrow from carry in", 0x00, 0x00, true, 0xFF, false, true, true},
+		{"half-borrow from carry in", 0x10, 0x00, true, 0x0F, false, true, false},
+		{"exact with carry in", 0x01, 0x00, true, 0x00, true, false, false},
+		// Value + carry = 0x100 can't fit in a byte; must still borrow
+		{"value 0xFF plus carry", 0xFF, 0xFF, true, 0xFF, false, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu := setupCPU([]byte{0xDE, tt.n})
+			cpu.Registers.A = tt.a
+			cpu.Registers.SetFlags(false, false, false, tt.carryIn)
+
+			cycles := step(t, cpu)
+
+			if cycles != 8 {
+				t.Errorf("Expected 8 cycles, got %d", cycles)
+			}
+			if cpu.Registers.A != tt.result {
+				t.Errorf("Expected A=0x%02X, got A=0x%02X", tt.result, cpu.Registers.A)
+			}
+			checkFlags(t, cpu.Registers, tt.z, true, tt.h, tt.c)
+		})
+	}
+}
+
+func TestOpSBC_A_A(t *testing.T) {
+	// A - A - 1 is always 0xFF with every borrow set
+	cpu := setupCPU([]byte{0x9F})
+	cpu.Registers.A = 0x42
+	cpu.Registers.SetFlagC(true)
+
+	cpu.Step()
+
+	if cpu.Registers.A != 0xFF {
+		t.Errorf("Expected A=0xFF, got A=0x%02X", cpu.Registers.A)
+	}
+	checkFlags(t, cpu.Registers, false, true, true, true)
+}
+
+// TestCarryArithmeticExhaustive checks ADC and SBC for every
+// combination of A, operand and carry-in against a reference
+// model built on plain int arithmetic. The half-carry reference
+// uses the XOR trick: bit 4 of (a ^ b ^ result) is exactly the
+// carry/borrow that crossed from bit 3 into bit 4.
+func TestCarryArithmeticExhaustive(t *testing.T) {
+	// The programs run from WRAM, so the operand can be patched
+	adc := setupCPU(nil)
+	adc.Memory.Write(0xC000, 0xCE)
+	sbc := setupCPU(nil)
+	sbc.Memory.Write(0xC000, 0xDE)
+
+	for a := range 256 {
+		for n := range 256 {
+			for carry := range 2 {
+				// ADC A, n
+				adc.Memory.Write(0xC001, uint8(n))
+				adc.Registers.PC = 0xC000
+				adc.Registers.A = uint8(a)
+				adc.Registers.SetFlagC(carry == 1)
+				adc.Step()
+
+				sum := a + n + carry
+				if adc.Registers.A != uint8(sum) ||
+					adc.Registers.GetFlagZ() != (uint8(sum) == 0) ||
+					adc.Registers.GetFlagN() ||
+					adc.Registers.GetFlagH() != ((a^n^sum)&0x10 != 0) ||
+					adc.Registers.GetFlagC() != (sum > 0xFF) {
+					t.Fatalf("ADC 0x%02X + 0x%02X + %d: got A=0x%02X F=0x%02X", a, n, carry, adc.Registers.A, adc.Registers.F)
+				}
+
+				// SBC A, n
+				sbc.Memory.Write(0xC001, uint8(n))
+				sbc.Registers.PC = 0xC000
+				sbc.Registers.A = uint8(a)
+				sbc.Registers.SetFlagC(carry == 1)
+				sbc.Step()
+
+				diff := a - n - carry
+				if sbc.Registers.A != uint8(diff) ||
+					sbc.Registers.GetFlagZ() != (uint8(diff) == 0) ||
+					!sbc.Registers.GetFlagN() ||
+					sbc.Registers.GetFlagH() != ((a^n^diff)&0x10 != 0) ||
+					sbc.Registers.GetFlagC() != (diff < 0) {
+					t.Fatalf("SBC 0x%02X - 0x%02X - %d: got A=0x%02X F=0x%02X", a, n, carry, sbc.Registers.A, sbc.Registers.F)
+				}
+			}
+		}
+	}
+}
+
+func TestOpSUB_A_r(t *testing.T) {
+	// 0x40 - 0x01 = 0x3F: half-borrow without a full borrow
+	for _, src := range aluSources {
+		t.Run(src.name, func(t *testing.T) {
+			cpu := setupCPU([]byte{0x90 + src.offset})
+			cpu.Registers.A = 0x40
+			src.set(cpu, 0x01)
+			cpu.Registers.SetFlagC(true) // SUB ignores the incoming carry
+
+			cycles := step(t, cpu)
+
+			if cycles != src.cycles {
+				t.Errorf("Expected %d cycles, got %d", src.cycles, cycles)
+			}
+			if cpu.Registers.A != 0x3F {
+				t.Errorf("Expected A=0x3F, got A=0x%02X", cpu.Registers.A)
+			}
+			checkFlags(t, cpu.Registers, false, true, true, false)
+		})
+	}
+}
+
+func TestOpSUB_A_A(t *testing.T) {
+	// SUB A, A always clears A and sets Z
+	cpu := setupCPU([]byte{0x97})
+	cpu.Registers.A = 0x5C
+
+	cpu.Step()
+
+	if cpu.Registers.A != 0x00 {
+		t.Errorf("Expected A=0x00, got A=0x%02X", cpu.Registers.A)
+	}
+	checkFlags(t, cpu.Registers, true, true, false, false)
+}
+
+func TestOpSUB_A_n(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, n    uint8
+		result  uint8
+		z, h, c bool
+	}{
+		{"no borrow", 0x3E, 0x0E, 0x30, false, false, false},
+		{"equal", 0x3E, 0x3E, 0x00, true, false, false},
+		{"half-borrow", 0x3E, 0x0F, 0x2F, false, true, false},
+		{"borrow", 0x3E, 0x40, 0xFE, false, false, true},
+		{"both borrows", 0x00, 0x01, 0xFF, false, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu := setupCPU([]byte{0xD6, tt.n})
+			cpu.Registers.A = tt.a
+
+			cycles := step(t, cpu)
+
+			if cycles != 8 {
+				t.Errorf("Expected 8 cycles, got %d", cycles)
+			}
+			if cpu.Registers.A != tt.result {
+				t.Errorf("Expected A=0x%02X, got A=0x%02X", tt.result, cpu.Registers.A)
+			}
+			checkFlags(t, cpu.Registers, tt.z, true, tt.h, tt.c)
+		})
+	}
+}
+
+func TestOpCP_A_r(t *testing.T) {
+	// 0x3C vs 0x2F: A > value, half-borrow only; A must not change
+	for _, src := range aluSources {
+		t.Run(src.name, func(t *testing.T) {
+			cpu := setupCPU([]byte{0xB8 + src.offset})
+			cpu.Registers.A = 0x3C
+			src.set(cpu, 0x2F)
+
+			cycles := step(t, cpu)
+
+			if cycles != src.cycles {
+				t.Errorf("Expected %d cycles, got %d", src.cycles, cycles)
+			}
+			if cpu.Registers.A != 0x3C {
+				t.Errorf("CP must not modify A, got A=0x%02X", cpu.Registers.A)
+			}
+			checkFlags(t, cpu.Registers, false, true, true, false)
+		})
+	}
+}
+
+func TestOpCP_A_A(t *testing.T) {
+	cpu := setupCPU([]byte{0xBF})
+	cpu.Registers.A = 0x77
+
+	cpu.Step()
+
+	if cpu.Registers.A != 0x77 {
+		t.Errorf("CP must not modify A, got A=0x%02X", cpu.Registers.A)
+	}
+	checkFlags(t, cpu.Registers, true, true, false, false)
+}
+
+func TestOpCP_A_n(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, n    uint8
+		z, h, c bool
+	}{
+		{"equal", 0x90, 0x90, true, false, false},
+		{"greater", 0x90, 0x10, false, false, false},
+		{"less", 0x10, 0x90, false, false, true},
+		{"less with half-borrow", 0x10, 0x91, false, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu := setupCPU([]byte{0xFE, tt.n})
+			cpu.Registers.A = tt.a
+
+			cycles := step(t, cpu)
+
+			if cycles != 8 {
+				t.Errorf("Expected 8 cycles, got %d", cycles)
+			}
+			if cpu.Registers.A != tt.a {
+				t.Errorf("CP must not modify A, got A=0x%02X", cpu.Registers.A)
+			}
+			checkFlags(t, cpu.Registers, tt.z, true, tt.h, tt.c)
+		})
+	}
+}
+
+func TestLogicOpsRegisterFamilies(t *testing.T) {
+	// A = 0b1100_1010, operand = 0b1010_0110
+	families := []struct {
+		name   string
+		base   uint8
+		result uint8
+		h      bool
+	}{
+		{"AND", 0xA0, 0b1000_0010, true},
+		{"XOR", 0xA8, 0b0110_1100, false},
+		{"OR", 0xB0, 0b1110_1110, false},
+	}
+
+	for _, fam := range families {
+		for _, src := range aluSources {
+			t.Run(fam.name+" "+src.name, func(t *testing.T) {
+				cpu := setupCPU([]byte{fam.base + src.offset})
+				cpu.Registers.A = 0b1100_1010
+				src.set(cpu, 0b1010_0110)
+				cpu.Registers.SetFlags(true, true, !fam.h, true) // All must be overwritten
+
+				cycles := step(t, cpu)
+
+				if cycles != src.cycles {
+					t.Errorf("Expected %d cycles, got %d", src.cycles, cycles)
+				}
+				if cpu.Registers.A != fam.result {
+					t.Errorf("Expected A=0x%02X, got A=0x%02X", fam.result, cpu.Registers.A)
+				}
+				checkFlags(t, cpu.Registers, false, false, fam.h, false)
+			})
+		}
+	}
+}
+
+func TestLogicOpsImmediate(t *testing.T) {
+	tests := []struct {
+		name   string
+		opcode uint8
+		a, n   uint8
+		result uint8
+		z, h   bool
+	}{
+		{"AND keeps low nibble", 0xE6, 0x5A, 0x0F, 0x0A, false, true},
+		// AND sets H even when the result is zero
+		{"AND to zero", 0xE6, 0xF0, 0x0F, 0x00, true, true},
+		{"XOR toggles", 0xEE, 0xFF, 0x0F, 0xF0, false, false},
+		{"XOR equal values", 0xEE, 0x3C, 0x3C, 0x00, true, false},
+		{"OR sets bits", 0xF6, 0x50, 0x05, 0x55, false, false},
+		{"OR zero", 0xF6, 0x00, 0x00, 0x00, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu := setupCPU([]byte{tt.opcode, tt.n})
+			cpu.Registers.A = tt.a
+			cpu.Registers.SetFlagC(true)
+
+			cycles := step(t, cpu)
+
+			if cycles != 8 {
+				t.Errorf("Expected 8 cycles, got %d", cycles)
+			}
+			if cpu.Registers.A != tt.result {
+				t.Errorf("Expected A=0x%02X, got A=0x%02X", tt.result, cpu.Registers.A)
+			}
+			checkFlags(t, cpu.Registers, tt.z, false, tt.h, false)
+		})
+	}
+}
+
+func TestLogicOpsOnA(t *testing.T) {
+	tests := []struct {
+		name   string
+		opcode uint8
+		result uint8
+		z, h   bool
+	}{
+		{"AND A, A", 0xA7, 0x81, false, true},
+		{"XOR A, A", 0xAF, 0x00, true, false},
+		{"OR A, A", 0xB7, 0x81, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu := setupCPU([]byte{tt.opcode})
+			cpu.Registers.A = 0x81
+
+			cpu.Step()
+
+			if cpu.Registers.A != tt.result {
+				t.Errorf("Expected A=0x%02X, got A=0x%02X", tt.result, cpu.Registers.A)
+			}
+			checkFlags(t, cpu.Registers, tt.z, false, tt.h, false)
+		})
+	}
+}
+
+// incDecTarget describes one operand of the INC r / DEC r opcodes.
+type incDecTarget struct {
+	name     string
+	inc, dec uint8
+	cycles   int
+	set      func(cpu *CPU, value uint8)
+	get      func(cpu *CPU) uint8
+}
+
+var incDecTargets = []incDecTarget{
+	{"B", 0x04, 0x05, 4, func(cpu *CPU, v uint8) { cpu.Registers.B = v }, func(cpu *CPU) uint8 { return cpu.Registers.B }},
+	{"C", 0x0C, 0x0D, 4, func(cpu *CPU, v uint8) { cpu.Registers.C = v }, func(cpu *CPU) uint8 { return cpu.Registers.C }},
+	{"D", 0x14, 0x15, 4, func(cpu *CPU, v uint8) { cpu.Registers.D = v }, func(cpu *CPU) uint8 { return cpu.Registers.D }},
+	{"E", 0x1C, 0x1D, 4, func(cpu *CPU, v uint8) { cpu.Registers.E = v }, func(cpu *CPU) uint8 { return cpu.Registers.E }},
+	{"H", 0x24, 0x25, 4, func(cpu *CPU, v uint8) { cpu.Registers.H = v }, func(cpu *CPU) uint8 { return cpu.Registers.H }},
+	{"L", 0x2C, 0x2D, 4, func(cpu *CPU, v uint8) { cpu.Registers.L = v }, func(cpu *CPU) uint8 { return cpu.Registers.L }},
+	{"(HL)", 0x34, 0x35, 12,
+		func(cpu *CPU, v uint8) { cpu.Registers.SetHL(0xC000); cpu.Memory.Write(0xC000, v) },
+		func(cpu *CPU) uint8 { return cpu.Memory.Read(0xC000) }},
+	{"A", 0x3C, 0x3D, 4, func(cpu *CPU, v uint8) { cpu.Registers.A = v }, func(cpu *CPU) uint8 { return cpu.Registers.A }},
+}
+
+func TestOpINC_r(t *testing.T) {
+	cases := []struct {
+		name   string
+		value  uint8
+		result uint8
+		z, h   bool
+	}{
+		{"simple", 0x41, 0x42, false, false},
+		{"half-carry", 0x0F, 0x10, false, true},
+		{"wrap to zero", 0xFF, 0x00, true, true},
+	}
+
+	for _, target := range incDecTargets {
+		for _, tc := range cases {
+			for _, carryIn := range []bool{false, true} {
+				cpu := setupCPU([]byte{target.inc})
+				target.set(cpu, tc.value)
+				cpu.Registers.SetFlags(false, true, false, carryIn)
+
+				cycles := step(t, cpu)
+
+				if cycles != target.cycles {
+					t.Errorf("INC %s: expected %d cycles, got %d", target.name, target.cycles, cycles)
+				}
+				if got := target.get(cpu); got != tc.result {
+					t.Errorf("INC %s %s: expected 0x%02X, got 0x%02X", target.name, tc.name, tc.result, got)
+				}
+				// C must survive untouched, even when the value wraps
+				if cpu.Registers.GetFlagZ() != tc.z || cpu.Registers.GetFlagN() ||
+					cpu.Registers.GetFlagH() != tc.h || cpu.Registers.GetFlagC() != carryIn {
+					t.Errorf("INC %s %s (C=%v): wrong flags F=0x%02X", target.name, tc.name, carryIn, cpu.Registers.F)
+				}
+			}
+		}
+	}
+}
+
+func TestOpDEC_r(t *testing.T) {
+	cases := []struct {
+		name   string
+		value  uint8
+		result uint8
+		z, h   bool
+	}{
+		{"simple", 0x42, 0x41, false, false},
+		{"half-borrow", 0x10, 0x0F, false, true},
+		{"to zero", 0x01, 0x00, true, false},
+		{"wrap below zero", 0x00, 0xFF, false, true},
+	}
+
+	for _, target := range incDecTargets {
+		for _, tc := range cases {
+			for _, carryIn := range []bool{false, true} {
+				cpu := setupCPU([]byte{target.dec})
+				target.set(cpu, tc.value)
+				cpu.Registers.SetFlags(false, false, false, carryIn)
+
+				cycles := step(t, cpu)
+
+				if cycles != target.cycles {
+					t.Errorf("DEC %s: expected %d cycles, got %d", target.name, target.cycles, cycles)
+				}
+				if got := target.get(cpu); got != tc.result {
+					t.Errorf("DEC %s %s: expected 0x%02X, got 0x%02X", target.name, tc.name, tc.result, got)
+				}
+				// C must survive untouched, even when the value wraps
+				if cpu.Registers.GetFlagZ() != tc.z || !cpu.Registers.GetFlagN() ||
+					cpu.Registers.GetFlagH() != tc.h || cpu.Registers.GetFlagC() != carryIn {
+					t.Errorf("DEC %s %s (C=%v): wrong flags F=0x%02X", target.name, tc.name, carryIn, cpu.Registers.F)
+				}
+			}
+		}
+	}
+}
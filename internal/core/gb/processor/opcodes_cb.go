@@ -0,0 +1,175 @@
+package processor
+
+// reg8Names lists the 8-bit operands in the order the SM83 encodes
+// them in the low 3 bits of many opcodes. Index 6 is not a register
+// but the byte in memory at address HL.
+var reg8Names = [8]string{"B", "C", "D", "E", "H", "L", "(HL)", "A"}
+
+// hlOperand is the operand index that refers to memory at (HL).
+const hlOperand = 6
+
+// readOperand8 returns the 8-bit operand with the given encoding index.
+func (cpu *CPU) readOperand8(index uint8) uint8 {
+	r := cpu.Registers
+	switch index {
+	case 0:
+		return r.B
+	case 1:
+		return r.C
+	case 2:
+		return r.D
+	case 3:
+		return r.E
+	case 4:
+		return r.H
+	case 5:
+		return r.L
+	case hlOperand:
+		return cpu.read(r.HL())
+	default:
+		return r.A
+	}
+}
+
+// writeOperand8 stores value into the 8-bit operand with the given
+// encoding index.
+func (cpu *CPU) writeOperand8(index uint8, value uint8) {
+	r := cpu.Registers
+	switch index {
+	case 0:
+		r.B = value
+	case 1:
+		r.C = value
+	case 2:
+		r.D = value
+	case 3:
+		r.E = value
+	case 4:
+		r.H = value
+	case 5:
+		r.L = value
+	case hlOperand:
+		cpu.write(r.HL(), value)
+	default:
+		r.A = value
+	}
+}
+
+// ============================================================
+// 0xCB: PREFIX CB - Extended instruction set
+// ============================================================
+// 0xCB is not an instruction on its own: it tells the CPU to read
+// one more byte and look it up in a second 256-entry table of
+// bit-manipulation instructions (rotates, shifts, BIT/RES/SET).
+//
+// Step decodes the prefix itself (see dispatch), so the CB entry
+// runs with its own cycle count. Cycle counts in cbTable are the
+// totals for the whole 2-byte instruction, including the 4 cycles
+// of fetching the prefix. opPrefixCB is only the reference Execute
+// of the 0xCB entry (see Opcode.Execute).
+func opPrefixCB(cpu *CPU) {
+	cpu.executeCB(cpu.fetchByte())
+}
+
+// ============================================================
+// CB 0x00-0x3F - Rotates and shifts
+// ============================================================
+// Each operation moves the bits of a register (or (HL)) one
+// position left or right. The bit that falls off the end goes
+// into the carry flag:
+//
+//	RLC  C <- [7 ... 0] <- bit 7    rotate left, bit 7 wraps around
+//	RRC  bit 0 -> [7 ... 0] -> C    rotate right, bit 0 wraps around
+//	RL   C <- [7 ... 0] <- C        rotate left THROUGH the carry
+//	RR   C -> [7 ... 0] -> C        rotate right THROUGH the carry
+//	SLA  C <- [7 ... 0] <- 0        shift left (multiply by 2)
+//	SRA  [7] -> [7 ... 0] -> C      shift right keeping the sign bit
+//	SRL  0 -> [7 ... 0] -> C        shift right (unsigned divide by 2)
+//	SWAP [7..4] <-> [3..0]          exchange nibbles, C = 0
+//
+// Flags affected:
+//
+//	Z: Set if result is zero
+//	N: Reset (0)
+//	H: Reset (0)
+//	C: The bit shifted out (0 for SWAP)
+//
+// Cycles: 8 for registers, 16 for (HL)
+// Bytes: 2
+
+// shiftResult sets the flags shared by every rotate/shift and
+// returns result, so each operation stays a one-liner.
+func (cpu *CPU) shiftResult(result uint8, carry bool) uint8 {
+	cpu.Registers.SetFlags(result == 0, false, false, carry)
+	return result
+}
+
+func (cpu *CPU) rlc(v uint8) uint8  { return cpu.shiftResult(v<<1|v>>7, v&0x80 != 0) }
+func (cpu *CPU) rrc(v uint8) uint8  { return cpu.shiftResult(v>>1|v<<7, v&0x01 != 0) }
+func (cpu *CPU) rl(v uint8) uint8   { return cpu.shiftResult(v<<1|cpu.carryBit(), v&0x80 != 0) }
+func (cpu *CPU) rr(v uint8) uint8   { return cpu.shiftResult(v>>1|cpu.carryBit()<<7, v&0x01 != 0) }
+func (cpu *CPU) sla(v uint8) uint8  { return cpu.shiftResult(v<<1, v&0x80 != 0) }
+func (cpu *CPU) sra(v uint8) uint8  { return cpu.shiftResult(v>>1|v&0x80, v&0x01 != 0) }
+func (cpu *CPU) swap(v uint8) uint8 { return cpu.shiftResult(v<<4|v>>4, false) }
+func (cpu *CPU) srl(v uint8) uint8  { return cpu.shiftResult(v>>1, v&0x01 != 0) }
+
+// cbShift returns the Execute function that applies a rotate/shift
+// to the given operand (see reg8Names) and writes the result back.
+// These closures are only cbTable's reference Execute functions (see
+// Opcode.Execute): executeCB does the same with the operand spelled
+// out (see opgen), and so it does for cbBIT, cbRES and cbSET.
+func cbShift(op func(cpu *CPU, v uint8) uint8, operand uint8) func(*CPU) {
+	return func(cpu *CPU) {
+		cpu.writeOperand8(operand, op(cpu, cpu.readOperand8(operand)))
+	}
+}
+
+// ============================================================
+// CB 0x40-0xFF - BIT n / RES n / SET n
+// ============================================================
+// Single-bit operations on a register or (HL):
+//
+//	BIT n, r  ->  test bit n:  Z = 1 if the bit is 0
+//	RES n, r  ->  clear bit n (r &^= 1 << n)
+//	SET n, r  ->  set bit n   (r |= 1 << n)
+//
+// BIT is how games poll hardware status bits, e.g. waiting for a
+// button press with "BIT 0, A; JR NZ, loop".
+//
+// BIT only reads its operand, so BIT n, (HL) takes 12 cycles
+// where RES/SET n, (HL) take 16 (they also write the byte back).
+//
+// Flags affected (BIT only - RES and SET change no flags):
+//
+//	Z: Set if the tested bit is 0
+//	N: Reset (0)
+//	H: Set (1)
+//	C: Not affected
+//
+// Cycles: 8 for registers; (HL): 12 for BIT, 16 for RES/SET
+// Bytes: 2
+
+// testBit updates the flags for BIT: Z reflects the masked bit.
+func (cpu *CPU) testBit(value, mask uint8) {
+	cpu.Registers.SetFlagZ(value&mask == 0)
+	cpu.Registers.SetFlagN(false)
+	cpu.Registers.SetFlagH(true)
+}
+
+// cbBIT, cbRES and cbSET return the reference Execute functions for
+// BIT, RES and SET of the given bit on the given operand (see
+// reg8Names).
+func cbBIT(bit, operand uint8) func(*CPU) {
+	mask := uint8(1) << bit
+	return func(cpu *CPU) { cpu.testBit(cpu.readOperand8(operand), mask) }
+}
+
+func cbRES(bit, operand uint8) func(*CPU) {
+	mask := uint8(1) << bit
+	return func(cpu *CPU) { cpu.writeOperand8(operand, cpu.readOperand8(operand)&^mask) }
+}
+
+func cbSET(bit, operand uint8) func(*CPU) {
+	mask := uint8(1) << bit
+	return func(cpu *CPU) { cpu.writeOperand8(operand, cpu.readOperand8(operand)|mask) }
+}
@@ -0,0 +1,209 @@
+package processor
+
+import "testing"
+
+// setOperand8 places value in the operand with the given encoding
+// index, pointing HL at WRAM for the (HL) operand.
+func setOperand8(cpu *CPU, index uint8, value uint8) {
+	if index == hlOperand {
+		cpu.Registers.SetHL(0xC000)
+	}
+	cpu.writeOperand8(index, value)
+}
+
+func TestCBShiftOps(t *testing.T) {
+	tests := []struct {
+		name    string
+		op      uint8 // Operation index (bits 3-5 of the CB opcode)
+		value   uint8
+		carryIn bool
+		result  uint8
+		carry   bool
+	}{
+		{"RLC", 0, 0b1000_0101, false, 0b0000_1011, true},
+		{"RLC ignores carry in", 0, 0b0100_0000, true, 0b1000_0000, false},
+		{"RRC", 1, 0b0000_0011, false, 0b1000_0001, true},
+		{"RRC ignores carry in", 1, 0b0000_0010, true, 0b0000_0001, false},
+		{"RL carry in", 2, 0b0100_0000, true, 0b1000_0001, false},
+		{"RL carry out", 2, 0b1000_0000, false, 0b0000_0000, true},
+		{"RR carry in", 3, 0b0000_0010, true, 0b1000_0001, false},
+		{"RR carry out", 3, 0b0000_0001, false, 0b0000_0000, true},
+		{"SLA", 4, 0b1100_0001, true, 0b1000_0010, true},
+		{"SRA keeps sign", 5, 0b1000_0011, false, 0b1100_0001, true},
+		{"SRA positive", 5, 0b0100_0000, true, 0b0010_0000, false},
+		{"SWAP", 6, 0xA5, true, 0x5A, false},
+		{"SWAP zero", 6, 0x00, true, 0x00, false},
+		{"SRL", 7, 0b1000_0011, true, 0b0100_0001, true},
+	}
+
+	for _, tt := range tests {
+		for operand := range uint8(8) {
+			opcode := tt.op<<3 | operand
+			cpu := setupCPU([]byte{0xCB, opcode})
+			setOperand8(cpu, operand, tt.value)
+			cpu.Registers.SetFlags(false, true, true, tt.carryIn)
+
+			cycles := step(t, cpu)
+
+			name := tt.name + " " + reg8Names[operand]
+			wantCycles := 8
+			if operand == hlOperand {
+				wantCycles = 16
+			}
+			if cycles != wantCycles {
+				t.Errorf("%s: expected %d cycles, got %d", name, wantCycles, cycles)
+			}
+			if cpu.Registers.PC != 2 {
+				t.Errorf("%s: expected PC=2, got PC=%d", name, cpu.Registers.PC)
+			}
+			if got := cpu.readOperand8(operand); got != tt.result {
+				t.Errorf("%s: expected 0x%02X, got 0x%02X", name, tt.result, got)
+			}
+			if cpu.Registers.GetFlagZ() != (tt.result == 0) || cpu.Registers.GetFlagN() ||
+				cpu.Registers.GetFlagH() || cpu.Registers.GetFlagC() != tt.carry {
+				t.Errorf("%s: wrong flags F=0x%02X", name, cpu.Registers.F)
+			}
+		}
+	}
+}
+
+func TestCBShiftOnlyTouchesOperand(t *testing.T) {
+	// SWAP D must leave every other register alone
+	cpu := setupCPU([]byte{0xCB, 0x32})
+	cpu.Registers.SetAF(0x1100)
+	cpu.Registers.SetBC(0x2233)
+	cpu.Registers.SetDE(0x4455)
+	cpu.Registers.SetHL(0x6677)
+
+	cpu.Step()
+
+	if cpu.Registers.D != 0x44 {
+		t.Errorf("Expected D=0x44 (0x44 swapped), got D=0x%02X", cpu.Registers.D)
+	}
+	if cpu.Registers.A != 0x11 || cpu.Registers.BC() != 0x2233 ||
+		cpu.Registers.E != 0x55 || cpu.Registers.HL() != 0x6677 {
+		t.Errorf("Other registers changed: AF=0x%04X BC=0x%04X DE=0x%04X HL=0x%04X",
+			cpu.Registers.AF(), cpu.Registers.BC(), cpu.Registers.DE(), cpu.Registers.HL())
+	}
+}
+
+func TestCBTableMnemonics(t *testing.T) {
+	tests := map[uint8]string{
+		0x00: "RLC B",
+		0x0E: "RRC (HL)",
+		0x17: "RL A",
+		0x1B: "RR E",
+		0x24: "SLA H",
+		0x2D: "SRA L",
+		0x37: "SWAP A",
+		0x3E: "SRL (HL)",
+	}
+
+	for opcode, want := range tests {
+		if got := cbTable[opcode].Mnemonic; got != want {
+			t.Errorf("CB 0x%02X: expected %q, got %q", opcode, want, got)
+		}
+	}
+}
+
+func TestCBBit(t *testing.T) {
+	for bit := range uint8(8) {
+		for operand := range uint8(8) {
+			opcode := 0x40 | bit<<3 | operand
+			name := cbTable[opcode].Mnemonic
+			wantCycles := 8
+			if operand == hlOperand {
+				wantCycles = 12
+			}
+
+			for _, set := range []bool{false, true} {
+				for _, carryIn := range []bool{false, true} {
+					// All other bits take the opposite value so a wrong mask shows up
+					value := ^uint8(1 << bit)
+					if set {
+						value = 1 << bit
+					}
+					cpu := setupCPU([]byte{0xCB, opcode})
+					setOperand8(cpu, operand, value)
+					cpu.Registers.SetFlags(set, true, false, carryIn)
+
+					cycles := step(t, cpu)
+
+					if cycles != wantCycles {
+						t.Errorf("%s: expected %d cycles, got %d", name, wantCycles, cycles)
+					}
+					if got := cpu.readOperand8(operand); got != value {
+						t.Errorf("%s: BIT must not modify its operand, got 0x%02X", name, got)
+					}
+					if cpu.Registers.GetFlagZ() != !set || cpu.Registers.GetFlagN() ||
+						!cpu.Registers.GetFlagH() || cpu.Registers.GetFlagC() != carryIn {
+						t.Errorf("%s on 0x%02X (C=%v): wrong flags F=0x%02X", name, value, carryIn, cpu.Registers.F)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestCBBit_HLTiming(t *testing.T) {
+	// BIT n, (HL) only reads memory: 12 cycles, not the 16 of RES/SET (HL)
+	cpu := setupCPU([]byte{0xCB, 0x46, 0xCB, 0x86, 0xCB, 0xC6}) // BIT 0,(HL); RES 0,(HL); SET 0,(HL)
+	cpu.Registers.SetHL(0xC000)
+
+	for i, want := range []int{12, 16, 16} {
+		if cycles := step(t, cpu); cycles != want {
+			t.Errorf("Instruction %d: expected %d cycles, got %d", i, want, cycles)
+		}
+	}
+	if cpu.TotalCycles != 44 {
+		t.Errorf("Expected 44 total cycles, got %d", cpu.TotalCycles)
+	}
+}
+
+func TestCBResSet(t *testing.T) {
+	for bit := range uint8(8) {
+		for operand := range uint8(8) {
+			wantCycles := 8
+			if operand == hlOperand {
+				wantCycles = 16
+			}
+
+			// RES n on 0xFF clears exactly that bit
+			res := 0x80 | bit<<3 | operand
+			cpu := setupCPU([]byte{0xCB, res})
+			setOperand8(cpu, operand, 0xFF)
+			cpu.Registers.F = 0xF0
+			cycles := step(t, cpu)
+
+			if got, want := cpu.readOperand8(operand), ^uint8(1<<bit); got != want {
+				t.Errorf("%s: expected 0x%02X, got 0x%02X", cbTable[res].Mnemonic, want, got)
+			}
+			if cycles != wantCycles || cpu.Registers.F != 0xF0 {
+				t.Errorf("%s: expected %d cycles and F=0xF0, got %d cycles F=0x%02X", cbTable[res].Mnemonic, wantCycles, cycles, cpu.Registers.F)
+			}
+
+			// SET n on 0x00 sets exactly that bit
+			set := 0xC0 | bit<<3 | operand
+			cpu = setupCPU([]byte{0xCB, set})
+			setOperand8(cpu, operand, 0x00)
+			cycles = step(t, cpu)
+
+			want := uint8(1 << bit)
+			if got := cpu.readOperand8(operand); got != want {
+				t.Errorf("%s: expected 0x%02X, got 0x%02X", cbTable[set].Mnemonic, want, got)
+			}
+			if cycles != wantCycles || cpu.Registers.F != 0x00 {
+				t.Errorf("%s: expected %d cycles and F=0x00, got %d cycles F=0x%02X", cbTable[set].Mnemonic, wantCycles, cycles, cpu.Registers.F)
+			}
+		}
+	}
+}
+
+func TestCBTableComplete(t *testing.T) {
+	// Every CB opcode is a real instruction, so all 256 entries must be filled
+	for i := range 256 {
+		if cbTable[i].Execute == nil || cbTable[i].Mnemonic == "" {
+			t.Errorf("CB 0x%02X is not implemented (%q)", i, cbTable[i].Mnemonic)
+		}
+	}
+}
@@ -0,0 +1,1400 @@
+// Code generated by opgen from opcodes.json; DO NOT EDIT.
+
+package processor
+
+// opcodeTable maps each opcode byte (0x00-0xFF) to its implementation.
+var opcodeTable = [256]Opcode{
+	0x00: {Mnemonic: "NOP", Bytes: 1, Cycles: 4, Execute: opNOP},
+	0x01: {Mnemonic: "LD BC, nn", Bytes: 3, Cycles: 12, Execute: opLD_BC_nn},
+	0x02: {Mnemonic: "UNKNOWN_0x02", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD (BC), A
+	0x03: {Mnemonic: "INC BC", Bytes: 1, Cycles: 8, Execute: opINC_BC},
+	0x04: {Mnemonic: "INC B", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagAffected}, Execute: opINC_B},
+	0x05: {Mnemonic: "DEC B", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected}, Execute: opDEC_B},
+	0x06: {Mnemonic: "LD B, n", Bytes: 2, Cycles: 8, Execute: opLD_B_n},
+	0x07: {Mnemonic: "RLCA", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagReset, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: opRLCA},
+	0x08: {Mnemonic: "LD (nn), SP", Bytes: 3, Cycles: 20, Execute: opLD_nn_SP},
+	0x09: {Mnemonic: "ADD HL, BC", Bytes: 1, Cycles: 8, Flags: FlagEffects{N: FlagReset, H: FlagAffected, C: FlagAffected}, Execute: opADD_HL_BC},
+	0x0A: {Mnemonic: "UNKNOWN_0x0A", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD A, (BC)
+	0x0B: {Mnemonic: "DEC BC", Bytes: 1, Cycles: 8, Execute: opDEC_BC},
+	0x0C: {Mnemonic: "INC C", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagAffected}, Execute: opINC_C},
+	0x0D: {Mnemonic: "DEC C", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected}, Execute: opDEC_C},
+	0x0E: {Mnemonic: "LD C, n", Bytes: 2, Cycles: 8, Execute: opLD_C_n},
+	0x0F: {Mnemonic: "RRCA", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagReset, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: opRRCA},
+	0x10: {Mnemonic: "STOP", Bytes: 2, Cycles: 4, Execute: opSTOP},
+	0x11: {Mnemonic: "LD DE, nn", Bytes: 3, Cycles: 12, Execute: opLD_DE_nn},
+	0x12: {Mnemonic: "UNKNOWN_0x12", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD (DE), A
+	0x13: {Mnemonic: "INC DE", Bytes: 1, Cycles: 8, Execute: opINC_DE},
+	0x14: {Mnemonic: "INC D", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagAffected}, Execute: opINC_D},
+	0x15: {Mnemonic: "DEC D", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected}, Execute: opDEC_D},
+	0x16: {Mnemonic: "UNKNOWN_0x16", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD D, n
+	0x17: {Mnemonic: "RLA", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagReset, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: opRLA},
+	0x18: {Mnemonic: "JR e8", Bytes: 2, Cycles: 12, Execute: opJR_e8},
+	0x19: {Mnemonic: "ADD HL, DE", Bytes: 1, Cycles: 8, Flags: FlagEffects{N: FlagReset, H: FlagAffected, C: FlagAffected}, Execute: opADD_HL_DE},
+	0x1A: {Mnemonic: "UNKNOWN_0x1A", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD A, (DE)
+	0x1B: {Mnemonic: "DEC DE", Bytes: 1, Cycles: 8, Execute: opDEC_DE},
+	0x1C: {Mnemonic: "INC E", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagAffected}, Execute: opINC_E},
+	0x1D: {Mnemonic: "DEC E", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected}, Execute: opDEC_E},
+	0x1E: {Mnemonic: "UNKNOWN_0x1E", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD E, n
+	0x1F: {Mnemonic: "RRA", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagReset, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: opRRA},
+	0x20: {Mnemonic: "JR NZ, e8", Bytes: 2, Cycles: 8, CyclesTaken: 12, Execute: opJR_NZ_e8},
+	0x21: {Mnemonic: "LD HL, nn", Bytes: 3, Cycles: 12, Execute: opLD_HL_nn},
+	0x22: {Mnemonic: "LD (HL+), A", Bytes: 1, Cycles: 8, Execute: opLD_HLI_A},
+	0x23: {Mnemonic: "INC HL", Bytes: 1, Cycles: 8, Execute: opINC_HL},
+	0x24: {Mnemonic: "INC H", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagAffected}, Execute: opINC_H},
+	0x25: {Mnemonic: "DEC H", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected}, Execute: opDEC_H},
+	0x26: {Mnemonic: "UNKNOWN_0x26", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD H, n
+	0x27: {Mnemonic: "DAA", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, H: FlagReset, C: FlagAffected}, Execute: opDAA},
+	0x28: {Mnemonic: "JR Z, e8", Bytes: 2, Cycles: 8, CyclesTaken: 12, Execute: opJR_Z_e8},
+	0x29: {Mnemonic: "ADD HL, HL", Bytes: 1, Cycles: 8, Flags: FlagEffects{N: FlagReset, H: FlagAffected, C: FlagAffected}, Execute: opADD_HL_HL},
+	0x2A: {Mnemonic: "LD A, (HL+)", Bytes: 1, Cycles: 8, Execute: opLD_A_HLI},
+	0x2B: {Mnemonic: "DEC HL", Bytes: 1, Cycles: 8, Execute: opDEC_HL},
+	0x2C: {Mnemonic: "INC L", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagAffected}, Execute: opINC_L},
+	0x2D: {Mnemonic: "DEC L", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected}, Execute: opDEC_L},
+	0x2E: {Mnemonic: "UNKNOWN_0x2E", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD L, n
+	0x2F: {Mnemonic: "CPL", Bytes: 1, Cycles: 4, Flags: FlagEffects{N: FlagSet, H: FlagSet}, Execute: opCPL},
+	0x30: {Mnemonic: "JR NC, e8", Bytes: 2, Cycles: 8, CyclesTaken: 12, Execute: opJR_NC_e8},
+	0x31: {Mnemonic: "LD SP, nn", Bytes: 3, Cycles: 12, Execute: opLD_SP_nn},
+	0x32: {Mnemonic: "LD (HL-), A", Bytes: 1, Cycles: 8, Execute: opLD_HLD_A},
+	0x33: {Mnemonic: "INC SP", Bytes: 1, Cycles: 8, Execute: opINC_SP},
+	0x34: {Mnemonic: "INC (HL)", Bytes: 1, Cycles: 12, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagAffected}, Execute: opINC_HLmem},
+	0x35: {Mnemonic: "DEC (HL)", Bytes: 1, Cycles: 12, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected}, Execute: opDEC_HLmem},
+	0x36: {Mnemonic: "UNKNOWN_0x36", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD (HL), n
+	0x37: {Mnemonic: "SCF", Bytes: 1, Cycles: 4, Flags: FlagEffects{N: FlagReset, H: FlagReset, C: FlagSet}, Execute: opSCF},
+	0x38: {Mnemonic: "JR C, e8", Bytes: 2, Cycles: 8, CyclesTaken: 12, Execute: opJR_C_e8},
+	0x39: {Mnemonic: "ADD HL, SP", Bytes: 1, Cycles: 8, Flags: FlagEffects{N: FlagReset, H: FlagAffected, C: FlagAffected}, Execute: opADD_HL_SP},
+	0x3A: {Mnemonic: "LD A, (HL-)", Bytes: 1, Cycles: 8, Execute: opLD_A_HLD},
+	0x3B: {Mnemonic: "DEC SP", Bytes: 1, Cycles: 8, Execute: opDEC_SP},
+	0x3C: {Mnemonic: "INC A", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagAffected}, Execute: opINC_A},
+	0x3D: {Mnemonic: "DEC A", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected}, Execute: opDEC_A},
+	0x3E: {Mnemonic: "LD A, n", Bytes: 2, Cycles: 8, Execute: opLD_A_n},
+	0x3F: {Mnemonic: "CCF", Bytes: 1, Cycles: 4, Flags: FlagEffects{N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: opCCF},
+	0x40: {Mnemonic: "LD B, B", Bytes: 1, Cycles: 4, Execute: opLD_B_B},
+	0x41: {Mnemonic: "UNKNOWN_0x41", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD B, C
+	0x42: {Mnemonic: "UNKNOWN_0x42", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD B, D
+	0x43: {Mnemonic: "UNKNOWN_0x43", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD B, E
+	0x44: {Mnemonic: "UNKNOWN_0x44", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD B, H
+	0x45: {Mnemonic: "UNKNOWN_0x45", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD B, L
+	0x46: {Mnemonic: "UNKNOWN_0x46", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD B, (HL)
+	0x47: {Mnemonic: "UNKNOWN_0x47", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD B, A
+	0x48: {Mnemonic: "UNKNOWN_0x48", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD C, B
+	0x49: {Mnemonic: "UNKNOWN_0x49", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD C, C
+	0x4A: {Mnemonic: "UNKNOWN_0x4A", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD C, D
+	0x4B: {Mnemonic: "UNKNOWN_0x4B", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD C, E
+	0x4C: {Mnemonic: "UNKNOWN_0x4C", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD C, H
+	0x4D: {Mnemonic: "UNKNOWN_0x4D", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD C, L
+	0x4E: {Mnemonic: "UNKNOWN_0x4E", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD C, (HL)
+	0x4F: {Mnemonic: "UNKNOWN_0x4F", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD C, A
+	0x50: {Mnemonic: "UNKNOWN_0x50", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD D, B
+	0x51: {Mnemonic: "UNKNOWN_0x51", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD D, C
+	0x52: {Mnemonic: "LD D, D", Bytes: 1, Cycles: 4, Execute: opLD_D_D},
+	0x53: {Mnemonic: "UNKNOWN_0x53", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD D, E
+	0x54: {Mnemonic: "UNKNOWN_0x54", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD D, H
+	0x55: {Mnemonic: "UNKNOWN_0x55", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD D, L
+	0x56: {Mnemonic: "UNKNOWN_0x56", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD D, (HL)
+	0x57: {Mnemonic: "UNKNOWN_0x57", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD D, A
+	0x58: {Mnemonic: "UNKNOWN_0x58", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD E, B
+	0x59: {Mnemonic: "UNKNOWN_0x59", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD E, C
+	0x5A: {Mnemonic: "UNKNOWN_0x5A", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD E, D
+	0x5B: {Mnemonic: "UNKNOWN_0x5B", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD E, E
+	0x5C: {Mnemonic: "UNKNOWN_0x5C", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD E, H
+	0x5D: {Mnemonic: "UNKNOWN_0x5D", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD E, L
+	0x5E: {Mnemonic: "UNKNOWN_0x5E", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD E, (HL)
+	0x5F: {Mnemonic: "UNKNOWN_0x5F", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD E, A
+	0x60: {Mnemonic: "UNKNOWN_0x60", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD H, B
+	0x61: {Mnemonic: "UNKNOWN_0x61", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD H, C
+	0x62: {Mnemonic: "UNKNOWN_0x62", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD H, D
+	0x63: {Mnemonic: "UNKNOWN_0x63", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD H, E
+	0x64: {Mnemonic: "UNKNOWN_0x64", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD H, H
+	0x65: {Mnemonic: "UNKNOWN_0x65", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD H, L
+	0x66: {Mnemonic: "UNKNOWN_0x66", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD H, (HL)
+	0x67: {Mnemonic: "UNKNOWN_0x67", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD H, A
+	0x68: {Mnemonic: "UNKNOWN_0x68", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD L, B
+	0x69: {Mnemonic: "UNKNOWN_0x69", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD L, C
+	0x6A: {Mnemonic: "UNKNOWN_0x6A", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD L, D
+	0x6B: {Mnemonic: "UNKNOWN_0x6B", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD L, E
+	0x6C: {Mnemonic: "UNKNOWN_0x6C", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD L, H
+	0x6D: {Mnemonic: "UNKNOWN_0x6D", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD L, L
+	0x6E: {Mnemonic: "UNKNOWN_0x6E", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD L, (HL)
+	0x6F: {Mnemonic: "UNKNOWN_0x6F", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD L, A
+	0x70: {Mnemonic: "UNKNOWN_0x70", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD (HL), B
+	0x71: {Mnemonic: "UNKNOWN_0x71", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD (HL), C
+	0x72: {Mnemonic: "UNKNOWN_0x72", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD (HL), D
+	0x73: {Mnemonic: "UNKNOWN_0x73", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD (HL), E
+	0x74: {Mnemonic: "UNKNOWN_0x74", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD (HL), H
+	0x75: {Mnemonic: "UNKNOWN_0x75", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD (HL), L
+	0x76: {Mnemonic: "HALT", Bytes: 1, Cycles: 4, Execute: opHALT},
+	0x77: {Mnemonic: "UNKNOWN_0x77", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD (HL), A
+	0x78: {Mnemonic: "LD A, B", Bytes: 1, Cycles: 4, Execute: opLD_A_B},
+	0x79: {Mnemonic: "LD A, C", Bytes: 1, Cycles: 4, Execute: opLD_A_C},
+	0x7A: {Mnemonic: "UNKNOWN_0x7A", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD A, D
+	0x7B: {Mnemonic: "UNKNOWN_0x7B", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD A, E
+	0x7C: {Mnemonic: "UNKNOWN_0x7C", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD A, H
+	0x7D: {Mnemonic: "UNKNOWN_0x7D", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD A, L
+	0x7E: {Mnemonic: "UNKNOWN_0x7E", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD A, (HL)
+	0x7F: {Mnemonic: "UNKNOWN_0x7F", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD A, A
+	0x80: {Mnemonic: "ADD A, B", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagAffected, C: FlagAffected}, Execute: opADD_A_B},
+	0x81: {Mnemonic: "ADD A, C", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagAffected, C: FlagAffected}, Execute: opADD_A_C},
+	0x82: {Mnemonic: "ADD A, D", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagAffected, C: FlagAffected}, Execute: opADD_A_D},
+	0x83: {Mnemonic: "ADD A, E", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagAffected, C: FlagAffected}, Execute: opADD_A_E},
+	0x84: {Mnemonic: "ADD A, H", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagAffected, C: FlagAffected}, Execute: opADD_A_H},
+	0x85: {Mnemonic: "ADD A, L", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagAffected, C: FlagAffected}, Execute: opADD_A_L},
+	0x86: {Mnemonic: "ADD A, (HL)", Bytes: 1, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagAffected, C: FlagAffected}, Execute: opADD_A_HLmem},
+	0x87: {Mnemonic: "ADD A, A", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagAffected, C: FlagAffected}, Execute: opADD_A_A},
+	0x88: {Mnemonic: "ADC A, B", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagAffected, C: FlagAffected}, Execute: opADC_A_B},
+	0x89: {Mnemonic: "ADC A, C", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagAffected, C: FlagAffected}, Execute: opADC_A_C},
+	0x8A: {Mnemonic: "ADC A, D", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagAffected, C: FlagAffected}, Execute: opADC_A_D},
+	0x8B: {Mnemonic: "ADC A, E", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagAffected, C: FlagAffected}, Execute: opADC_A_E},
+	0x8C: {Mnemonic: "ADC A, H", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagAffected, C: FlagAffected}, Execute: opADC_A_H},
+	0x8D: {Mnemonic: "ADC A, L", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagAffected, C: FlagAffected}, Execute: opADC_A_L},
+	0x8E: {Mnemonic: "ADC A, (HL)", Bytes: 1, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagAffected, C: FlagAffected}, Execute: opADC_A_HLmem},
+	0x8F: {Mnemonic: "ADC A, A", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagAffected, C: FlagAffected}, Execute: opADC_A_A},
+	0x90: {Mnemonic: "SUB A, B", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected, C: FlagAffected}, Execute: opSUB_A_B},
+	0x91: {Mnemonic: "SUB A, C", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected, C: FlagAffected}, Execute: opSUB_A_C},
+	0x92: {Mnemonic: "SUB A, D", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected, C: FlagAffected}, Execute: opSUB_A_D},
+	0x93: {Mnemonic: "SUB A, E", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected, C: FlagAffected}, Execute: opSUB_A_E},
+	0x94: {Mnemonic: "SUB A, H", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected, C: FlagAffected}, Execute: opSUB_A_H},
+	0x95: {Mnemonic: "SUB A, L", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected, C: FlagAffected}, Execute: opSUB_A_L},
+	0x96: {Mnemonic: "SUB A, (HL)", Bytes: 1, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected, C: FlagAffected}, Execute: opSUB_A_HLmem},
+	0x97: {Mnemonic: "SUB A, A", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected, C: FlagAffected}, Execute: opSUB_A_A},
+	0x98: {Mnemonic: "SBC A, B", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected, C: FlagAffected}, Execute: opSBC_A_B},
+	0x99: {Mnemonic: "SBC A, C", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected, C: FlagAffected}, Execute: opSBC_A_C},
+	0x9A: {Mnemonic: "SBC A, D", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected, C: FlagAffected}, Execute: opSBC_A_D},
+	0x9B: {Mnemonic: "SBC A, E", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected, C: FlagAffected}, Execute: opSBC_A_E},
+	0x9C: {Mnemonic: "SBC A, H", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected, C: FlagAffected}, Execute: opSBC_A_H},
+	0x9D: {Mnemonic: "SBC A, L", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected, C: FlagAffected}, Execute: opSBC_A_L},
+	0x9E: {Mnemonic: "SBC A, (HL)", Bytes: 1, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected, C: FlagAffected}, Execute: opSBC_A_HLmem},
+	0x9F: {Mnemonic: "SBC A, A", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected, C: FlagAffected}, Execute: opSBC_A_A},
+	0xA0: {Mnemonic: "AND A, B", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet, C: FlagReset}, Execute: opAND_A_B},
+	0xA1: {Mnemonic: "AND A, C", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet, C: FlagReset}, Execute: opAND_A_C},
+	0xA2: {Mnemonic: "AND A, D", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet, C: FlagReset}, Execute: opAND_A_D},
+	0xA3: {Mnemonic: "AND A, E", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet, C: FlagReset}, Execute: opAND_A_E},
+	0xA4: {Mnemonic: "AND A, H", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet, C: FlagReset}, Execute: opAND_A_H},
+	0xA5: {Mnemonic: "AND A, L", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet, C: FlagReset}, Execute: opAND_A_L},
+	0xA6: {Mnemonic: "AND A, (HL)", Bytes: 1, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet, C: FlagReset}, Execute: opAND_A_HLmem},
+	0xA7: {Mnemonic: "AND A, A", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet, C: FlagReset}, Execute: opAND_A_A},
+	0xA8: {Mnemonic: "XOR A, B", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagReset}, Execute: opXOR_A_B},
+	0xA9: {Mnemonic: "XOR A, C", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagReset}, Execute: opXOR_A_C},
+	0xAA: {Mnemonic: "XOR A, D", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagReset}, Execute: opXOR_A_D},
+	0xAB: {Mnemonic: "XOR A, E", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagReset}, Execute: opXOR_A_E},
+	0xAC: {Mnemonic: "XOR A, H", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagReset}, Execute: opXOR_A_H},
+	0xAD: {Mnemonic: "XOR A, L", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagReset}, Execute: opXOR_A_L},
+	0xAE: {Mnemonic: "XOR A, (HL)", Bytes: 1, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagReset}, Execute: opXOR_A_HLmem},
+	0xAF: {Mnemonic: "XOR A, A", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagReset}, Execute: opXOR_A_A},
+	0xB0: {Mnemonic: "OR A, B", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagReset}, Execute: opOR_A_B},
+	0xB1: {Mnemonic: "OR A, C", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagReset}, Execute: opOR_A_C},
+	0xB2: {Mnemonic: "OR A, D", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagReset}, Execute: opOR_A_D},
+	0xB3: {Mnemonic: "OR A, E", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagReset}, Execute: opOR_A_E},
+	0xB4: {Mnemonic: "OR A, H", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagReset}, Execute: opOR_A_H},
+	0xB5: {Mnemonic: "OR A, L", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagReset}, Execute: opOR_A_L},
+	0xB6: {Mnemonic: "OR A, (HL)", Bytes: 1, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagReset}, Execute: opOR_A_HLmem},
+	0xB7: {Mnemonic: "OR A, A", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagReset}, Execute: opOR_A_A},
+	0xB8: {Mnemonic: "CP A, B", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected, C: FlagAffected}, Execute: opCP_A_B},
+	0xB9: {Mnemonic: "CP A, C", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected, C: FlagAffected}, Execute: opCP_A_C},
+	0xBA: {Mnemonic: "CP A, D", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected, C: FlagAffected}, Execute: opCP_A_D},
+	0xBB: {Mnemonic: "CP A, E", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected, C: FlagAffected}, Execute: opCP_A_E},
+	0xBC: {Mnemonic: "CP A, H", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected, C: FlagAffected}, Execute: opCP_A_H},
+	0xBD: {Mnemonic: "CP A, L", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected, C: FlagAffected}, Execute: opCP_A_L},
+	0xBE: {Mnemonic: "CP A, (HL)", Bytes: 1, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected, C: FlagAffected}, Execute: opCP_A_HLmem},
+	0xBF: {Mnemonic: "CP A, A", Bytes: 1, Cycles: 4, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected, C: FlagAffected}, Execute: opCP_A_A},
+	0xC0: {Mnemonic: "RET NZ", Bytes: 1, Cycles: 8, CyclesTaken: 20, Execute: opRET_NZ},
+	0xC1: {Mnemonic: "POP BC", Bytes: 1, Cycles: 12, Execute: opPOP_BC},
+	0xC2: {Mnemonic: "JP NZ, nn", Bytes: 3, Cycles: 12, CyclesTaken: 16, Execute: opJP_NZ_nn},
+	0xC3: {Mnemonic: "JP nn", Bytes: 3, Cycles: 16, Execute: opJP_nn},
+	0xC4: {Mnemonic: "CALL NZ, nn", Bytes: 3, Cycles: 12, CyclesTaken: 24, Execute: opCALL_NZ_nn},
+	0xC5: {Mnemonic: "PUSH BC", Bytes: 1, Cycles: 16, Execute: opPUSH_BC},
+	0xC6: {Mnemonic: "ADD A, n", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagAffected, C: FlagAffected}, Execute: opADD_A_n},
+	0xC7: {Mnemonic: "RST 00H", Bytes: 1, Cycles: 16, Execute: opRST_00},
+	0xC8: {Mnemonic: "RET Z", Bytes: 1, Cycles: 8, CyclesTaken: 20, Execute: opRET_Z},
+	0xC9: {Mnemonic: "RET", Bytes: 1, Cycles: 16, Execute: opRET},
+	0xCA: {Mnemonic: "JP Z, nn", Bytes: 3, Cycles: 12, CyclesTaken: 16, Execute: opJP_Z_nn},
+	0xCB: {Mnemonic: "PREFIX CB", Bytes: 1, Cycles: 4, Execute: opPrefixCB},
+	0xCC: {Mnemonic: "CALL Z, nn", Bytes: 3, Cycles: 12, CyclesTaken: 24, Execute: opCALL_Z_nn},
+	0xCD: {Mnemonic: "CALL nn", Bytes: 3, Cycles: 24, Execute: opCALL_nn},
+	0xCE: {Mnemonic: "ADC A, n", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagAffected, C: FlagAffected}, Execute: opADC_A_n},
+	0xCF: {Mnemonic: "RST 08H", Bytes: 1, Cycles: 16, Execute: opRST_08},
+	0xD0: {Mnemonic: "RET NC", Bytes: 1, Cycles: 8, CyclesTaken: 20, Execute: opRET_NC},
+	0xD1: {Mnemonic: "POP DE", Bytes: 1, Cycles: 12, Execute: opPOP_DE},
+	0xD2: {Mnemonic: "JP NC, nn", Bytes: 3, Cycles: 12, CyclesTaken: 16, Execute: opJP_NC_nn},
+	0xD3: {Mnemonic: "ILLEGAL_0xD3", Bytes: 1, Cycles: 4, Execute: opIllegal},
+	0xD4: {Mnemonic: "CALL NC, nn", Bytes: 3, Cycles: 12, CyclesTaken: 24, Execute: opCALL_NC_nn},
+	0xD5: {Mnemonic: "PUSH DE", Bytes: 1, Cycles: 16, Execute: opPUSH_DE},
+	0xD6: {Mnemonic: "SUB A, n", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected, C: FlagAffected}, Execute: opSUB_A_n},
+	0xD7: {Mnemonic: "RST 10H", Bytes: 1, Cycles: 16, Execute: opRST_10},
+	0xD8: {Mnemonic: "RET C", Bytes: 1, Cycles: 8, CyclesTaken: 20, Execute: opRET_C},
+	0xD9: {Mnemonic: "RETI", Bytes: 1, Cycles: 16, Execute: opRETI},
+	0xDA: {Mnemonic: "JP C, nn", Bytes: 3, Cycles: 12, CyclesTaken: 16, Execute: opJP_C_nn},
+	0xDB: {Mnemonic: "ILLEGAL_0xDB", Bytes: 1, Cycles: 4, Execute: opIllegal},
+	0xDC: {Mnemonic: "CALL C, nn", Bytes: 3, Cycles: 12, CyclesTaken: 24, Execute: opCALL_C_nn},
+	0xDD: {Mnemonic: "ILLEGAL_0xDD", Bytes: 1, Cycles: 4, Execute: opIllegal},
+	0xDE: {Mnemonic: "SBC A, n", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected, C: FlagAffected}, Execute: opSBC_A_n},
+	0xDF: {Mnemonic: "RST 18H", Bytes: 1, Cycles: 16, Execute: opRST_18},
+	0xE0: {Mnemonic: "LDH (n), A", Bytes: 2, Cycles: 12, Execute: opLDH_n_A},
+	0xE1: {Mnemonic: "POP HL", Bytes: 1, Cycles: 12, Execute: opPOP_HL},
+	0xE2: {Mnemonic: "LD (C), A", Bytes: 1, Cycles: 8, Execute: opLD_Cmem_A},
+	0xE3: {Mnemonic: "ILLEGAL_0xE3", Bytes: 1, Cycles: 4, Execute: opIllegal},
+	0xE4: {Mnemonic: "ILLEGAL_0xE4", Bytes: 1, Cycles: 4, Execute: opIllegal},
+	0xE5: {Mnemonic: "PUSH HL", Bytes: 1, Cycles: 16, Execute: opPUSH_HL},
+	0xE6: {Mnemonic: "AND A, n", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet, C: FlagReset}, Execute: opAND_A_n},
+	0xE7: {Mnemonic: "RST 20H", Bytes: 1, Cycles: 16, Execute: opRST_20},
+	0xE8: {Mnemonic: "ADD SP, e8", Bytes: 2, Cycles: 16, Flags: FlagEffects{Z: FlagReset, N: FlagReset, H: FlagAffected, C: FlagAffected}, Execute: opADD_SP_e8},
+	0xE9: {Mnemonic: "UNKNOWN_0xE9", Bytes: 1, Cycles: 4, Execute: opUnknown}, // JP HL
+	0xEA: {Mnemonic: "UNKNOWN_0xEA", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD (nn), A
+	0xEB: {Mnemonic: "ILLEGAL_0xEB", Bytes: 1, Cycles: 4, Execute: opIllegal},
+	0xEC: {Mnemonic: "ILLEGAL_0xEC", Bytes: 1, Cycles: 4, Execute: opIllegal},
+	0xED: {Mnemonic: "ILLEGAL_0xED", Bytes: 1, Cycles: 4, Execute: opIllegal},
+	0xEE: {Mnemonic: "XOR A, n", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagReset}, Execute: opXOR_A_n},
+	0xEF: {Mnemonic: "RST 28H", Bytes: 1, Cycles: 16, Execute: opRST_28},
+	0xF0: {Mnemonic: "LDH A, (n)", Bytes: 2, Cycles: 12, Execute: opLDH_A_n},
+	0xF1: {Mnemonic: "POP AF", Bytes: 1, Cycles: 12, Flags: FlagEffects{Z: FlagAffected, N: FlagAffected, H: FlagAffected, C: FlagAffected}, Execute: opPOP_AF},
+	0xF2: {Mnemonic: "LD A, (C)", Bytes: 1, Cycles: 8, Execute: opLD_A_Cmem},
+	0xF3: {Mnemonic: "DI", Bytes: 1, Cycles: 4, Execute: opDI},
+	0xF4: {Mnemonic: "ILLEGAL_0xF4", Bytes: 1, Cycles: 4, Execute: opIllegal},
+	0xF5: {Mnemonic: "PUSH AF", Bytes: 1, Cycles: 16, Execute: opPUSH_AF},
+	0xF6: {Mnemonic: "OR A, n", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagReset}, Execute: opOR_A_n},
+	0xF7: {Mnemonic: "RST 30H", Bytes: 1, Cycles: 16, Execute: opRST_30},
+	0xF8: {Mnemonic: "LD HL, SP+e8", Bytes: 2, Cycles: 12, Flags: FlagEffects{Z: FlagReset, N: FlagReset, H: FlagAffected, C: FlagAffected}, Execute: opLD_HL_SPe8},
+	0xF9: {Mnemonic: "LD SP, HL", Bytes: 1, Cycles: 8, Execute: opLD_SP_HL},
+	0xFA: {Mnemonic: "UNKNOWN_0xFA", Bytes: 1, Cycles: 4, Execute: opUnknown}, // LD A, (nn)
+	0xFB: {Mnemonic: "EI", Bytes: 1, Cycles: 4, Execute: opEI},
+	0xFC: {Mnemonic: "ILLEGAL_0xFC", Bytes: 1, Cycles: 4, Execute: opIllegal},
+	0xFD: {Mnemonic: "ILLEGAL_0xFD", Bytes: 1, Cycles: 4, Execute: opIllegal},
+	0xFE: {Mnemonic: "CP A, n", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagSet, H: FlagAffected, C: FlagAffected}, Execute: opCP_A_n},
+	0xFF: {Mnemonic: "RST 38H", Bytes: 1, Cycles: 16, Execute: opRST_38},
+}
+
+// cbTable maps the byte following a 0xCB prefix to its implementation.
+var cbTable = [256]Opcode{
+	0x00: {Mnemonic: "RLC B", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).rlc, 0)},
+	0x01: {Mnemonic: "RLC C", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).rlc, 1)},
+	0x02: {Mnemonic: "RLC D", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).rlc, 2)},
+	0x03: {Mnemonic: "RLC E", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).rlc, 3)},
+	0x04: {Mnemonic: "RLC H", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).rlc, 4)},
+	0x05: {Mnemonic: "RLC L", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).rlc, 5)},
+	0x06: {Mnemonic: "RLC (HL)", Bytes: 2, Cycles: 16, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).rlc, 6)},
+	0x07: {Mnemonic: "RLC A", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).rlc, 7)},
+	0x08: {Mnemonic: "RRC B", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).rrc, 0)},
+	0x09: {Mnemonic: "RRC C", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).rrc, 1)},
+	0x0A: {Mnemonic: "RRC D", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).rrc, 2)},
+	0x0B: {Mnemonic: "RRC E", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).rrc, 3)},
+	0x0C: {Mnemonic: "RRC H", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).rrc, 4)},
+	0x0D: {Mnemonic: "RRC L", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).rrc, 5)},
+	0x0E: {Mnemonic: "RRC (HL)", Bytes: 2, Cycles: 16, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).rrc, 6)},
+	0x0F: {Mnemonic: "RRC A", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).rrc, 7)},
+	0x10: {Mnemonic: "RL B", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).rl, 0)},
+	0x11: {Mnemonic: "RL C", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).rl, 1)},
+	0x12: {Mnemonic: "RL D", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).rl, 2)},
+	0x13: {Mnemonic: "RL E", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).rl, 3)},
+	0x14: {Mnemonic: "RL H", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).rl, 4)},
+	0x15: {Mnemonic: "RL L", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).rl, 5)},
+	0x16: {Mnemonic: "RL (HL)", Bytes: 2, Cycles: 16, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).rl, 6)},
+	0x17: {Mnemonic: "RL A", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).rl, 7)},
+	0x18: {Mnemonic: "RR B", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).rr, 0)},
+	0x19: {Mnemonic: "RR C", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).rr, 1)},
+	0x1A: {Mnemonic: "RR D", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).rr, 2)},
+	0x1B: {Mnemonic: "RR E", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).rr, 3)},
+	0x1C: {Mnemonic: "RR H", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).rr, 4)},
+	0x1D: {Mnemonic: "RR L", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).rr, 5)},
+	0x1E: {Mnemonic: "RR (HL)", Bytes: 2, Cycles: 16, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).rr, 6)},
+	0x1F: {Mnemonic: "RR A", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).rr, 7)},
+	0x20: {Mnemonic: "SLA B", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).sla, 0)},
+	0x21: {Mnemonic: "SLA C", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).sla, 1)},
+	0x22: {Mnemonic: "SLA D", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).sla, 2)},
+	0x23: {Mnemonic: "SLA E", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).sla, 3)},
+	0x24: {Mnemonic: "SLA H", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).sla, 4)},
+	0x25: {Mnemonic: "SLA L", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).sla, 5)},
+	0x26: {Mnemonic: "SLA (HL)", Bytes: 2, Cycles: 16, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).sla, 6)},
+	0x27: {Mnemonic: "SLA A", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).sla, 7)},
+	0x28: {Mnemonic: "SRA B", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).sra, 0)},
+	0x29: {Mnemonic: "SRA C", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).sra, 1)},
+	0x2A: {Mnemonic: "SRA D", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).sra, 2)},
+	0x2B: {Mnemonic: "SRA E", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).sra, 3)},
+	0x2C: {Mnemonic: "SRA H", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).sra, 4)},
+	0x2D: {Mnemonic: "SRA L", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).sra, 5)},
+	0x2E: {Mnemonic: "SRA (HL)", Bytes: 2, Cycles: 16, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).sra, 6)},
+	0x2F: {Mnemonic: "SRA A", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).sra, 7)},
+	0x30: {Mnemonic: "SWAP B", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagReset}, Execute: cbShift((*CPU).swap, 0)},
+	0x31: {Mnemonic: "SWAP C", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagReset}, Execute: cbShift((*CPU).swap, 1)},
+	0x32: {Mnemonic: "SWAP D", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagReset}, Execute: cbShift((*CPU).swap, 2)},
+	0x33: {Mnemonic: "SWAP E", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagReset}, Execute: cbShift((*CPU).swap, 3)},
+	0x34: {Mnemonic: "SWAP H", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagReset}, Execute: cbShift((*CPU).swap, 4)},
+	0x35: {Mnemonic: "SWAP L", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagReset}, Execute: cbShift((*CPU).swap, 5)},
+	0x36: {Mnemonic: "SWAP (HL)", Bytes: 2, Cycles: 16, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagReset}, Execute: cbShift((*CPU).swap, 6)},
+	0x37: {Mnemonic: "SWAP A", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagReset}, Execute: cbShift((*CPU).swap, 7)},
+	0x38: {Mnemonic: "SRL B", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).srl, 0)},
+	0x39: {Mnemonic: "SRL C", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).srl, 1)},
+	0x3A: {Mnemonic: "SRL D", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).srl, 2)},
+	0x3B: {Mnemonic: "SRL E", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).srl, 3)},
+	0x3C: {Mnemonic: "SRL H", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).srl, 4)},
+	0x3D: {Mnemonic: "SRL L", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).srl, 5)},
+	0x3E: {Mnemonic: "SRL (HL)", Bytes: 2, Cycles: 16, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).srl, 6)},
+	0x3F: {Mnemonic: "SRL A", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagReset, C: FlagAffected}, Execute: cbShift((*CPU).srl, 7)},
+	0x40: {Mnemonic: "BIT 0, B", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(0, 0)},
+	0x41: {Mnemonic: "BIT 0, C", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(0, 1)},
+	0x42: {Mnemonic: "BIT 0, D", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(0, 2)},
+	0x43: {Mnemonic: "BIT 0, E", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(0, 3)},
+	0x44: {Mnemonic: "BIT 0, H", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(0, 4)},
+	0x45: {Mnemonic: "BIT 0, L", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(0, 5)},
+	0x46: {Mnemonic: "BIT 0, (HL)", Bytes: 2, Cycles: 12, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(0, 6)},
+	0x47: {Mnemonic: "BIT 0, A", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(0, 7)},
+	0x48: {Mnemonic: "BIT 1, B", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(1, 0)},
+	0x49: {Mnemonic: "BIT 1, C", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(1, 1)},
+	0x4A: {Mnemonic: "BIT 1, D", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(1, 2)},
+	0x4B: {Mnemonic: "BIT 1, E", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(1, 3)},
+	0x4C: {Mnemonic: "BIT 1, H", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(1, 4)},
+	0x4D: {Mnemonic: "BIT 1, L", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(1, 5)},
+	0x4E: {Mnemonic: "BIT 1, (HL)", Bytes: 2, Cycles: 12, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(1, 6)},
+	0x4F: {Mnemonic: "BIT 1, A", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(1, 7)},
+	0x50: {Mnemonic: "BIT 2, B", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(2, 0)},
+	0x51: {Mnemonic: "BIT 2, C", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(2, 1)},
+	0x52: {Mnemonic: "BIT 2, D", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(2, 2)},
+	0x53: {Mnemonic: "BIT 2, E", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(2, 3)},
+	0x54: {Mnemonic: "BIT 2, H", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(2, 4)},
+	0x55: {Mnemonic: "BIT 2, L", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(2, 5)},
+	0x56: {Mnemonic: "BIT 2, (HL)", Bytes: 2, Cycles: 12, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(2, 6)},
+	0x57: {Mnemonic: "BIT 2, A", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(2, 7)},
+	0x58: {Mnemonic: "BIT 3, B", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(3, 0)},
+	0x59: {Mnemonic: "BIT 3, C", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(3, 1)},
+	0x5A: {Mnemonic: "BIT 3, D", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(3, 2)},
+	0x5B: {Mnemonic: "BIT 3, E", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(3, 3)},
+	0x5C: {Mnemonic: "BIT 3, H", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(3, 4)},
+	0x5D: {Mnemonic: "BIT 3, L", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(3, 5)},
+	0x5E: {Mnemonic: "BIT 3, (HL)", Bytes: 2, Cycles: 12, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(3, 6)},
+	0x5F: {Mnemonic: "BIT 3, A", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(3, 7)},
+	0x60: {Mnemonic: "BIT 4, B", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(4, 0)},
+	0x61: {Mnemonic: "BIT 4, C", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(4, 1)},
+	0x62: {Mnemonic: "BIT 4, D", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(4, 2)},
+	0x63: {Mnemonic: "BIT 4, E", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(4, 3)},
+	0x64: {Mnemonic: "BIT 4, H", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(4, 4)},
+	0x65: {Mnemonic: "BIT 4, L", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(4, 5)},
+	0x66: {Mnemonic: "BIT 4, (HL)", Bytes: 2, Cycles: 12, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(4, 6)},
+	0x67: {Mnemonic: "BIT 4, A", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(4, 7)},
+	0x68: {Mnemonic: "BIT 5, B", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(5, 0)},
+	0x69: {Mnemonic: "BIT 5, C", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(5, 1)},
+	0x6A: {Mnemonic: "BIT 5, D", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(5, 2)},
+	0x6B: {Mnemonic: "BIT 5, E", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(5, 3)},
+	0x6C: {Mnemonic: "BIT 5, H", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(5, 4)},
+	0x6D: {Mnemonic: "BIT 5, L", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(5, 5)},
+	0x6E: {Mnemonic: "BIT 5, (HL)", Bytes: 2, Cycles: 12, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(5, 6)},
+	0x6F: {Mnemonic: "BIT 5, A", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(5, 7)},
+	0x70: {Mnemonic: "BIT 6, B", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(6, 0)},
+	0x71: {Mnemonic: "BIT 6, C", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(6, 1)},
+	0x72: {Mnemonic: "BIT 6, D", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(6, 2)},
+	0x73: {Mnemonic: "BIT 6, E", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(6, 3)},
+	0x74: {Mnemonic: "BIT 6, H", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(6, 4)},
+	0x75: {Mnemonic: "BIT 6, L", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(6, 5)},
+	0x76: {Mnemonic: "BIT 6, (HL)", Bytes: 2, Cycles: 12, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(6, 6)},
+	0x77: {Mnemonic: "BIT 6, A", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(6, 7)},
+	0x78: {Mnemonic: "BIT 7, B", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(7, 0)},
+	0x79: {Mnemonic: "BIT 7, C", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(7, 1)},
+	0x7A: {Mnemonic: "BIT 7, D", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(7, 2)},
+	0x7B: {Mnemonic: "BIT 7, E", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(7, 3)},
+	0x7C: {Mnemonic: "BIT 7, H", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(7, 4)},
+	0x7D: {Mnemonic: "BIT 7, L", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(7, 5)},
+	0x7E: {Mnemonic: "BIT 7, (HL)", Bytes: 2, Cycles: 12, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(7, 6)},
+	0x7F: {Mnemonic: "BIT 7, A", Bytes: 2, Cycles: 8, Flags: FlagEffects{Z: FlagAffected, N: FlagReset, H: FlagSet}, Execute: cbBIT(7, 7)},
+	0x80: {Mnemonic: "RES 0, B", Bytes: 2, Cycles: 8, Execute: cbRES(0, 0)},
+	0x81: {Mnemonic: "RES 0, C", Bytes: 2, Cycles: 8, Execute: cbRES(0, 1)},
+	0x82: {Mnemonic: "RES 0, D", Bytes: 2, Cycles: 8, Execute: cbRES(0, 2)},
+	0x83: {Mnemonic: "RES 0, E", Bytes: 2, Cycles: 8, Execute: cbRES(0, 3)},
+	0x84: {Mnemonic: "RES 0, H", Bytes: 2, Cycles: 8, Execute: cbRES(0, 4)},
+	0x85: {Mnemonic: "RES 0, L", Bytes: 2, Cycles: 8, Execute: cbRES(0, 5)},
+	0x86: {Mnemonic: "RES 0, (HL)", Bytes: 2, Cycles: 16, Execute: cbRES(0, 6)},
+	0x87: {Mnemonic: "RES 0, A", Bytes: 2, Cycles: 8, Execute: cbRES(0, 7)},
+	0x88: {Mnemonic: "RES 1, B", Bytes: 2, Cycles: 8, Execute: cbRES(1, 0)},
+	0x89: {Mnemonic: "RES 1, C", Bytes: 2, Cycles: 8, Execute: cbRES(1, 1)},
+	0x8A: {Mnemonic: "RES 1, D", Bytes: 2, Cycles: 8, Execute: cbRES(1, 2)},
+	0x8B: {Mnemonic: "RES 1, E", Bytes: 2, Cycles: 8, Execute: cbRES(1, 3)},
+	0x8C: {Mnemonic: "RES 1, H", Bytes: 2, Cycles: 8, Execute: cbRES(1, 4)},
+	0x8D: {Mnemonic: "RES 1, L", Bytes: 2, Cycles: 8, Execute: cbRES(1, 5)},
+	0x8E: {Mnemonic: "RES 1, (HL)", Bytes: 2, Cycles: 16, Execute: cbRES(1, 6)},
+	0x8F: {Mnemonic: "RES 1, A", Bytes: 2, Cycles: 8, Execute: cbRES(1, 7)},
+	0x90: {Mnemonic: "RES 2, B", Bytes: 2, Cycles: 8, Execute: cbRES(2, 0)},
+	0x91: {Mnemonic: "RES 2, C", Bytes: 2, Cycles: 8, Execute: cbRES(2, 1)},
+	0x92: {Mnemonic: "RES 2, D", Bytes: 2, Cycles: 8, Execute: cbRES(2, 2)},
+	0x93: {Mnemonic: "RES 2, E", Bytes: 2, Cycles: 8, Execute: cbRES(2, 3)},
+	0x94: {Mnemonic: "RES 2, H", Bytes: 2, Cycles: 8, Execute: cbRES(2, 4)},
+	0x95: {Mnemonic: "RES 2, L", Bytes: 2, Cycles: 8, Execute: cbRES(2, 5)},
+	0x96: {Mnemonic: "RES 2, (HL)", Bytes: 2, Cycles: 16, Execute: cbRES(2, 6)},
+	0x97: {Mnemonic: "RES 2, A", Bytes: 2, Cycles: 8, Execute: cbRES(2, 7)},
+	0x98: {Mnemonic: "RES 3, B", Bytes: 2, Cycles: 8, Execute: cbRES(3, 0)},
+	0x99: {Mnemonic: "RES 3, C", Bytes: 2, Cycles: 8, Execute: cbRES(3, 1)},
+	0x9A: {Mnemonic: "RES 3, D", Bytes: 2, Cycles: 8, Execute: cbRES(3, 2)},
+	0x9B: {Mnemonic: "RES 3, E", Bytes: 2, Cycles: 8, Execute: cbRES(3, 3)},
+	0x9C: {Mnemonic: "RES 3, H", Bytes: 2, Cycles: 8, Execute: cbRES(3, 4)},
+	0x9D: {Mnemonic: "RES 3, L", Bytes: 2, Cycles: 8, Execute: cbRES(3, 5)},
+	0x9E: {Mnemonic: "RES 3, (HL)", Bytes: 2, Cycles: 16, Execute: cbRES(3, 6)},
+	0x9F: {Mnemonic: "RES 3, A", Bytes: 2, Cycles: 8, Execute: cbRES(3, 7)},
+	0xA0: {Mnemonic: "RES 4, B", Bytes: 2, Cycles: 8, Execute: cbRES(4, 0)},
+	0xA1: {Mnemonic: "RES 4, C", Bytes: 2, Cycles: 8, Execute: cbRES(4, 1)},
+	0xA2: {Mnemonic: "RES 4, D", Bytes: 2, Cycles: 8, Execute: cbRES(4, 2)},
+	0xA3: {Mnemonic: "RES 4, E", Bytes: 2, Cycles: 8, Execute: cbRES(4, 3)},
+	0xA4: {Mnemonic: "RES 4, H", Bytes: 2, Cycles: 8, Execute: cbRES(4, 4)},
+	0xA5: {Mnemonic: "RES 4, L", Bytes: 2, Cycles: 8, Execute: cbRES(4, 5)},
+	0xA6: {Mnemonic: "RES 4, (HL)", Bytes: 2, Cycles: 16, Execute: cbRES(4, 6)},
+	0xA7: {Mnemonic: "RES 4, A", Bytes: 2, Cycles: 8, Execute: cbRES(4, 7)},
+	0xA8: {Mnemonic: "RES 5, B", Bytes: 2, Cycles: 8, Execute: cbRES(5, 0)},
+	0xA9: {Mnemonic: "RES 5, C", Bytes: 2, Cycles: 8, Execute: cbRES(5, 1)},
+	0xAA: {Mnemonic: "RES 5, D", Bytes: 2, Cycles: 8, Execute: cbRES(5, 2)},
+	0xAB: {Mnemonic: "RES 5, E", Bytes: 2, Cycles: 8, Execute: cbRES(5, 3)},
+	0xAC: {Mnemonic: "RES 5, H", Bytes: 2, Cycles: 8, Execute: cbRES(5, 4)},
+	0xAD: {Mnemonic: "RES 5, L", Bytes: 2, Cycles: 8, Execute: cbRES(5, 5)},
+	0xAE: {Mnemonic: "RES 5, (HL)", Bytes: 2, Cycles: 16, Execute: cbRES(5, 6)},
+	0xAF: {Mnemonic: "RES 5, A", Bytes: 2, Cycles: 8, Execute: cbRES(5, 7)},
+	0xB0: {Mnemonic: "RES 6, B", Bytes: 2, Cycles: 8, Execute: cbRES(6, 0)},
+	0xB1: {Mnemonic: "RES 6, C", Bytes: 2, Cycles: 8, Execute: cbRES(6, 1)},
+	0xB2: {Mnemonic: "RES 6, D", Bytes: 2, Cycles: 8, Execute: cbRES(6, 2)},
+	0xB3: {Mnemonic: "RES 6, E", Bytes: 2, Cycles: 8, Execute: cbRES(6, 3)},
+	0xB4: {Mnemonic: "RES 6, H", Bytes: 2, Cycles: 8, Execute: cbRES(6, 4)},
+	0xB5: {Mnemonic: "RES 6, L", Bytes: 2, Cycles: 8, Execute: cbRES(6, 5)},
+	0xB6: {Mnemonic: "RES 6, (HL)", Bytes: 2, Cycles: 16, Execute: cbRES(6, 6)},
+	0xB7: {Mnemonic: "RES 6, A", Bytes: 2, Cycles: 8, Execute: cbRES(6, 7)},
+	0xB8: {Mnemonic: "RES 7, B", Bytes: 2, Cycles: 8, Execute: cbRES(7, 0)},
+	0xB9: {Mnemonic: "RES 7, C", Bytes: 2, Cycles: 8, Execute: cbRES(7, 1)},
+	0xBA: {Mnemonic: "RES 7, D", Bytes: 2, Cycles: 8, Execute: cbRES(7, 2)},
+	0xBB: {Mnemonic: "RES 7, E", Bytes: 2, Cycles: 8, Execute: cbRES(7, 3)},
+	0xBC: {Mnemonic: "RES 7, H", Bytes: 2, Cycles: 8, Execute: cbRES(7, 4)},
+	0xBD: {Mnemonic: "RES 7, L", Bytes: 2, Cycles: 8, Execute: cbRES(7, 5)},
+	0xBE: {Mnemonic: "RES 7, (HL)", Bytes: 2, Cycles: 16, Execute: cbRES(7, 6)},
+	0xBF: {Mnemonic: "RES 7, A", Bytes: 2, Cycles: 8, Execute: cbRES(7, 7)},
+	0xC0: {Mnemonic: "SET 0, B", Bytes: 2, Cycles: 8, Execute: cbSET(0, 0)},
+	0xC1: {Mnemonic: "SET 0, C", Bytes: 2, Cycles: 8, Execute: cbSET(0, 1)},
+	0xC2: {Mnemonic: "SET 0, D", Bytes: 2, Cycles: 8, Execute: cbSET(0, 2)},
+	0xC3: {Mnemonic: "SET 0, E", Bytes: 2, Cycles: 8, Execute: cbSET(0, 3)},
+	0xC4: {Mnemonic: "SET 0, H", Bytes: 2, Cycles: 8, Execute: cbSET(0, 4)},
+	0xC5: {Mnemonic: "SET 0, L", Bytes: 2, Cycles: 8, Execute: cbSET(0, 5)},
+	0xC6: {Mnemonic: "SET 0, (HL)", Bytes: 2, Cycles: 16, Execute: cbSET(0, 6)},
+	0xC7: {Mnemonic: "SET 0, A", Bytes: 2, Cycles: 8, Execute: cbSET(0, 7)},
+	0xC8: {Mnemonic: "SET 1, B", Bytes: 2, Cycles: 8, Execute: cbSET(1, 0)},
+	0xC9: {Mnemonic: "SET 1, C", Bytes: 2, Cycles: 8, Execute: cbSET(1, 1)},
+	0xCA: {Mnemonic: "SET 1, D", Bytes: 2, Cycles: 8, Execute: cbSET(1, 2)},
+	0xCB: {Mnemonic: "SET 1, E", Bytes: 2, Cycles: 8, Execute: cbSET(1, 3)},
+	0xCC: {Mnemonic: "SET 1, H", Bytes: 2, Cycles: 8, Execute: cbSET(1, 4)},
+	0xCD: {Mnemonic: "SET 1, L", Bytes: 2, Cycles: 8, Execute: cbSET(1, 5)},
+	0xCE: {Mnemonic: "SET 1, (HL)", Bytes: 2, Cycles: 16, Execute: cbSET(1, 6)},
+	0xCF: {Mnemonic: "SET 1, A", Bytes: 2, Cycles: 8, Execute: cbSET(1, 7)},
+	0xD0: {Mnemonic: "SET 2, B", Bytes: 2, Cycles: 8, Execute: cbSET(2, 0)},
+	0xD1: {Mnemonic: "SET 2, C", Bytes: 2, Cycles: 8, Execute: cbSET(2, 1)},
+	0xD2: {Mnemonic: "SET 2, D", Bytes: 2, Cycles: 8, Execute: cbSET(2, 2)},
+	0xD3: {Mnemonic: "SET 2, E", Bytes: 2, Cycles: 8, Execute: cbSET(2, 3)},
+	0xD4: {Mnemonic: "SET 2, H", Bytes: 2, Cycles: 8, Execute: cbSET(2, 4)},
+	0xD5: {Mnemonic: "SET 2, L", Bytes: 2, Cycles: 8, Execute: cbSET(2, 5)},
+	0xD6: {Mnemonic: "SET 2, (HL)", Bytes: 2, Cycles: 16, Execute: cbSET(2, 6)},
+	0xD7: {Mnemonic: "SET 2, A", Bytes: 2, Cycles: 8, Execute: cbSET(2, 7)},
+	0xD8: {Mnemonic: "SET 3, B", Bytes: 2, Cycles: 8, Execute: cbSET(3, 0)},
+	0xD9: {Mnemonic: "SET 3, C", Bytes: 2, Cycles: 8, Execute: cbSET(3, 1)},
+	0xDA: {Mnemonic: "SET 3, D", Bytes: 2, Cycles: 8, Execute: cbSET(3, 2)},
+	0xDB: {Mnemonic: "SET 3, E", Bytes: 2, Cycles: 8, Execute: cbSET(3, 3)},
+	0xDC: {Mnemonic: "SET 3, H", Bytes: 2, Cycles: 8, Execute: cbSET(3, 4)},
+	0xDD: {Mnemonic: "SET 3, L", Bytes: 2, Cycles: 8, Execute: cbSET(3, 5)},
+	0xDE: {Mnemonic: "SET 3, (HL)", Bytes: 2, Cycles: 16, Execute: cbSET(3, 6)},
+	0xDF: {Mnemonic: "SET 3, A", Bytes: 2, Cycles: 8, Execute: cbSET(3, 7)},
+	0xE0: {Mnemonic: "SET 4, B", Bytes: 2, Cycles: 8, Execute: cbSET(4, 0)},
+	0xE1: {Mnemonic: "SET 4, C", Bytes: 2, Cycles: 8, Execute: cbSET(4, 1)},
+	0xE2: {Mnemonic: "SET 4, D", Bytes: 2, Cycles: 8, Execute: cbSET(4, 2)},
+	0xE3: {Mnemonic: "SET 4, E", Bytes: 2, Cycles: 8, Execute: cbSET(4, 3)},
+	0xE4: {Mnemonic: "SET 4, H", Bytes: 2, Cycles: 8, Execute: cbSET(4, 4)},
+	0xE5: {Mnemonic: "SET 4, L", Bytes: 2, Cycles: 8, Execute: cbSET(4, 5)},
+	0xE6: {Mnemonic: "SET 4, (HL)", Bytes: 2, Cycles: 16, Execute: cbSET(4, 6)},
+	0xE7: {Mnemonic: "SET 4, A", Bytes: 2, Cycles: 8, Execute: cbSET(4, 7)},
+	0xE8: {Mnemonic: "SET 5, B", Bytes: 2, Cycles: 8, Execute: cbSET(5, 0)},
+	0xE9: {Mnemonic: "SET 5, C", Bytes: 2, Cycles: 8, Execute: cbSET(5, 1)},
+	0xEA: {Mnemonic: "SET 5, D", Bytes: 2, Cycles: 8, Execute: cbSET(5, 2)},
+	0xEB: {Mnemonic: "SET 5, E", Bytes: 2, Cycles: 8, Execute: cbSET(5, 3)},
+	0xEC: {Mnemonic: "SET 5, H", Bytes: 2, Cycles: 8, Execute: cbSET(5, 4)},
+	0xED: {Mnemonic: "SET 5, L", Bytes: 2, Cycles: 8, Execute: cbSET(5, 5)},
+	0xEE: {Mnemonic: "SET 5, (HL)", Bytes: 2, Cycles: 16, Execute: cbSET(5, 6)},
+	0xEF: {Mnemonic: "SET 5, A", Bytes: 2, Cycles: 8, Execute: cbSET(5, 7)},
+	0xF0: {Mnemonic: "SET 6, B", Bytes: 2, Cycles: 8, Execute: cbSET(6, 0)},
+	0xF1: {Mnemonic: "SET 6, C", Bytes: 2, Cycles: 8, Execute: cbSET(6, 1)},
+	0xF2: {Mnemonic: "SET 6, D", Bytes: 2, Cycles: 8, Execute: cbSET(6, 2)},
+	0xF3: {Mnemonic: "SET 6, E", Bytes: 2, Cycles: 8, Execute: cbSET(6, 3)},
+	0xF4: {Mnemonic: "SET 6, H", Bytes: 2, Cycles: 8, Execute: cbSET(6, 4)},
+	0xF5: {Mnemonic: "SET 6, L", Bytes: 2, Cycles: 8, Execute: cbSET(6, 5)},
+	0xF6: {Mnemonic: "SET 6, (HL)", Bytes: 2, Cycles: 16, Execute: cbSET(6, 6)},
+	0xF7: {Mnemonic: "SET 6, A", Bytes: 2, Cycles: 8, Execute: cbSET(6, 7)},
+	0xF8: {Mnemonic: "SET 7, B", Bytes: 2, Cycles: 8, Execute: cbSET(7, 0)},
+	0xF9: {Mnemonic: "SET 7, C", Bytes: 2, Cycles: 8, Execute: cbSET(7, 1)},
+	0xFA: {Mnemonic: "SET 7, D", Bytes: 2, Cycles: 8, Execute: cbSET(7, 2)},
+	0xFB: {Mnemonic: "SET 7, E", Bytes: 2, Cycles: 8, Execute: cbSET(7, 3)},
+	0xFC: {Mnemonic: "SET 7, H", Bytes: 2, Cycles: 8, Execute: cbSET(7, 4)},
+	0xFD: {Mnemonic: "SET 7, L", Bytes: 2, Cycles: 8, Execute: cbSET(7, 5)},
+	0xFE: {Mnemonic: "SET 7, (HL)", Bytes: 2, Cycles: 16, Execute: cbSET(7, 6)},
+	0xFF: {Mnemonic: "SET 7, A", Bytes: 2, Cycles: 8, Execute: cbSET(7, 7)},
+}
+
+// execute runs the instruction for an unprefixed opcode, like
+// opcodeTable[opcode].Execute but without the indirect call.
+// The 0xCB prefix is decoded by dispatch and has no case here.
+func (cpu *CPU) execute(opcode uint8) {
+	switch opcode {
+	case 0x00:
+		opNOP(cpu)
+	case 0x01:
+		opLD_BC_nn(cpu)
+	case 0x02, 0x0A, 0x12, 0x16, 0x1A, 0x1E, 0x26, 0x2E, 0x36, 0x41, 0x42, 0x43, 0x44, 0x45, 0x46, 0x47, 0x48, 0x49, 0x4A, 0x4B, 0x4C, 0x4D, 0x4E, 0x4F, 0x50, 0x51, 0x53, 0x54, 0x55, 0x56, 0x57, 0x58, 0x59, 0x5A, 0x5B, 0x5C, 0x5D, 0x5E, 0x5F, 0x60, 0x61, 0x62, 0x63, 0x64, 0x65, 0x66, 0x67, 0x68, 0x69, 0x6A, 0x6B, 0x6C, 0x6D, 0x6E, 0x6F, 0x70, 0x71, 0x72, 0x73, 0x74, 0x75, 0x77, 0x7A, 0x7B, 0x7C, 0x7D, 0x7E, 0x7F, 0xE9, 0xEA, 0xFA:
+		opUnknown(cpu)
+	case 0x03:
+		opINC_BC(cpu)
+	case 0x04:
+		opINC_B(cpu)
+	case 0x05:
+		opDEC_B(cpu)
+	case 0x06:
+		opLD_B_n(cpu)
+	case 0x07:
+		opRLCA(cpu)
+	case 0x08:
+		opLD_nn_SP(cpu)
+	case 0x09:
+		opADD_HL_BC(cpu)
+	case 0x0B:
+		opDEC_BC(cpu)
+	case 0x0C:
+		opINC_C(cpu)
+	case 0x0D:
+		opDEC_C(cpu)
+	case 0x0E:
+		opLD_C_n(cpu)
+	case 0x0F:
+		opRRCA(cpu)
+	case 0x10:
+		opSTOP(cpu)
+	case 0x11:
+		opLD_DE_nn(cpu)
+	case 0x13:
+		opINC_DE(cpu)
+	case 0x14:
+		opINC_D(cpu)
+	case 0x15:
+		opDEC_D(cpu)
+	case 0x17:
+		opRLA(cpu)
+	case 0x18:
+		opJR_e8(cpu)
+	case 0x19:
+		opADD_HL_DE(cpu)
+	case 0x1B:
+		opDEC_DE(cpu)
+	case 0x1C:
+		opINC_E(cpu)
+	case 0x1D:
+		opDEC_E(cpu)
+	case 0x1F:
+		opRRA(cpu)
+	case 0x20:
+		opJR_NZ_e8(cpu)
+	case 0x21:
+		opLD_HL_nn(cpu)
+	case 0x22:
+		opLD_HLI_A(cpu)
+	case 0x23:
+		opINC_HL(cpu)
+	case 0x24:
+		opINC_H(cpu)
+	case 0x25:
+		opDEC_H(cpu)
+	case 0x27:
+		opDAA(cpu)
+	case 0x28:
+		opJR_Z_e8(cpu)
+	case 0x29:
+		opADD_HL_HL(cpu)
+	case 0x2A:
+		opLD_A_HLI(cpu)
+	case 0x2B:
+		opDEC_HL(cpu)
+	case 0x2C:
+		opINC_L(cpu)
+	case 0x2D:
+		opDEC_L(cpu)
+	case 0x2F:
+		opCPL(cpu)
+	case 0x30:
+		opJR_NC_e8(cpu)
+	case 0x31:
+		opLD_SP_nn(cpu)
+	case 0x32:
+		opLD_HLD_A(cpu)
+	case 0x33:
+		opINC_SP(cpu)
+	case 0x34:
+		opINC_HLmem(cpu)
+	case 0x35:
+		opDEC_HLmem(cpu)
+	case 0x37:
+		opSCF(cpu)
+	case 0x38:
+		opJR_C_e8(cpu)
+	case 0x39:
+		opADD_HL_SP(cpu)
+	case 0x3A:
+		opLD_A_HLD(cpu)
+	case 0x3B:
+		opDEC_SP(cpu)
+	case 0x3C:
+		opINC_A(cpu)
+	case 0x3D:
+		opDEC_A(cpu)
+	case 0x3E:
+		opLD_A_n(cpu)
+	case 0x3F:
+		opCCF(cpu)
+	case 0x40:
+		opLD_B_B(cpu)
+	case 0x52:
+		opLD_D_D(cpu)
+	case 0x76:
+		opHALT(cpu)
+	case 0x78:
+		opLD_A_B(cpu)
+	case 0x79:
+		opLD_A_C(cpu)
+	case 0x80:
+		opADD_A_B(cpu)
+	case 0x81:
+		opADD_A_C(cpu)
+	case 0x82:
+		opADD_A_D(cpu)
+	case 0x83:
+		opADD_A_E(cpu)
+	case 0x84:
+		opADD_A_H(cpu)
+	case 0x85:
+		opADD_A_L(cpu)
+	case 0x86:
+		opADD_A_HLmem(cpu)
+	case 0x87:
+		opADD_A_A(cpu)
+	case 0x88:
+		opADC_A_B(cpu)
+	case 0x89:
+		opADC_A_C(cpu)
+	case 0x8A:
+		opADC_A_D(cpu)
+	case 0x8B:
+		opADC_A_E(cpu)
+	case 0x8C:
+		opADC_A_H(cpu)
+	case 0x8D:
+		opADC_A_L(cpu)
+	case 0x8E:
+		opADC_A_HLmem(cpu)
+	case 0x8F:
+		opADC_A_A(cpu)
+	case 0x90:
+		opSUB_A_B(cpu)
+	case 0x91:
+		opSUB_A_C(cpu)
+	case 0x92:
+		opSUB_A_D(cpu)
+	case 0x93:
+		opSUB_A_E(cpu)
+	case 0x94:
+		opSUB_A_H(cpu)
+	case 0x95:
+		opSUB_A_L(cpu)
+	case 0x96:
+		opSUB_A_HLmem(cpu)
+	case 0x97:
+		opSUB_A_A(cpu)
+	case 0x98:
+		opSBC_A_B(cpu)
+	case 0x99:
+		opSBC_A_C(cpu)
+	case 0x9A:
+		opSBC_A_D(cpu)
+	case 0x9B:
+		opSBC_A_E(cpu)
+	case 0x9C:
+		opSBC_A_H(cpu)
+	case 0x9D:
+		opSBC_A_L(cpu)
+	case 0x9E:
+		opSBC_A_HLmem(cpu)
+	case 0x9F:
+		opSBC_A_A(cpu)
+	case 0xA0:
+		opAND_A_B(cpu)
+	case 0xA1:
+		opAND_A_C(cpu)
+	case 0xA2:
+		opAND_A_D(cpu)
+	case 0xA3:
+		opAND_A_E(cpu)
+	case 0xA4:
+		opAND_A_H(cpu)
+	case 0xA5:
+		opAND_A_L(cpu)
+	case 0xA6:
+		opAND_A_HLmem(cpu)
+	case 0xA7:
+		opAND_A_A(cpu)
+	case 0xA8:
+		opXOR_A_B(cpu)
+	case 0xA9:
+		opXOR_A_C(cpu)
+	case 0xAA:
+		opXOR_A_D(cpu)
+	case 0xAB:
+		opXOR_A_E(cpu)
+	case 0xAC:
+		opXOR_A_H(cpu)
+	case 0xAD:
+		opXOR_A_L(cpu)
+	case 0xAE:
+		opXOR_A_HLmem(cpu)
+	case 0xAF:
+		opXOR_A_A(cpu)
+	case 0xB0:
+		opOR_A_B(cpu)
+	case 0xB1:
+		opOR_A_C(cpu)
+	case 0xB2:
+		opOR_A_D(cpu)
+	case 0xB3:
+		opOR_A_E(cpu)
+	case 0xB4:
+		opOR_A_H(cpu)
+	case 0xB5:
+		opOR_A_L(cpu)
+	case 0xB6:
+		opOR_A_HLmem(cpu)
+	case 0xB7:
+		opOR_A_A(cpu)
+	case 0xB8:
+		opCP_A_B(cpu)
+	case 0xB9:
+		opCP_A_C(cpu)
+	case 0xBA:
+		opCP_A_D(cpu)
+	case 0xBB:
+		opCP_A_E(cpu)
+	case 0xBC:
+		opCP_A_H(cpu)
+	case 0xBD:
+		opCP_A_L(cpu)
+	case 0xBE:
+		opCP_A_HLmem(cpu)
+	case 0xBF:
+		opCP_A_A(cpu)
+	case 0xC0:
+		opRET_NZ(cpu)
+	case 0xC1:
+		opPOP_BC(cpu)
+	case 0xC2:
+		opJP_NZ_nn(cpu)
+	case 0xC3:
+		opJP_nn(cpu)
+	case 0xC4:
+		opCALL_NZ_nn(cpu)
+	case 0xC5:
+		opPUSH_BC(cpu)
+	case 0xC6:
+		opADD_A_n(cpu)
+	case 0xC7:
+		opRST_00(cpu)
+	case 0xC8:
+		opRET_Z(cpu)
+	case 0xC9:
+		opRET(cpu)
+	case 0xCA:
+		opJP_Z_nn(cpu)
+	case 0xCC:
+		opCALL_Z_nn(cpu)
+	case 0xCD:
+		opCALL_nn(cpu)
+	case 0xCE:
+		opADC_A_n(cpu)
+	case 0xCF:
+		opRST_08(cpu)
+	case 0xD0:
+		opRET_NC(cpu)
+	case 0xD1:
+		opPOP_DE(cpu)
+	case 0xD2:
+		opJP_NC_nn(cpu)
+	case 0xD3, 0xDB, 0xDD, 0xE3, 0xE4, 0xEB, 0xEC, 0xED, 0xF4, 0xFC, 0xFD:
+		opIllegal(cpu)
+	case 0xD4:
+		opCALL_NC_nn(cpu)
+	case 0xD5:
+		opPUSH_DE(cpu)
+	case 0xD6:
+		opSUB_A_n(cpu)
+	case 0xD7:
+		opRST_10(cpu)
+	case 0xD8:
+		opRET_C(cpu)
+	case 0xD9:
+		opRETI(cpu)
+	case 0xDA:
+		opJP_C_nn(cpu)
+	case 0xDC:
+		opCALL_C_nn(cpu)
+	case 0xDE:
+		opSBC_A_n(cpu)
+	case 0xDF:
+		opRST_18(cpu)
+	case 0xE0:
+		opLDH_n_A(cpu)
+	case 0xE1:
+		opPOP_HL(cpu)
+	case 0xE2:
+		opLD_Cmem_A(cpu)
+	case 0xE5:
+		opPUSH_HL(cpu)
+	case 0xE6:
+		opAND_A_n(cpu)
+	case 0xE7:
+		opRST_20(cpu)
+	case 0xE8:
+		opADD_SP_e8(cpu)
+	case 0xEE:
+		opXOR_A_n(cpu)
+	case 0xEF:
+		opRST_28(cpu)
+	case 0xF0:
+		opLDH_A_n(cpu)
+	case 0xF1:
+		opPOP_AF(cpu)
+	case 0xF2:
+		opLD_A_Cmem(cpu)
+	case 0xF3:
+		opDI(cpu)
+	case 0xF5:
+		opPUSH_AF(cpu)
+	case 0xF6:
+		opOR_A_n(cpu)
+	case 0xF7:
+		opRST_30(cpu)
+	case 0xF8:
+		opLD_HL_SPe8(cpu)
+	case 0xF9:
+		opLD_SP_HL(cpu)
+	case 0xFB:
+		opEI(cpu)
+	case 0xFE:
+		opCP_A_n(cpu)
+	case 0xFF:
+		opRST_38(cpu)
+	}
+}
+
+// executeCB runs the instruction for the byte following a 0xCB
+// prefix, like cbTable[opcode].Execute but without the indirect call.
+func (cpu *CPU) executeCB(opcode uint8) {
+	switch opcode {
+	case 0x00:
+		cpu.Registers.B = cpu.rlc(cpu.Registers.B)
+	case 0x01:
+		cpu.Registers.C = cpu.rlc(cpu.Registers.C)
+	case 0x02:
+		cpu.Registers.D = cpu.rlc(cpu.Registers.D)
+	case 0x03:
+		cpu.Registers.E = cpu.rlc(cpu.Registers.E)
+	case 0x04:
+		cpu.Registers.H = cpu.rlc(cpu.Registers.H)
+	case 0x05:
+		cpu.Registers.L = cpu.rlc(cpu.Registers.L)
+	case 0x06:
+		cpu.write(cpu.Registers.HL(), cpu.rlc(cpu.read(cpu.Registers.HL())))
+	case 0x07:
+		cpu.Registers.A = cpu.rlc(cpu.Registers.A)
+	case 0x08:
+		cpu.Registers.B = cpu.rrc(cpu.Registers.B)
+	case 0x09:
+		cpu.Registers.C = cpu.rrc(cpu.Registers.C)
+	case 0x0A:
+		cpu.Registers.D = cpu.rrc(cpu.Registers.D)
+	case 0x0B:
+		cpu.Registers.E = cpu.rrc(cpu.Registers.E)
+	case 0x0C:
+		cpu.Registers.H = cpu.rrc(cpu.Registers.H)
+	case 0x0D:
+		cpu.Registers.L = cpu.rrc(cpu.Registers.L)
+	case 0x0E:
+		cpu.write(cpu.Registers.HL(), cpu.rrc(cpu.read(cpu.Registers.HL())))
+	case 0x0F:
+		cpu.Registers.A = cpu.rrc(cpu.Registers.A)
+	case 0x10:
+		cpu.Registers.B = cpu.rl(cpu.Registers.B)
+	case 0x11:
+		cpu.Registers.C = cpu.rl(cpu.Registers.C)
+	case 0x12:
+		cpu.Registers.D = cpu.rl(cpu.Registers.D)
+	case 0x13:
+		cpu.Registers.E = cpu.rl(cpu.Registers.E)
+	case 0x14:
+		cpu.Registers.H = cpu.rl(cpu.Registers.H)
+	case 0x15:
+		cpu.Registers.L = cpu.rl(cpu.Registers.L)
+	case 0x16:
+		cpu.write(cpu.Registers.HL(), cpu.rl(cpu.read(cpu.Registers.HL())))
+	case 0x17:
+		cpu.Registers.A = cpu.rl(cpu.Registers.A)
+	case 0x18:
+		cpu.Registers.B = cpu.rr(cpu.Registers.B)
+	case 0x19:
+		cpu.Registers.C = cpu.rr(cpu.Registers.C)
+	case 0x1A:
+		cpu.Registers.D = cpu.rr(cpu.Registers.D)
+	case 0x1B:
+		cpu.Registers.E = cpu.rr(cpu.Registers.E)
+	case 0x1C:
+		cpu.Registers.H = cpu.rr(cpu.Registers.H)
+	case 0x1D:
+		cpu.Registers.L = cpu.rr(cpu.Registers.L)
+	case 0x1E:
+		cpu.write(cpu.Registers.HL(), cpu.rr(cpu.read(cpu.Registers.HL())))
+	case 0x1F:
+		cpu.Registers.A = cpu.rr(cpu.Registers.A)
+	case 0x20:
+		cpu.Registers.B = cpu.sla(cpu.Registers.B)
+	case 0x21:
+		cpu.Registers.C = cpu.sla(cpu.Registers.C)
+	case 0x22:
+		cpu.Registers.D = cpu.sla(cpu.Registers.D)
+	case 0x23:
+		cpu.Registers.E = cpu.sla(cpu.Registers.E)
+	case 0x24:
+		cpu.Registers.H = cpu.sla(cpu.Registers.H)
+	case 0x25:
+		cpu.Registers.L = cpu.sla(cpu.Registers.L)
+	case 0x26:
+		cpu.write(cpu.Registers.HL(), cpu.sla(cpu.read(cpu.Registers.HL())))
+	case 0x27:
+		cpu.Registers.A = cpu.sla(cpu.Registers.A)
+	case 0x28:
+		cpu.Registers.B = cpu.sra(cpu.Registers.B)
+	case 0x29:
+		cpu.Registers.C = cpu.sra(cpu.Registers.C)
+	case 0x2A:
+		cpu.Registers.D = cpu.sra(cpu.Registers.D)
+	case 0x2B:
+		cpu.Registers.E = cpu.sra(cpu.Registers.E)
+	case 0x2C:
+		cpu.Registers.H = cpu.sra(cpu.Registers.H)
+	case 0x2D:
+		cpu.Registers.L = cpu.sra(cpu.Registers.L)
+	case 0x2E:
+		cpu.write(cpu.Registers.HL(), cpu.sra(cpu.read(cpu.Registers.HL())))
+	case 0x2F:
+		cpu.Registers.A = cpu.sra(cpu.Registers.A)
+	case 0x30:
+		cpu.Registers.B = cpu.swap(cpu.Registers.B)
+	case 0x31:
+		cpu.Registers.C = cpu.swap(cpu.Registers.C)
+	case 0x32:
+		cpu.Registers.D = cpu.swap(cpu.Registers.D)
+	case 0x33:
+		cpu.Registers.E = cpu.swap(cpu.Registers.E)
+	case 0x34:
+		cpu.Registers.H = cpu.swap(cpu.Registers.H)
+	case 0x35:
+		cpu.Registers.L = cpu.swap(cpu.Registers.L)
+	case 0x36:
+		cpu.write(cpu.Registers.HL(), cpu.swap(cpu.read(cpu.Registers.HL())))
+	case 0x37:
+		cpu.Registers.A = cpu.swap(cpu.Registers.A)
+	case 0x38:
+		cpu.Registers.B = cpu.srl(cpu.Registers.B)
+	case 0x39:
+		cpu.Registers.C = cpu.srl(cpu.Registers.C)
+	case 0x3A:
+		cpu.Registers.D = cpu.srl(cpu.Registers.D)
+	case 0x3B:
+		cpu.Registers.E = cpu.srl(cpu.Registers.E)
+	case 0x3C:
+		cpu.Registers.H = cpu.srl(cpu.Registers.H)
+	case 0x3D:
+		cpu.Registers.L = cpu.srl(cpu.Registers.L)
+	case 0x3E:
+		cpu.write(cpu.Registers.HL(), cpu.srl(cpu.read(cpu.Registers.HL())))
+	case 0x3F:
+		cpu.Registers.A = cpu.srl(cpu.Registers.A)
+	case 0x40:
+		cpu.testBit(cpu.Registers.B, 0x01)
+	case 0x41:
+		cpu.testBit(cpu.Registers.C, 0x01)
+	case 0x42:
+		cpu.testBit(cpu.Registers.D, 0x01)
+	case 0x43:
+		cpu.testBit(cpu.Registers.E, 0x01)
+	case 0x44:
+		cpu.testBit(cpu.Registers.H, 0x01)
+	case 0x45:
+		cpu.testBit(cpu.Registers.L, 0x01)
+	case 0x46:
+		cpu.testBit(cpu.read(cpu.Registers.HL()), 0x01)
+	case 0x47:
+		cpu.testBit(cpu.Registers.A, 0x01)
+	case 0x48:
+		cpu.testBit(cpu.Registers.B, 0x02)
+	case 0x49:
+		cpu.testBit(cpu.Registers.C, 0x02)
+	case 0x4A:
+		cpu.testBit(cpu.Registers.D, 0x02)
+	case 0x4B:
+		cpu.testBit(cpu.Registers.E, 0x02)
+	case 0x4C:
+		cpu.testBit(cpu.Registers.H, 0x02)
+	case 0x4D:
+		cpu.testBit(cpu.Registers.L, 0x02)
+	case 0x4E:
+		cpu.testBit(cpu.read(cpu.Registers.HL()), 0x02)
+	case 0x4F:
+		cpu.testBit(cpu.Registers.A, 0x02)
+	case 0x50:
+		cpu.testBit(cpu.Registers.B, 0x04)
+	case 0x51:
+		cpu.testBit(cpu.Registers.C, 0x04)
+	case 0x52:
+		cpu.testBit(cpu.Registers.D, 0x04)
+	case 0x53:
+		cpu.testBit(cpu.Registers.E, 0x04)
+	case 0x54:
+		cpu.testBit(cpu.Registers.H, 0x04)
+	case 0x55:
+		cpu.testBit(cpu.Registers.L, 0x04)
+	case 0x56:
+		cpu.testBit(cpu.read(cpu.Registers.HL()), 0x04)
+	case 0x57:
+		cpu.testBit(cpu.Registers.A, 0x04)
+	case 0x58:
+		cpu.testBit(cpu.Registers.B, 0x08)
+	case 0x59:
+		cpu.testBit(cpu.Registers.C, 0x08)
+	case 0x5A:
+		cpu.testBit(cpu.Registers.D, 0x08)
+	case 0x5B:
+		cpu.testBit(cpu.Registers.E, 0x08)
+	case 0x5C:
+		cpu.testBit(cpu.Registers.H, 0x08)
+	case 0x5D:
+		cpu.testBit(cpu.Registers.L, 0x08)
+	case 0x5E:
+		cpu.testBit(cpu.read(cpu.Registers.HL()), 0x08)
+	case 0x5F:
+		cpu.testBit(cpu.Registers.A, 0x08)
+	case 0x60:
+		cpu.testBit(cpu.Registers.B, 0x10)
+	case 0x61:
+		cpu.testBit(cpu.Registers.C, 0x10)
+	case 0x62:
+		cpu.testBit(cpu.Registers.D, 0x10)
+	case 0x63:
+		cpu.testBit(cpu.Registers.E, 0x10)
+	case 0x64:
+		cpu.testBit(cpu.Registers.H, 0x10)
+	case 0x65:
+		cpu.testBit(cpu.Registers.L, 0x10)
+	case 0x66:
+		cpu.testBit(cpu.read(cpu.Registers.HL()), 0x10)
+	case 0x67:
+		cpu.testBit(cpu.Registers.A, 0x10)
+	case 0x68:
+		cpu.testBit(cpu.Registers.B, 0x20)
+	case 0x69:
+		cpu.testBit(cpu.Registers.C, 0x20)
+	case 0x6A:
+		cpu.testBit(cpu.Registers.D, 0x20)
+	case 0x6B:
+		cpu.testBit(cpu.Registers.E, 0x20)
+	case 0x6C:
+		cpu.testBit(cpu.Registers.H, 0x20)
+	case 0x6D:
+		cpu.testBit(cpu.Registers.L, 0x20)
+	case 0x6E:
+		cpu.testBit(cpu.read(cpu.Registers.HL()), 0x20)
+	case 0x6F:
+		cpu.testBit(cpu.Registers.A, 0x20)
+	case 0x70:
+		cpu.testBit(cpu.Registers.B, 0x40)
+	case 0x71:
+		cpu.testBit(cpu.Registers.C, 0x40)
+	case 0x72:
+		cpu.testBit(cpu.Registers.D, 0x40)
+	case 0x73:
+		cpu.testBit(cpu.Registers.E, 0x40)
+	case 0x74:
+		cpu.testBit(cpu.Registers.H, 0x40)
+	case 0x75:
+		cpu.testBit(cpu.Registers.L, 0x40)
+	case 0x76:
+		cpu.testBit(cpu.read(cpu.Registers.HL()), 0x40)
+	case 0x77:
+		cpu.testBit(cpu.Registers.A, 0x40)
+	case 0x78:
+		cpu.testBit(cpu.Registers.B, 0x80)
+	case 0x79:
+		cpu.testBit(cpu.Registers.C, 0x80)
+	case 0x7A:
+		cpu.testBit(cpu.Registers.D, 0x80)
+	case 0x7B:
+		cpu.testBit(cpu.Registers.E, 0x80)
+	case 0x7C:
+		cpu.testBit(cpu.Registers.H, 0x80)
+	case 0x7D:
+		cpu.testBit(cpu.Registers.L, 0x80)
+	case 0x7E:
+		cpu.testBit(cpu.read(cpu.Registers.HL()), 0x80)
+	case 0x7F:
+		cpu.testBit(cpu.Registers.A, 0x80)
+	case 0x80:
+		cpu.Registers.B &^= 0x01
+	case 0x81:
+		cpu.Registers.C &^= 0x01
+	case 0x82:
+		cpu.Registers.D &^= 0x01
+	case 0x83:
+		cpu.Registers.E &^= 0x01
+	case 0x84:
+		cpu.Registers.H &^= 0x01
+	case 0x85:
+		cpu.Registers.L &^= 0x01
+	case 0x86:
+		cpu.write(cpu.Registers.HL(), cpu.read(cpu.Registers.HL())&^0x01)
+	case 0x87:
+		cpu.Registers.A &^= 0x01
+	case 0x88:
+		cpu.Registers.B &^= 0x02
+	case 0x89:
+		cpu.Registers.C &^= 0x02
+	case 0x8A:
+		cpu.Registers.D &^= 0x02
+	case 0x8B:
+		cpu.Registers.E &^= 0x02
+	case 0x8C:
+		cpu.Registers.H &^= 0x02
+	case 0x8D:
+		cpu.Registers.L &^= 0x02
+	case 0x8E:
+		cpu.write(cpu.Registers.HL(), cpu.read(cpu.Registers.HL())&^0x02)
+	case 0x8F:
+		cpu.Registers.A &^= 0x02
+	case 0x90:
+		cpu.Registers.B &^= 0x04
+	case 0x91:
+		cpu.Registers.C &^= 0x04
+	case 0x92:
+		cpu.Registers.D &^= 0x04
+	case 0x93:
+		cpu.Registers.E &^= 0x04
+	case 0x94:
+		cpu.Registers.H &^= 0x04
+	case 0x95:
+		cpu.Registers.L &^= 0x04
+	case 0x96:
+		cpu.write(cpu.Registers.HL(), cpu.read(cpu.Registers.HL())&^0x04)
+	case 0x97:
+		cpu.Registers.A &^= 0x04
+	case 0x98:
+		cpu.Registers.B &^= 0x08
+	case 0x99:
+		cpu.Registers.C &^= 0x08
+	case 0x9A:
+		cpu.Registers.D &^= 0x08
+	case 0x9B:
+		cpu.Registers.E &^= 0x08
+	case 0x9C:
+		cpu.Registers.H &^= 0x08
+	case 0x9D:
+		cpu.Registers.L &^= 0x08
+	case 0x9E:
+		cpu.write(cpu.Registers.HL(), cpu.read(cpu.Registers.HL())&^0x08)
+	case 0x9F:
+		cpu.Registers.A &^= 0x08
+	case 0xA0:
+		cpu.Registers.B &^= 0x10
+	case 0xA1:
+		cpu.Registers.C &^= 0x10
+	case 0xA2:
+		cpu.Registers.D &^= 0x10
+	case 0xA3:
+		cpu.Registers.E &^= 0x10
+	case 0xA4:
+		cpu.Registers.H &^= 0x10
+	case 0xA5:
+		cpu.Registers.L &^= 0x10
+	case 0xA6:
+		cpu.write(cpu.Registers.HL(), cpu.read(cpu.Registers.HL())&^0x10)
+	case 0xA7:
+		cpu.Registers.A &^= 0x10
+	case 0xA8:
+		cpu.Registers.B &^= 0x20
+	case 0xA9:
+		cpu.Registers.C &^= 0x20
+	case 0xAA:
+		cpu.Registers.D &^= 0x20
+	case 0xAB:
+		cpu.Registers.E &^= 0x20
+	case 0xAC:
+		cpu.Registers.H &^= 0x20
+	case 0xAD:
+		cpu.Registers.L &^= 0x20
+	case 0xAE:
+		cpu.write(cpu.Registers.HL(), cpu.read(cpu.Registers.HL())&^0x20)
+	case 0xAF:
+		cpu.Registers.A &^= 0x20
+	case 0xB0:
+		cpu.Registers.B &^= 0x40
+	case 0xB1:
+		cpu.Registers.C &^= 0x40
+	case 0xB2:
+		cpu.Registers.D &^= 0x40
+	case 0xB3:
+		cpu.Registers.E &^= 0x40
+	case 0xB4:
+		cpu.Registers.H &^= 0x40
+	case 0xB5:
+		cpu.Registers.L &^= 0x40
+	case 0xB6:
+		cpu.write(cpu.Registers.HL(), cpu.read(cpu.Registers.HL())&^0x40)
+	case 0xB7:
+		cpu.Registers.A &^= 0x40
+	case 0xB8:
+		cpu.Registers.B &^= 0x80
+	case 0xB9:
+		cpu.Registers.C &^= 0x80
+	case 0xBA:
+		cpu.Registers.D &^= 0x80
+	case 0xBB:
+		cpu.Registers.E &^= 0x80
+	case 0xBC:
+		cpu.Registers.H &^= 0x80
+	case 0xBD:
+		cpu.Registers.L &^= 0x80
+	case 0xBE:
+		cpu.write(cpu.Registers.HL(), cpu.read(cpu.Registers.HL())&^0x80)
+	case 0xBF:
+		cpu.Registers.A &^= 0x80
+	case 0xC0:
+		cpu.Registers.B |= 0x01
+	case 0xC1:
+		cpu.Registers.C |= 0x01
+	case 0xC2:
+		cpu.Registers.D |= 0x01
+	case 0xC3:
+		cpu.Registers.E |= 0x01
+	case 0xC4:
+		cpu.Registers.H |= 0x01
+	case 0xC5:
+		cpu.Registers.L |= 0x01
+	case 0xC6:
+		cpu.write(cpu.Registers.HL(), cpu.read(cpu.Registers.HL())|0x01)
+	case 0xC7:
+		cpu.Registers.A |= 0x01
+	case 0xC8:
+		cpu.Registers.B |= 0x02
+	case 0xC9:
+		cpu.Registers.C |= 0x02
+	case 0xCA:
+		cpu.Registers.D |= 0x02
+	case 0xCB:
+		cpu.Registers.E |= 0x02
+	case 0xCC:
+		cpu.Registers.H |= 0x02
+	case 0xCD:
+		cpu.Registers.L |= 0x02
+	case 0xCE:
+		cpu.write(cpu.Registers.HL(), cpu.read(cpu.Registers.HL())|0x02)
+	case 0xCF:
+		cpu.Registers.A |= 0x02
+	case 0xD0:
+		cpu.Registers.B |= 0x04
+	case 0xD1:
+		cpu.Registers.C |= 0x04
+	case 0xD2:
+		cpu.Registers.D |= 0x04
+	case 0xD3:
+		cpu.Registers.E |= 0x04
+	case 0xD4:
+		cpu.Registers.H |= 0x04
+	case 0xD5:
+		cpu.Registers.L |= 0x04
+	case 0xD6:
+		cpu.write(cpu.Registers.HL(), cpu.read(cpu.Registers.HL())|0x04)
+	case 0xD7:
+		cpu.Registers.A |= 0x04
+	case 0xD8:
+		cpu.Registers.B |= 0x08
+	case 0xD9:
+		cpu.Registers.C |= 0x08
+	case 0xDA:
+		cpu.Registers.D |= 0x08
+	case 0xDB:
+		cpu.Registers.E |= 0x08
+	case 0xDC:
+		cpu.Registers.H |= 0x08
+	case 0xDD:
+		cpu.Registers.L |= 0x08
+	case 0xDE:
+		cpu.write(cpu.Registers.HL(), cpu.read(cpu.Registers.HL())|0x08)
+	case 0xDF:
+		cpu.Registers.A |= 0x08
+	case 0xE0:
+		cpu.Registers.B |= 0x10
+	case 0xE1:
+		cpu.Registers.C |= 0x10
+	case 0xE2:
+		cpu.Registers.D |= 0x10
+	case 0xE3:
+		cpu.Registers.E |= 0x10
+	case 0xE4:
+		cpu.Registers.H |= 0x10
+	case 0xE5:
+		cpu.Registers.L |= 0x10
+	case 0xE6:
+		cpu.write(cpu.Registers.HL(), cpu.read(cpu.Registers.HL())|0x10)
+	case 0xE7:
+		cpu.Registers.A |= 0x10
+	case 0xE8:
+		cpu.Registers.B |= 0x20
+	case 0xE9:
+		cpu.Registers.C |= 0x20
+	case 0xEA:
+		cpu.Registers.D |= 0x20
+	case 0xEB:
+		cpu.Registers.E |= 0x20
+	case 0xEC:
+		cpu.Registers.H |= 0x20
+	case 0xED:
+		cpu.Registers.L |= 0x20
+	case 0xEE:
+		cpu.write(cpu.Registers.HL(), cpu.read(cpu.Registers.HL())|0x20)
+	case 0xEF:
+		cpu.Registers.A |= 0x20
+	case 0xF0:
+		cpu.Registers.B |= 0x40
+	case 0xF1:
+		cpu.Registers.C |= 0x40
+	case 0xF2:
+		cpu.Registers.D |= 0x40
+	case 0xF3:
+		cpu.Registers.E |= 0x40
+	case 0xF4:
+		cpu.Registers.H |= 0x40
+	case 0xF5:
+		cpu.Registers.L |= 0x40
+	case 0xF6:
+		cpu.write(cpu.Registers.HL(), cpu.read(cpu.Registers.HL())|0x40)
+	case 0xF7:
+		cpu.Registers.A |= 0x40
+	case 0xF8:
+		cpu.Registers.B |= 0x80
+	case 0xF9:
+		cpu.Registers.C |= 0x80
+	case 0xFA:
+		cpu.Registers.D |= 0x80
+	case 0xFB:
+		cpu.Registers.E |= 0x80
+	case 0xFC:
+		cpu.Registers.H |= 0x80
+	case 0xFD:
+		cpu.Registers.L |= 0x80
+	case 0xFE:
+		cpu.write(cpu.Registers.HL(), cpu.read(cpu.Registers.HL())|0x80)
+	case 0xFF:
+		cpu.Registers.A |= 0x80
+	}
+}
@@ -0,0 +1,230 @@
+package processor
+
+// takeBranch records that a conditional instruction's condition held.
+//
+// Conditional instructions carry two cycle counts: Cycles when the
+// branch is NOT taken and CyclesTaken when it is. Their Execute calls
+// takeBranch when the condition holds, so Step charges CyclesTaken.
+func (cpu *CPU) takeBranch() {
+	cpu.branchTaken = true
+}
+
+// ============================================================
+// 0xCD: CALL nn - Call subroutine
+// ============================================================
+// Pushes the address of the next instruction (the return
+// address) onto the stack, then jumps to nn. A later RET pops
+// the return address back into PC.
+//
+// Example:
+//
+//	0x0100: CALL 0x0200  ->  pushes 0x0103, PC = 0x0200
+//
+// Flags: None affected
+// Cycles: 24
+// Bytes: 3
+func opCALL_nn(cpu *CPU) {
+	addr := cpu.fetchWord() // PC now points past the operand
+	cpu.pushWord(cpu.Registers.PC)
+	cpu.pushCall(cpu.instructionPC, addr, false)
+	cpu.Registers.PC = addr
+}
+
+// ============================================================
+// CALL cc, nn - Conditional call
+// ============================================================
+// Like CALL nn, but only if the condition holds:
+//
+//	NZ: Z flag is 0     Z: Z flag is 1
+//	NC: C flag is 0     C: C flag is 1
+//
+// The address operand is always read (so PC always skips it),
+// but the push and jump only happen when the call is taken.
+//
+// Flags: None affected
+// Cycles: 24 if taken, 12 if not
+// Bytes: 3
+
+// callIf performs a conditional call. Taking the call costs the
+// 12 extra cycles of the push.
+func (cpu *CPU) callIf(condition bool) {
+	addr := cpu.fetchWord()
+	if condition {
+		cpu.pushWord(cpu.Registers.PC)
+		cpu.pushCall(cpu.instructionPC, addr, false)
+		cpu.Registers.PC = addr
+		cpu.takeBranch()
+	}
+}
+
+func opCALL_NZ_nn(cpu *CPU) { cpu.callIf(!cpu.Registers.GetFlagZ()) }
+func opCALL_Z_nn(cpu *CPU)  { cpu.callIf(cpu.Registers.GetFlagZ()) }
+func opCALL_NC_nn(cpu *CPU) { cpu.callIf(!cpu.Registers.GetFlagC()) }
+func opCALL_C_nn(cpu *CPU)  { cpu.callIf(cpu.Registers.GetFlagC()) }
+
+// ============================================================
+// 0xC9: RET - Return from subroutine
+// ============================================================
+// Pops the return address pushed by CALL back into PC, resuming
+// execution right after the CALL.
+//
+// Flags: None affected
+// Cycles: 16
+// Bytes: 1
+func opRET(cpu *CPU) {
+	cpu.Registers.PC = cpu.popWord()
+	cpu.popCall()
+}
+
+// ============================================================
+// 0xD9: RETI - Return from interrupt
+// ============================================================
+// Same as RET, but also re-enables interrupts. Interrupt
+// handlers end with RETI so that the next interrupt can be
+// serviced as soon as the handler is done. Unlike EI, IME is
+// set immediately, with no one-instruction delay.
+//
+// Flags: None affected
+// Cycles: 16
+// Bytes: 1
+func opRETI(cpu *CPU) {
+	cpu.Registers.PC = cpu.popWord()
+	cpu.popCall()
+	cpu.IME = true
+}
+
+// ============================================================
+// RET cc - Conditional return
+// ============================================================
+// Returns only if the condition holds (NZ, Z, NC, C - see
+// CALL cc). Checking the condition costs an extra cycle compared
+// to a plain RET, so a taken RET cc is slower than RET.
+//
+// Flags: None affected
+// Cycles: 20 if taken, 8 if not
+// Bytes: 1
+
+// retIf performs a conditional return. Taking the return costs the
+// 12 extra cycles of the pop.
+func (cpu *CPU) retIf(condition bool) {
+	cpu.idle() // Checking the condition takes an internal M-cycle
+	if condition {
+		cpu.Registers.PC = cpu.popWord()
+		cpu.popCall()
+		cpu.takeBranch()
+	}
+}
+
+func opRET_NZ(cpu *CPU) { cpu.retIf(!cpu.Registers.GetFlagZ()) }
+func opRET_Z(cpu *CPU)  { cpu.retIf(cpu.Registers.GetFlagZ()) }
+func opRET_NC(cpu *CPU) { cpu.retIf(!cpu.Registers.GetFlagC()) }
+func opRET_C(cpu *CPU)  { cpu.retIf(cpu.Registers.GetFlagC()) }
+
+// ============================================================
+// RST n - Restart (call a fixed vector)
+// ============================================================
+// A one-byte CALL to one of eight fixed addresses at the start
+// of ROM: 0x00, 0x08, 0x10, ... 0x38. Games put small, frequently
+// called routines there because RST is shorter and faster than
+// CALL nn.
+//
+// The vector is encoded in bits 3-5 of the opcode itself:
+// 0xC7 | (n << 3) -> RST n*8.
+//
+// Example:
+//
+//	0x0150: RST 28H  ->  pushes 0x0151, PC = 0x0028
+//
+// Flags: None affected
+// Cycles: 16
+// Bytes: 1
+
+// rst pushes the return address and jumps to vector.
+func (cpu *CPU) rst(vector uint16) {
+	cpu.pushWord(cpu.Registers.PC)
+	cpu.pushCall(cpu.instructionPC, vector, false)
+	cpu.Registers.PC = vector
+}
+
+func opRST_00(cpu *CPU) { cpu.rst(0x00) }
+func opRST_08(cpu *CPU) { cpu.rst(0x08) }
+func opRST_10(cpu *CPU) { cpu.rst(0x10) }
+func opRST_18(cpu *CPU) { cpu.rst(0x18) }
+func opRST_20(cpu *CPU) { cpu.rst(0x20) }
+func opRST_28(cpu *CPU) { cpu.rst(0x28) }
+func opRST_30(cpu *CPU) { cpu.rst(0x30) }
+func opRST_38(cpu *CPU) { cpu.rst(0x38) }
+
+// ============================================================
+// 0x18: JR e8 - Relative jump
+// ============================================================
+// Adds a signed 8-bit offset (-128..+127) to PC. The offset is
+// relative to the address AFTER the JR instruction, so an offset
+// of 0 does nothing and 0xFE (-2) jumps back onto the JR itself
+// (the classic "JR @" infinite loop).
+//
+// JR is one byte shorter than JP nn, so it is the usual way to
+// write loops and short branches.
+//
+// Example:
+//
+//	0x0100: JR 0x05  ->  PC = 0x0102 + 5 = 0x0107
+//	0x0100: JR 0xFB  ->  PC = 0x0102 - 5 = 0x00FD
+//
+// Flags: None affected
+// Cycles: 12
+// Bytes: 2
+func opJR_e8(cpu *CPU) {
+	offset := int8(cpu.fetchByte())
+	cpu.Registers.PC += uint16(offset)
+}
+
+// ============================================================
+// JR cc, e8 - Conditional relative jump
+// ============================================================
+// Like JR e8, but only if the condition holds (NZ, Z, NC, C -
+// see CALL cc). The offset is always read.
+//
+// Flags: None affected
+// Cycles: 12 if taken, 8 if not
+// Bytes: 2
+
+// jrIf performs a conditional relative jump. Taking the jump costs
+// 4 extra cycles.
+func (cpu *CPU) jrIf(condition bool) {
+	offset := int8(cpu.fetchByte())
+	if condition {
+		cpu.Registers.PC += uint16(offset)
+		cpu.takeBranch()
+	}
+}
+
+func opJR_NZ_e8(cpu *CPU) { cpu.jrIf(!cpu.Registers.GetFlagZ()) }
+func opJR_Z_e8(cpu *CPU)  { cpu.jrIf(cpu.Registers.GetFlagZ()) }
+func opJR_NC_e8(cpu *CPU) { cpu.jrIf(!cpu.Registers.GetFlagC()) }
+func opJR_C_e8(cpu *CPU)  { cpu.jrIf(cpu.Registers.GetFlagC()) }
+
+// ============================================================
+// JP cc, nn - Conditional absolute jump
+// ============================================================
+// Like JP nn, but only if the condition holds (NZ, Z, NC, C -
+// see CALL cc). The address is always read.
+//
+// Flags: None affected
+// Cycles: 16 if taken, 12 if not
+// Bytes: 3
+
+// jpIf performs a conditional absolute jump. Taking the jump costs
+// 4 extra cycles.
+func (cpu *CPU) jpIf(condition bool) {
+	addr := cpu.fetchWord()
+	if condition {
+		cpu.Registers.PC = addr
+		cpu.takeBranch()
+	}
+}
+
+func opJP_NZ_nn(cpu *CPU) { cpu.jpIf(!cpu.Registers.GetFlagZ()) }
+func opJP_Z_nn(cpu *CPU)  { cpu.jpIf(cpu.Registers.GetFlagZ()) }
+func opJP_NC_nn(cpu *CPU) { cpu.jpIf(!cpu.Registers.GetFlagC()) }
+func opJP_C_nn(cpu *CPU)  { cpu.jpIf(cpu.Registers.GetFlagC()) }
@@ -0,0 +1,375 @@
+package processor
+
+import "testing"
+
+// setupCPUAt creates a CPU whose program starts at addr instead of 0.
+// The bytes before addr are left as zeros (NOPs).
+func setupCPUAt(addr uint16, program []byte) *CPU {
+	rom := make([]byte, int(addr)+len(program))
+	copy(rom[addr:], program)
+	cpu := setupCPU(rom)
+	cpu.Registers.PC = addr
+	return cpu
+}
+
+func TestOpCALL_nn(t *testing.T) {
+	// Program at 0x0100: CALL 0x0234
+	cpu := setupCPUAt(0x0100, []byte{0xCD, 0x34, 0x02})
+	cpu.Registers.SP = 0xD000
+
+	cycles := step(t, cpu)
+
+	if cycles != 24 {
+		t.Errorf("Expected 24 cycles, got %d", cycles)
+	}
+	if cpu.Registers.PC != 0x0234 {
+		t.Errorf("Expected PC=0x0234, got PC=0x%04X", cpu.Registers.PC)
+	}
+	if cpu.Registers.SP != 0xCFFE {
+		t.Errorf("Expected SP=0xCFFE, got SP=0x%04X", cpu.Registers.SP)
+	}
+	// Return address is the instruction after the CALL: 0x0103
+	if ret := cpu.popWord(); ret != 0x0103 {
+		t.Errorf("Expected return address 0x0103, got 0x%04X", ret)
+	}
+}
+
+func TestOpCALL_cc_nn(t *testing.T) {
+	tests := []struct {
+		name   string
+		opcode uint8
+		flags  uint8
+		taken  bool
+	}{
+		{"NZ taken", 0xC4, 0x00, true},
+		{"NZ not taken", 0xC4, FlagZ, false},
+		{"Z taken", 0xCC, FlagZ, true},
+		{"Z not taken", 0xCC, 0x00, false},
+		{"NC taken", 0xD4, FlagZ, true},
+		{"NC not taken", 0xD4, FlagC, false},
+		{"C taken", 0xDC, FlagC, true},
+		{"C not taken", 0xDC, FlagZ, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu := setupCPUAt(0x0100, []byte{tt.opcode, 0x00, 0x02})
+			cpu.Registers.SP = 0xD000
+			cpu.Registers.F = tt.flags
+
+			cycles := step(t, cpu)
+
+			wantCycles, wantPC, wantSP := 12, uint16(0x0103), uint16(0xD000)
+			if tt.taken {
+				wantCycles, wantPC, wantSP = 24, 0x0200, 0xCFFE
+			}
+			if cycles != wantCycles {
+				t.Errorf("Expected %d cycles, got %d", wantCycles, cycles)
+			}
+			if cpu.Registers.PC != wantPC {
+				t.Errorf("Expected PC=0x%04X, got PC=0x%04X", wantPC, cpu.Registers.PC)
+			}
+			if cpu.Registers.SP != wantSP {
+				t.Errorf("Expected SP=0x%04X, got SP=0x%04X", wantSP, cpu.Registers.SP)
+			}
+			if cpu.Registers.F != tt.flags {
+				t.Errorf("Flags should be unchanged, got F=0x%02X", cpu.Registers.F)
+			}
+		})
+	}
+}
+
+func TestTakenBranchCyclesDontLeak(t *testing.T) {
+	// A taken CALL's extra cycles must not carry over to the next instruction
+	cpu := setupCPUAt(0x0100, []byte{0xCC, 0x03, 0x01, 0x00}) // CALL Z, 0x0103; NOP
+	cpu.Registers.SetFlagZ(true)
+
+	cpu.Step() // CALL Z (taken)
+	if cycles := step(t, cpu); cycles != 4 {
+		t.Errorf("NOP after a taken CALL: expected 4 cycles, got %d", cycles)
+	}
+	if cpu.TotalCycles != 28 {
+		t.Errorf("Expected 28 total cycles, got %d", cpu.TotalCycles)
+	}
+}
+
+func TestOpRET(t *testing.T) {
+	for _, opcode := range []uint8{0xC9, 0xD9} { // RET, RETI
+		t.Run(opcodeTable[opcode].Mnemonic, func(t *testing.T) {
+			cpu := setupCPU([]byte{opcode})
+			cpu.Registers.SP = 0xCFFE
+			cpu.Memory.Write(0xCFFE, 0x03) // Return address 0x0103
+			cpu.Memory.Write(0xCFFF, 0x01)
+
+			cycles := step(t, cpu)
+
+			if cycles != 16 {
+				t.Errorf("Expected 16 cycles, got %d", cycles)
+			}
+			if cpu.Registers.PC != 0x0103 {
+				t.Errorf("Expected PC=0x0103, got PC=0x%04X", cpu.Registers.PC)
+			}
+			if cpu.Registers.SP != 0xD000 {
+				t.Errorf("Expected SP=0xD000, got SP=0x%04X", cpu.Registers.SP)
+			}
+		})
+	}
+}
+
+func TestOpRET_cc(t *testing.T) {
+	tests := []struct {
+		name   string
+		opcode uint8
+		flags  uint8
+		taken  bool
+	}{
+		{"NZ taken", 0xC0, 0x00, true},
+		{"NZ not taken", 0xC0, FlagZ, false},
+		{"Z taken", 0xC8, FlagZ, true},
+		{"Z not taken", 0xC8, FlagC, false},
+		{"NC taken", 0xD0, 0x00, true},
+		{"NC not taken", 0xD0, FlagC, false},
+		{"C taken", 0xD8, FlagC | FlagZ, true},
+		{"C not taken", 0xD8, 0x00, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu := setupCPU([]byte{tt.opcode})
+			cpu.Registers.SP = 0xCFFE
+			cpu.Memory.Write(0xCFFE, 0x00) // Return address 0x0200
+			cpu.Memory.Write(0xCFFF, 0x02)
+			cpu.Registers.F = tt.flags
+
+			cycles := step(t, cpu)
+
+			wantCycles, wantPC, wantSP := 8, uint16(0x0001), uint16(0xCFFE)
+			if tt.taken {
+				wantCycles, wantPC, wantSP = 20, 0x0200, 0xD000
+			}
+			if cycles != wantCycles {
+				t.Errorf("Expected %d cycles, got %d", wantCycles, cycles)
+			}
+			if cpu.Registers.PC != wantPC {
+				t.Errorf("Expected PC=0x%04X, got PC=0x%04X", wantPC, cpu.Registers.PC)
+			}
+			if cpu.Registers.SP != wantSP {
+				t.Errorf("Expected SP=0x%04X, got SP=0x%04X", wantSP, cpu.Registers.SP)
+			}
+		})
+	}
+}
+
+func TestCallRetRoundTrip(t *testing.T) {
+	// 0x0000: CALL 0x0010
+	// 0x0003: LD A, 0x22
+	// 0x0010: LD A, 0x11
+	// 0x0012: RET
+	rom := make([]byte, 0x20)
+	copy(rom[0x00:], []byte{0xCD, 0x10, 0x00, 0x3E, 0x22})
+	copy(rom[0x10:], []byte{0x3E, 0x11, 0xC9})
+	cpu := setupCPU(rom)
+	sp := cpu.Registers.SP
+
+	cpu.Step() // CALL 0x0010
+	cpu.Step() // LD A, 0x11
+	if cpu.Registers.A != 0x11 {
+		t.Fatalf("Subroutine didn't run: A=0x%02X", cpu.Registers.A)
+	}
+	cpu.Step() // RET
+	if cpu.Registers.PC != 0x0003 || cpu.Registers.SP != sp {
+		t.Fatalf("Expected PC=0x0003 SP=0x%04X after RET, got PC=0x%04X SP=0x%04X", sp, cpu.Registers.PC, cpu.Registers.SP)
+	}
+	cpu.Step() // LD A, 0x22
+	if cpu.Registers.A != 0x22 {
+		t.Errorf("Expected A=0x22 after returning, got A=0x%02X", cpu.Registers.A)
+	}
+}
+
+func TestOpRST(t *testing.T) {
+	for n := range 8 {
+		opcode := uint8(0xC7 | n<<3)
+		vector := uint16(n * 8)
+
+		t.Run(opcodeTable[opcode].Mnemonic, func(t *testing.T) {
+			cpu := setupCPUAt(0x0150, []byte{opcode})
+			cpu.Registers.SP = 0xD000
+
+			cycles := step(t, cpu)
+
+			if cycles != 16 {
+				t.Errorf("Expected 16 cycles, got %d", cycles)
+			}
+			if cpu.Registers.PC != vector {
+				t.Errorf("Expected PC=0x%04X, got PC=0x%04X", vector, cpu.Registers.PC)
+			}
+			if ret := cpu.popWord(); ret != 0x0151 {
+				t.Errorf("Expected return address 0x0151, got 0x%04X", ret)
+			}
+		})
+	}
+}
+
+func TestOpJR_e8(t *testing.T) {
+	tests := []struct {
+		name   string
+		offset uint8
+		pc     uint16
+	}{
+		{"forward", 0x05, 0x0107},
+		{"zero offset", 0x00, 0x0102},
+		{"backward", 0xFB, 0x00FD},
+		{"onto itself", 0xFE, 0x0100},
+		{"max forward", 0x7F, 0x0181},
+		{"max backward", 0x80, 0x0082},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu := setupCPUAt(0x0100, []byte{0x18, tt.offset})
+			cpu.Registers.F = 0xF0
+
+			cycles := step(t, cpu)
+
+			if cycles != 12 {
+				t.Errorf("Expected 12 cycles, got %d", cycles)
+			}
+			if cpu.Registers.PC != tt.pc {
+				t.Errorf("Expected PC=0x%04X, got PC=0x%04X", tt.pc, cpu.Registers.PC)
+			}
+			if cpu.Registers.F != 0xF0 {
+				t.Errorf("Flags should be unchanged, got F=0x%02X", cpu.Registers.F)
+			}
+		})
+	}
+}
+
+func TestOpJR_cc_e8(t *testing.T) {
+	tests := []struct {
+		name   string
+		opcode uint8
+		flags  uint8
+		taken  bool
+	}{
+		{"NZ taken", 0x20, FlagC, true},
+		{"NZ not taken", 0x20, FlagZ, false},
+		{"Z taken", 0x28, FlagZ, true},
+		{"Z not taken", 0x28, 0x00, false},
+		{"NC taken", 0x30, 0x00, true},
+		{"NC not taken", 0x30, FlagC, false},
+		{"C taken", 0x38, FlagC, true},
+		{"C not taken", 0x38, FlagZ, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Jump backward by 4 so a taken branch can't be mistaken for PC+2
+			cpu := setupCPUAt(0x0100, []byte{tt.opcode, 0xFC})
+			cpu.Registers.F = tt.flags
+
+			cycles := step(t, cpu)
+
+			wantCycles, wantPC := 8, uint16(0x0102)
+			if tt.taken {
+				wantCycles, wantPC = 12, 0x00FE
+			}
+			if cycles != wantCycles {
+				t.Errorf("Expected %d cycles, got %d", wantCycles, cycles)
+			}
+			if cpu.Registers.PC != wantPC {
+				t.Errorf("Expected PC=0x%04X, got PC=0x%04X", wantPC, cpu.Registers.PC)
+			}
+		})
+	}
+}
+
+func TestJRCountdownLoop(t *testing.T) {
+	// 0x0000: LD B, 3
+	// 0x0002: DEC B
+	// 0x0003: JR NZ, -3 (back to DEC B)
+	// 0x0005: NOP
+	cpu := setupCPU([]byte{0x06, 0x03, 0x05, 0x20, 0xFD, 0x00})
+
+	steps := 0
+	for cpu.Registers.PC != 0x0005 && steps < 100 {
+		cpu.Step()
+		steps++
+	}
+
+	if cpu.Registers.B != 0 {
+		t.Errorf("Expected B=0 after the loop, got B=%d", cpu.Registers.B)
+	}
+	// LD + 3 x (DEC + JR)
+	if steps != 7 {
+		t.Errorf("Expected 7 instructions, got %d", steps)
+	}
+	// 8 + 3 x 4 (DEC) + 2 x 12 (taken JR) + 8 (final JR)
+	if cpu.TotalCycles != 52 {
+		t.Errorf("Expected 52 cycles, got %d", cpu.TotalCycles)
+	}
+}
+
+func TestOpJP_cc_nn(t *testing.T) {
+	tests := []struct {
+		name   string
+		opcode uint8
+		flags  uint8
+		taken  bool
+	}{
+		{"NZ taken", 0xC2, FlagC, true},
+		{"NZ not taken", 0xC2, FlagZ, false},
+		{"Z taken", 0xCA, FlagZ, true},
+		{"Z not taken", 0xCA, 0x00, false},
+		{"NC taken", 0xD2, 0x00, true},
+		{"NC not taken", 0xD2, FlagC, false},
+		{"C taken", 0xDA, FlagC, true},
+		{"C not taken", 0xDA, FlagZ, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu := setupCPUAt(0x0100, []byte{tt.opcode, 0x50, 0x02})
+			cpu.Registers.F = tt.flags
+
+			cycles := step(t, cpu)
+
+			wantCycles, wantPC := 12, uint16(0x0103)
+			if tt.taken {
+				wantCycles, wantPC = 16, 0x0250
+			}
+			if cycles != wantCycles {
+				t.Errorf("Expected %d cycles, got %d", wantCycles, cycles)
+			}
+			if cpu.Registers.PC != wantPC {
+				t.Errorf("Expected PC=0x%04X, got PC=0x%04X", wantPC, cpu.Registers.PC)
+			}
+		})
+	}
+}
+
+func TestConditionalTiming(t *testing.T) {
+	// Every conditional branch: not taken / taken cycle counts
+	want := map[uint8][2]int{
+		0x20: {8, 12}, 0x28: {8, 12}, 0x30: {8, 12}, 0x38: {8, 12}, // JR cc
+		0xC2: {12, 16}, 0xCA: {12, 16}, 0xD2: {12, 16}, 0xDA: {12, 16}, // JP cc
+		0xC4: {12, 24}, 0xCC: {12, 24}, 0xD4: {12, 24}, 0xDC: {12, 24}, // CALL cc
+		0xC0: {8, 20}, 0xC8: {8, 20}, 0xD0: {8, 20}, 0xD8: {8, 20}, // RET cc
+	}
+
+	for i, op := range opcodeTable {
+		timing, conditional := want[uint8(i)]
+		if op.Conditional() != conditional {
+			t.Errorf("%s (0x%02X): Conditional() = %v, want %v", op.Mnemonic, i, op.Conditional(), conditional)
+			continue
+		}
+		if conditional && (op.Cycles != timing[0] || op.CyclesTaken != timing[1]) {
+			t.Errorf("%s (0x%02X): got %d/%d cycles, want %d/%d",
+				op.Mnemonic, i, op.Cycles, op.CyclesTaken, timing[0], timing[1])
+		}
+	}
+	for i, op := range cbTable {
+		if op.Conditional() {
+			t.Errorf("%s (CB 0x%02X) should not be conditional", op.Mnemonic, i)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package processor
+
+// ============================================================
+// LD rr, nn - Load immediate 16-bit value into a register pair
+// ============================================================
+// Reads the next two bytes (little-endian) and stores them in a
+// register pair or SP. This is how programs set up pointers and
+// the stack before doing anything else.
+//
+// Example:
+//
+//	Memory: [0x21] [0x00] [0xC0]
+//	Result: HL = 0xC000
+//
+// Flags: None affected
+// Cycles: 12
+// Bytes: 3
+
+// opLD_BC_nn implements 0x01: LD BC, nn.
+func opLD_BC_nn(cpu *CPU) {
+	cpu.Registers.SetBC(cpu.fetchWord())
+}
+
+// opLD_DE_nn implements 0x11: LD DE, nn.
+func opLD_DE_nn(cpu *CPU) {
+	cpu.Registers.SetDE(cpu.fetchWord())
+}
+
+// opLD_HL_nn implements 0x21: LD HL, nn.
+func opLD_HL_nn(cpu *CPU) {
+	cpu.Registers.SetHL(cpu.fetchWord())
+}
+
+// opLD_SP_nn implements 0x31: LD SP, nn.
+func opLD_SP_nn(cpu *CPU) {
+	cpu.Registers.SP = cpu.fetchWord()
+}
+
+// ============================================================
+// 0x08: LD (nn), SP - Store SP at a 16-bit address
+// ============================================================
+// Writes SP to memory at the immediate address, low byte first
+// (little-endian), so (nn) gets the low byte and (nn+1) the high.
+//
+// Example:
+//
+//	SP = 0xFFF8, Memory: [0x08] [0x00] [0xC1]
+//	Result: (0xC100) = 0xF8, (0xC101) = 0xFF
+//
+// Flags: None affected
+// Cycles: 20
+// Bytes: 3
+func opLD_nn_SP(cpu *CPU) {
+	addr := cpu.fetchWord()
+	cpu.write(addr, uint8(cpu.Registers.SP))      // Low byte
+	cpu.write(addr+1, uint8(cpu.Registers.SP>>8)) // High byte
+}
+
+// ============================================================
+// 0xF9: LD SP, HL - Copy HL into SP
+// ============================================================
+// Moves the stack pointer to the address held in HL. Used to
+// switch stacks or to restore a stack frame computed in HL.
+//
+// Flags: None affected
+// Cycles: 8
+// Bytes: 1
+func opLD_SP_HL(cpu *CPU) {
+	cpu.Registers.SP = cpu.Registers.HL()
+}
+
+// ============================================================
+// 0xF8: LD HL, SP+e8 - Load SP plus signed offset into HL
+// ============================================================
+// Adds a signed 8-bit offset (-128..+127) to SP and stores the
+// result in HL. SP itself is not changed.
+//
+// The flags are unusual: even though this is a 16-bit result,
+// H and C come from adding the offset's raw byte to the LOW byte
+// of SP, as if it were an unsigned 8-bit addition. Z is always
+// cleared, even when the result is 0.
+//
+// Example:
+//
+//	SP = 0xFFF8, Memory: [0xF8] [0x02]
+//	Result: HL = 0xFFFA, SP unchanged
+//
+// Flags affected:
+//
+//	Z: Reset (0)
+//	N: Reset (0)
+//	H: Set if carry from bit 3 of the low-byte addition
+//	C: Set if carry from bit 7 of the low-byte addition
+//
+// Cycles: 12
+// Bytes: 2
+func opLD_HL_SPe8(cpu *CPU) {
+	cpu.Registers.SetHL(cpu.addSPOffset())
+}
+
+// addSPOffset fetches a signed 8-bit offset, sets the flags as
+// described for LD HL, SP+e8 and returns SP + offset.
+// SP itself is left untouched; ADD SP, e8 stores the result back.
+func (cpu *CPU) addSPOffset() uint16 {
+	offset := cpu.fetchByte()
+	sp := cpu.Registers.SP
+
+	// Flags use the unsigned low-byte addition
+	halfCarry := (sp&0x0F)+uint16(offset&0x0F) > 0x0F
+	carry := (sp&0xFF)+uint16(offset) > 0xFF
+	cpu.Registers.SetFlags(false, false, halfCarry, carry)
+
+	// The result uses the sign-extended offset
+	return sp + uint16(int8(offset))
+}
+
+// ============================================================
+// LDI / LDD - Loads with HL post-increment/decrement
+// ============================================================
+// These move a byte between A and the address in HL, and then
+// step HL by one. They are the Game Boy's memcpy/memset
+// primitives: a loop of "LD A, (HL+)" walks through memory
+// without needing a separate INC HL.
+//
+// The address used is the value of HL *before* it changes.
+// HL wraps around at 0xFFFF/0x0000 like any 16-bit register.
+//
+// Example:
+//
+//	HL = 0xC000, A = 0x42
+//	LD (HL+), A  ->  (0xC000) = 0x42, HL = 0xC001
+//
+// Flags: None affected
+// Cycles: 8
+// Bytes: 1
+
+// opLD_HLI_A implements 0x22: LD (HL+), A.
+func opLD_HLI_A(cpu *CPU) {
+	hl := cpu.Registers.HL()
+	cpu.write(hl, cpu.Registers.A)
+	cpu.Registers.SetHL(hl + 1)
+}
+
+// opLD_A_HLI implements 0x2A: LD A, (HL+).
+func opLD_A_HLI(cpu *CPU) {
+	hl := cpu.Registers.HL()
+	cpu.Registers.A = cpu.read(hl)
+	cpu.Registers.SetHL(hl + 1)
+}
+
+// opLD_HLD_A implements 0x32: LD (HL-), A.
+func opLD_HLD_A(cpu *CPU) {
+	hl := cpu.Registers.HL()
+	cpu.write(hl, cpu.Registers.A)
+	cpu.Registers.SetHL(hl - 1)
+}
+
+// opLD_A_HLD implements 0x3A: LD A, (HL-).
+func opLD_A_HLD(cpu *CPU) {
+	hl := cpu.Registers.HL()
+	cpu.Registers.A = cpu.read(hl)
+	cpu.Registers.SetHL(hl - 1)
+}
+
+// ============================================================
+// LDH - Loads in the high page (0xFF00-0xFFFF)
+// ============================================================
+// The top 256 bytes of the address space hold the I/O registers
+// and HRAM. LDH reaches them with a single byte offset instead of
+// a full 16-bit address, which makes it shorter and faster than
+// a normal load. Games use it constantly to poke hardware
+// registers (LCDC, joypad, timers...) and to access HRAM.
+//
+// The offset comes either from an immediate byte (LDH (n), A) or
+// from register C (LD (C), A).
+//
+// Example:
+//
+//	Memory: [0xE0] [0x80], A = 0x42
+//	Result: (0xFF80) = 0x42
+//
+// Flags: None affected
+// Cycles: 12 for the immediate forms, 8 for the (C) forms
+// Bytes: 2 for the immediate forms, 1 for the (C) forms
+
+// highPage is the base address of the I/O + HRAM page used by LDH.
+const highPage uint16 = 0xFF00
+
+// opLDH_n_A implements 0xE0: LDH (n), A.
+func opLDH_n_A(cpu *CPU) {
+	offset := cpu.fetchByte()
+	cpu.write(highPage+uint16(offset), cpu.Registers.A)
+}
+
+// opLDH_A_n implements 0xF0: LDH A, (n).
+func opLDH_A_n(cpu *CPU) {
+	offset := cpu.fetchByte()
+	cpu.Registers.A = cpu.read(highPage + uint16(offset))
+}
+
+// opLD_Cmem_A implements 0xE2: LD (C), A.
+func opLD_Cmem_A(cpu *CPU) {
+	cpu.write(highPage+uint16(cpu.Registers.C), cpu.Registers.A)
+}
+
+// opLD_A_Cmem implements 0xF2: LD A, (C).
+func opLD_A_Cmem(cpu *CPU) {
+	cpu.Registers.A = cpu.read(highPage + uint16(cpu.Registers.C))
+}
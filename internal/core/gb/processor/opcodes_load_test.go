@@ -0,0 +1,296 @@
+package processor
+
+import "testing"
+
+func TestOpLD_rr_nn(t *testing.T) {
+	tests := []struct {
+		name   string
+		opcode uint8
+		get    func(r *Registers) uint16
+	}{
+		{"LD BC, nn", 0x01, (*Registers).BC},
+		{"LD DE, nn", 0x11, (*Registers).DE},
+		{"LD HL, nn", 0x21, (*Registers).HL},
+		{"LD SP, nn", 0x31, func(r *Registers) uint16 { return r.SP }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Operand bytes are little-endian: 0x34, 0x12 = 0x1234
+			cpu := setupCPU([]byte{tt.opcode, 0x34, 0x12})
+
+			cycles := step(t, cpu)
+
+			if cycles != 12 {
+				t.Errorf("Expected 12 cycles, got %d", cycles)
+			}
+			if got := tt.get(cpu.Registers); got != 0x1234 {
+				t.Errorf("Expected 0x1234, got 0x%04X", got)
+			}
+			if cpu.Registers.PC != 3 {
+				t.Errorf("Expected PC=3, got PC=%d", cpu.Registers.PC)
+			}
+		})
+	}
+}
+
+func TestOpLD_nn_SP(t *testing.T) {
+	// Program: LD SP, 0xFFF8; LD (0xC100), SP
+	cpu := setupCPU([]byte{0x31, 0xF8, 0xFF, 0x08, 0x00, 0xC1})
+
+	cpu.Step() // LD SP, 0xFFF8
+	cycles := step(t, cpu)
+
+	if cycles != 20 {
+		t.Errorf("Expected 20 cycles, got %d", cycles)
+	}
+	// Stored little-endian: low byte first
+	if val := cpu.Memory.Read(0xC100); val != 0xF8 {
+		t.Errorf("Expected (0xC100)=0xF8, got 0x%02X", val)
+	}
+	if val := cpu.Memory.Read(0xC101); val != 0xFF {
+		t.Errorf("Expected (0xC101)=0xFF, got 0x%02X", val)
+	}
+	if cpu.Registers.PC != 6 {
+		t.Errorf("Expected PC=6, got PC=%d", cpu.Registers.PC)
+	}
+}
+
+func TestOpLD_HLI_A(t *testing.T) {
+	// Program: LD (HL+), A
+	cpu := setupCPU([]byte{0x22})
+	cpu.Registers.A = 0x42
+	cpu.Registers.SetHL(0xC000)
+
+	cycles := step(t, cpu)
+
+	if cycles != 8 {
+		t.Errorf("Expected 8 cycles, got %d", cycles)
+	}
+	if val := cpu.Memory.Read(0xC000); val != 0x42 {
+		t.Errorf("Expected (0xC000)=0x42, got 0x%02X", val)
+	}
+	if cpu.Registers.HL() != 0xC001 {
+		t.Errorf("Expected HL=0xC001, got HL=0x%04X", cpu.Registers.HL())
+	}
+}
+
+func TestOpLD_A_HLI(t *testing.T) {
+	// Program: LD A, (HL+)
+	cpu := setupCPU([]byte{0x2A})
+	cpu.Memory.Write(0xC0FF, 0x99)
+	cpu.Registers.SetHL(0xC0FF)
+
+	cycles := step(t, cpu)
+
+	if cycles != 8 {
+		t.Errorf("Expected 8 cycles, got %d", cycles)
+	}
+	if cpu.Registers.A != 0x99 {
+		t.Errorf("Expected A=0x99, got A=0x%02X", cpu.Registers.A)
+	}
+	// Increment carries from L into H
+	if cpu.Registers.H != 0xC1 || cpu.Registers.L != 0x00 {
+		t.Errorf("Expected HL=0xC100, got HL=0x%04X", cpu.Registers.HL())
+	}
+}
+
+func TestOpLD_HLD_A(t *testing.T) {
+	// Program: LD (HL-), A
+	cpu := setupCPU([]byte{0x32})
+	cpu.Registers.A = 0x7E
+	cpu.Registers.SetHL(0xC100)
+
+	cycles := step(t, cpu)
+
+	if cycles != 8 {
+		t.Errorf("Expected 8 cycles, got %d", cycles)
+	}
+	if val := cpu.Memory.Read(0xC100); val != 0x7E {
+		t.Errorf("Expected (0xC100)=0x7E, got 0x%02X", val)
+	}
+	// Decrement borrows from H
+	if cpu.Registers.HL() != 0xC0FF {
+		t.Errorf("Expected HL=0xC0FF, got HL=0x%04X", cpu.Registers.HL())
+	}
+}
+
+func TestOpLD_A_HLD(t *testing.T) {
+	// Program: LD A, (HL-)
+	cpu := setupCPU([]byte{0x3A})
+	cpu.Memory.Write(0xFF80, 0x5A)
+	cpu.Registers.SetHL(0xFF80)
+
+	cpu.Step()
+
+	if cpu.Registers.A != 0x5A {
+		t.Errorf("Expected A=0x5A, got A=0x%02X", cpu.Registers.A)
+	}
+	if cpu.Registers.HL() != 0xFF7F {
+		t.Errorf("Expected HL=0xFF7F, got HL=0x%04X", cpu.Registers.HL())
+	}
+}
+
+func TestLDIWrapsHL(t *testing.T) {
+	// HL wraps from 0xFFFF to 0x0000 like any 16-bit register
+	cpu := setupCPU([]byte{0x2A})
+	cpu.Registers.SetHL(0xFFFF)
+
+	cpu.Step()
+
+	if cpu.Registers.HL() != 0x0000 {
+		t.Errorf("Expected HL to wrap to 0x0000, got HL=0x%04X", cpu.Registers.HL())
+	}
+}
+
+func TestLDIFlagsUnaffected(t *testing.T) {
+	// Loads never touch the flags
+	cpu := setupCPU([]byte{0x22, 0x32})
+	cpu.Registers.SetHL(0xC000)
+	cpu.Registers.SetFlags(true, true, true, true)
+
+	cpu.Step() // LD (HL+), A
+	cpu.Step() // LD (HL-), A
+
+	if cpu.Registers.F != 0xF0 {
+		t.Errorf("Flags should be unchanged, got F=0x%02X", cpu.Registers.F)
+	}
+}
+
+func TestOpLDH_n_A(t *testing.T) {
+	// Program: LDH (0x80), A
+	cpu := setupCPU([]byte{0xE0, 0x80})
+	cpu.Registers.A = 0x42
+
+	cycles := step(t, cpu)
+
+	if cycles != 12 {
+		t.Errorf("Expected 12 cycles, got %d", cycles)
+	}
+	if val := cpu.Memory.Read(0xFF80); val != 0x42 {
+		t.Errorf("Expected (0xFF80)=0x42, got 0x%02X", val)
+	}
+	if cpu.Registers.PC != 2 {
+		t.Errorf("Expected PC=2, got PC=%d", cpu.Registers.PC)
+	}
+}
+
+func TestOpLDH_A_n(t *testing.T) {
+	// Program: LDH A, (0xFE)
+	cpu := setupCPU([]byte{0xF0, 0xFE})
+	cpu.Memory.Write(0xFFFE, 0x3C)
+
+	cycles := step(t, cpu)
+
+	if cycles != 12 {
+		t.Errorf("Expected 12 cycles, got %d", cycles)
+	}
+	if cpu.Registers.A != 0x3C {
+		t.Errorf("Expected A=0x3C, got A=0x%02X", cpu.Registers.A)
+	}
+	if cpu.Registers.PC != 2 {
+		t.Errorf("Expected PC=2, got PC=%d", cpu.Registers.PC)
+	}
+}
+
+func TestOpLD_Cmem_A(t *testing.T) {
+	// Program: LD C, 0x90; LD (C), A
+	cpu := setupCPU([]byte{0x0E, 0x90, 0xE2})
+	cpu.Registers.A = 0xA5
+
+	cpu.Step() // LD C, 0x90
+	cycles := step(t, cpu)
+
+	if cycles != 8 {
+		t.Errorf("Expected 8 cycles, got %d", cycles)
+	}
+	if val := cpu.Memory.Read(0xFF90); val != 0xA5 {
+		t.Errorf("Expected (0xFF90)=0xA5, got 0x%02X", val)
+	}
+	if cpu.Registers.PC != 3 {
+		t.Errorf("Expected PC=3, got PC=%d", cpu.Registers.PC)
+	}
+}
+
+func TestOpLD_A_Cmem(t *testing.T) {
+	// Program: LD C, 0x85; LD A, (C)
+	cpu := setupCPU([]byte{0x0E, 0x85, 0xF2})
+	cpu.Memory.Write(0xFF85, 0x17)
+
+	cpu.Step() // LD C, 0x85
+	cycles := step(t, cpu)
+
+	if cycles != 8 {
+		t.Errorf("Expected 8 cycles, got %d", cycles)
+	}
+	if cpu.Registers.A != 0x17 {
+		t.Errorf("Expected A=0x17, got A=0x%02X", cpu.Registers.A)
+	}
+}
+
+func TestOpLD_SP_HL(t *testing.T) {
+	// Program: LD HL, 0xC123; LD SP, HL
+	cpu := setupCPU([]byte{0x21, 0x23, 0xC1, 0xF9})
+
+	cpu.Step() // LD HL, 0xC123
+	cycles := step(t, cpu)
+
+	if cycles != 8 {
+		t.Errorf("Expected 8 cycles, got %d", cycles)
+	}
+	if cpu.Registers.SP != 0xC123 {
+		t.Errorf("Expected SP=0xC123, got SP=0x%04X", cpu.Registers.SP)
+	}
+}
+
+func TestOpLD_HL_SPe8(t *testing.T) {
+	tests := []struct {
+		name   string
+		sp     uint16
+		offset uint8
+		hl     uint16
+		h, c   bool
+	}{
+		{"small positive", 0xFFF0, 0x02, 0xFFF2, false, false},
+		{"zero offset", 0x0000, 0x00, 0x0000, false, false},
+		{"half-carry only", 0x000F, 0x01, 0x0010, true, false},
+		{"carry only", 0x00F0, 0x10, 0x0100, false, true},
+		{"both carries", 0x00FF, 0x01, 0x0100, true, true},
+		// -1 (0xFF): low-byte addition 0xF8 + 0xFF carries out of both bits
+		{"negative offset", 0xFFF8, 0xFF, 0xFFF7, true, true},
+		// -128 (0x80) from 0x1000: low-byte 0x00 + 0x80 sets no flags
+		{"most negative offset", 0x1000, 0x80, 0x0F80, false, false},
+		// -2 (0xFE) from 0x0001: wraps below zero, 0x01 + 0xFE sets no flags
+		{"negative wraps", 0x0001, 0xFE, 0xFFFF, false, false},
+		{"most positive offset", 0x0000, 0x7F, 0x007F, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu := setupCPU([]byte{0xF8, tt.offset})
+			cpu.Registers.SP = tt.sp
+			cpu.Registers.SetFlags(true, true, false, false) // Z and N must be cleared
+
+			cycles := step(t, cpu)
+
+			if cycles != 12 {
+				t.Errorf("Expected 12 cycles, got %d", cycles)
+			}
+			if cpu.Registers.HL() != tt.hl {
+				t.Errorf("Expected HL=0x%04X, got HL=0x%04X", tt.hl, cpu.Registers.HL())
+			}
+			if cpu.Registers.SP != tt.sp {
+				t.Errorf("SP should be unchanged, got SP=0x%04X", cpu.Registers.SP)
+			}
+			if cpu.Registers.GetFlagZ() || cpu.Registers.GetFlagN() {
+				t.Error("Z and N flags should always be cleared")
+			}
+			if cpu.Registers.GetFlagH() != tt.h {
+				t.Errorf("Expected H=%v, got H=%v", tt.h, cpu.Registers.GetFlagH())
+			}
+			if cpu.Registers.GetFlagC() != tt.c {
+				t.Errorf("Expected C=%v, got C=%v", tt.c, cpu.Registers.GetFlagC())
+			}
+		})
+	}
+}
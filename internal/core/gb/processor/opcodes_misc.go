@@ -0,0 +1,304 @@
+package processor
+
+import "fmt"
+
+// ============================================================
+// RLCA / RRCA / RLA / RRA - Rotate A
+// ============================================================
+// One-byte versions of CB RLC A, RRC A, RL A and RR A. They move
+// the bits exactly like their CB cousins (see opcodes_cb.go), but
+// take 4 cycles instead of 8 and ALWAYS clear the Z flag, even
+// when A ends up as 0. Code that relies on Z after a rotate must
+// use the CB forms.
+//
+// Example:
+//
+//	A = 0x80, C = 0
+//	RLA   ->  A = 0x00, C = 1, Z = 0
+//	RL A  ->  A = 0x00, C = 1, Z = 1
+//
+// Flags affected:
+//
+//	Z: Reset (0)
+//	N: Reset (0)
+//	H: Reset (0)
+//	C: The bit rotated out of A
+//
+// Cycles: 4
+// Bytes: 1
+
+// rotateA applies a CB rotate to A and then clears Z.
+func (cpu *CPU) rotateA(rotate func(cpu *CPU, value uint8) uint8) {
+	cpu.Registers.A = rotate(cpu, cpu.Registers.A)
+	cpu.Registers.SetFlagZ(false)
+}
+
+func opRLCA(cpu *CPU) { cpu.rotateA((*CPU).rlc) }
+func opRRCA(cpu *CPU) { cpu.rotateA((*CPU).rrc) }
+func opRLA(cpu *CPU)  { cpu.rotateA((*CPU).rl) }
+func opRRA(cpu *CPU)  { cpu.rotateA((*CPU).rr) }
+
+// ============================================================
+// 0x27: DAA - Decimal Adjust Accumulator
+// ============================================================
+// Fixes up A after adding or subtracting two BCD (binary-coded
+// decimal) numbers, where each nibble holds one decimal digit
+// 0-9. Games use BCD for scores and timers because it is trivial
+// to draw digit by digit.
+//
+// A plain binary add gives the wrong BCD answer whenever a digit
+// goes past 9:
+//
+//	0x19 + 0x28 = 0x41   (but 19 + 28 = 47)
+//	DAA adds 0x06        ->  0x47
+//
+// DAA works out what correction to apply from the flags left by
+// the previous instruction:
+//
+//	After an addition (N = 0):
+//	  - if C was set or A > 0x99: add 0x60 and set C (tens overflowed)
+//	  - if H was set or the low digit > 9: add 0x06 (ones overflowed)
+//
+//	After a subtraction (N = 1), only the flags matter, because a
+//	borrow out of a digit always leaves it 6 too high:
+//	  - if C was set: subtract 0x60 (C stays set)
+//	  - if H was set: subtract 0x06
+//
+// Flags affected:
+//
+//	Z: Set if result is zero
+//	N: Not affected
+//	H: Reset (0)
+//	C: Set if the adjustment carried/borrowed past 99, otherwise unchanged
+//
+// Cycles: 4
+// Bytes: 1
+func opDAA(cpu *CPU) {
+	a := cpu.Registers.A
+	carry := cpu.Registers.GetFlagC()
+
+	if !cpu.Registers.GetFlagN() {
+		if carry || a > 0x99 {
+			a += 0x60
+			carry = true
+		}
+		if cpu.Registers.GetFlagH() || a&0x0F > 0x09 {
+			a += 0x06
+		}
+	} else {
+		if carry {
+			a -= 0x60
+		}
+		if cpu.Registers.GetFlagH() {
+			a -= 0x06
+		}
+	}
+
+	cpu.Registers.A = a
+	cpu.Registers.SetFlagZ(a == 0)
+	cpu.Registers.SetFlagH(false)
+	cpu.Registers.SetFlagC(carry)
+}
+
+// ============================================================
+// 0x2F: CPL - Complement A
+// ============================================================
+// Flips every bit of A (A = ^A), the same as XOR A, 0xFF but
+// with different flag effects. Combined with INC A it negates a
+// two's complement number.
+//
+// Flags affected:
+//
+//	Z: Not affected
+//	N: Set (1)
+//	H: Set (1)
+//	C: Not affected
+//
+// Cycles: 4
+// Bytes: 1
+func opCPL(cpu *CPU) {
+	cpu.Registers.A = ^cpu.Registers.A
+	cpu.Registers.SetFlagN(true)
+	cpu.Registers.SetFlagH(true)
+}
+
+// ============================================================
+// 0x37: SCF - Set Carry Flag
+// ============================================================
+// Forces C to 1, e.g. to return a "success" status from a routine
+// or to prime an ADC/SBC chain.
+//
+// Flags affected:
+//
+//	Z: Not affected
+//	N: Reset (0)
+//	H: Reset (0)
+//	C: Set (1)
+//
+// Cycles: 4
+// Bytes: 1
+func opSCF(cpu *CPU) {
+	cpu.Registers.SetFlagN(false)
+	cpu.Registers.SetFlagH(false)
+	cpu.Registers.SetFlagC(true)
+}
+
+// ============================================================
+// 0x3F: CCF - Complement Carry Flag
+// ============================================================
+// Flips C. There is no "clear carry" instruction: the usual idiom
+// is SCF followed by CCF (or simply AND A / OR A).
+//
+// Flags affected:
+//
+//	Z: Not affected
+//	N: Reset (0)
+//	H: Reset (0)
+//	C: Inverted
+//
+// Cycles: 4
+// Bytes: 1
+func opCCF(cpu *CPU) {
+	cpu.Registers.SetFlagN(false)
+	cpu.Registers.SetFlagH(false)
+	cpu.Registers.SetFlagC(!cpu.Registers.GetFlagC())
+}
+
+// ============================================================
+// 0x76: HALT - Halt until interrupt
+// ============================================================
+// Puts the CPU into a low-power state. While halted, Step does
+// nothing but burn 4 cycles; as soon as any interrupt is pending
+// (IE & IF != 0) the CPU wakes up. With IME=1 it services the
+// interrupt; with IME=0 it just carries on with the next
+// instruction and the request stays in IF, which is how games wait
+// for an interrupt they poll for themselves:
+//
+//	DI
+//	HALT       ; Wait for VBlank...
+//	LDH A,(0F) ; ...and find its bit still set in IF
+//
+// The HALT bug: if HALT is executed while IME=0 and an interrupt
+// is ALREADY pending, the CPU does not halt at all. Instead, the
+// next opcode fetch fails to increment PC, so the byte after HALT
+// is read twice:
+//
+//	HALT      ; 0x76
+//	INC A     ; 0x3C  ->  executed twice
+//
+// With IME=1 the CPU halts as usual and wakes up straight away, so
+// the pending interrupt can be serviced. In between is "EI; HALT"
+// with an interrupt pending: IME is still 0 when HALT runs, so the
+// bug triggers, but the interrupt is serviced before the byte after
+// HALT is fetched. The handler then returns to the HALT itself,
+// which runs again (see serviceInterrupt).
+//
+// Flags affected: None
+//
+// Cycles: 4
+// Bytes: 1
+func opHALT(cpu *CPU) {
+	if !cpu.IME && cpu.pendingInterrupts() != 0 {
+		cpu.haltBug = true
+		return
+	}
+	cpu.Halted = true
+}
+
+// ============================================================
+// 0x10: STOP - Stop CPU and LCD
+// ============================================================
+// Enters a very low power mode where the CPU (and, on hardware,
+// the LCD and oscillator) stops until a button is pressed. STOP is
+// encoded as 2 bytes: the opcode is followed by a padding byte,
+// normally 0x00, which is skipped.
+//
+// On CGB, STOP is also how the CPU switches between normal and
+// double speed (after arming KEY1). That is delegated to the
+// OnStop hook: if it reports that it handled the STOP, the CPU
+// just continues with the next instruction.
+//
+// Flags affected: None
+//
+// Cycles: 4
+// Bytes: 2
+func opSTOP(cpu *CPU) {
+	cpu.Registers.PC++ // Skip the padding byte (it is not fetched)
+
+	if cpu.OnStop != nil && cpu.OnStop(cpu) {
+		return
+	}
+	cpu.Stopped = true
+}
+
+// ============================================================
+// 0xF3: DI - Disable interrupts
+// 0xFB: EI - Enable interrupts
+// ============================================================
+// Clear or set IME, the master switch that decides whether
+// pending interrupts are serviced. DI takes effect immediately
+// (and cancels an EI that has not kicked in yet). EI is delayed
+// by one instruction: IME is only set once the instruction
+// AFTER EI has finished, so the common handler epilogue
+//
+//	EI
+//	RET
+//
+// returns before another interrupt can be taken.
+//
+// Flags affected: None
+//
+// Cycles: 4
+// Bytes: 1
+func opDI(cpu *CPU) {
+	cpu.IME = false
+	cpu.eiDelay = 0
+}
+
+func opEI(cpu *CPU) {
+	// EI right after EI does not push the enable back any further
+	if !cpu.IME && cpu.eiDelay == 0 {
+		cpu.eiDelay = 2 // This instruction, and the next one
+	}
+}
+
+// ============================================================
+// Illegal opcodes: 0xD3, 0xDB, 0xDD, 0xE3, 0xE4, 0xEB, 0xEC,
+// 0xED, 0xF4, 0xFC, 0xFD
+// ============================================================
+// These 11 opcodes do not exist on the SM83. Executing one
+// hard-locks the CPU: it stops fetching instructions and nothing,
+// not even an interrupt, brings it back; only a reset does. A ROM
+// that gets here has almost certainly jumped into data.
+//
+// OnLock, if set, is told which opcode caused the lock-up, so a
+// debugger can stop right where execution went off the rails.
+//
+// Flags affected: None
+//
+// Cycles: 4
+// Bytes: 1
+
+func opIllegal(cpu *CPU) { cpu.lock(cpu.opcode) }
+
+// IllegalOpcodeError is returned by the Step that executes an
+// illegal opcode. It matches ErrLocked with errors.Is.
+type IllegalOpcodeError struct {
+	Opcode uint8  // The opcode byte
+	PC     uint16 // Address the opcode was fetched from
+}
+
+func (e *IllegalOpcodeError) Error() string {
+	return fmt.Sprintf("processor: illegal opcode 0x%02X at 0x%04X locked up the CPU", e.Opcode, e.PC)
+}
+
+func (e *IllegalOpcodeError) Unwrap() error { return ErrLocked }
+
+// lock puts the CPU into the Locked state after an illegal opcode.
+func (cpu *CPU) lock(opcode uint8) {
+	cpu.Locked = true
+	cpu.err = &IllegalOpcodeError{Opcode: opcode, PC: cpu.instructionPC}
+	if cpu.OnLock != nil {
+		cpu.OnLock(cpu, opcode)
+	}
+}
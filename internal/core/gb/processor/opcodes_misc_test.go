@@ -0,0 +1,524 @@
+package processor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/antoniosarro/yagbc/internal/core/gb/memory"
+)
+
+func TestAccumulatorRotates(t *testing.T) {
+	tests := []struct {
+		name    string
+		opcode  uint8
+		a       uint8
+		carryIn bool
+		result  uint8
+		carry   bool
+	}{
+		{"RLCA", 0x07, 0b1000_0101, false, 0b0000_1011, true},
+		{"RRCA", 0x0F, 0b0000_0011, false, 0b1000_0001, true},
+		{"RLA", 0x17, 0b0100_0000, true, 0b1000_0001, false},
+		{"RRA", 0x1F, 0b0000_0010, true, 0b1000_0001, false},
+		// Results of zero must still leave Z cleared
+		{"RLA to zero", 0x17, 0b1000_0000, false, 0x00, true},
+		{"RRA to zero", 0x1F, 0b0000_0001, false, 0x00, true},
+		{"RLCA of zero", 0x07, 0x00, true, 0x00, false},
+		{"RRCA of zero", 0x0F, 0x00, true, 0x00, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu := setupCPU([]byte{tt.opcode})
+			cpu.Registers.A = tt.a
+			cpu.Registers.SetFlags(true, true, true, tt.carryIn)
+
+			cycles := step(t, cpu)
+
+			if cycles != 4 {
+				t.Errorf("Expected 4 cycles, got %d", cycles)
+			}
+			if cpu.Registers.A != tt.result {
+				t.Errorf("Expected A=0x%02X, got A=0x%02X", tt.result, cpu.Registers.A)
+			}
+			checkFlags(t, cpu.Registers, false, false, false, tt.carry)
+		})
+	}
+}
+
+func TestAccumulatorRotatesVsCB(t *testing.T) {
+	// Same bit movement as the CB forms; only Z and timing differ
+	pairs := []struct {
+		name     string
+		opcode   uint8
+		cbOpcode uint8
+	}{
+		{"RLCA vs RLC A", 0x07, 0x07},
+		{"RRCA vs RRC A", 0x0F, 0x0F},
+		{"RLA vs RL A", 0x17, 0x17},
+		{"RRA vs RR A", 0x1F, 0x1F},
+	}
+
+	for _, p := range pairs {
+		t.Run(p.name, func(t *testing.T) {
+			for a := range 256 {
+				for _, carryIn := range []bool{false, true} {
+					fast := setupCPU([]byte{p.opcode})
+					fast.Registers.A = uint8(a)
+					fast.Registers.SetFlagC(carryIn)
+					fastCycles := step(t, fast)
+
+					cb := setupCPU([]byte{0xCB, p.cbOpcode})
+					cb.Registers.A = uint8(a)
+					cb.Registers.SetFlagC(carryIn)
+					cbCycles := step(t, cb)
+
+					if fast.Registers.A != cb.Registers.A || fast.Registers.GetFlagC() != cb.Registers.GetFlagC() {
+						t.Fatalf("A=0x%02X C=%v: results differ (0x%02X vs 0x%02X)", a, carryIn, fast.Registers.A, cb.Registers.A)
+					}
+					if fast.Registers.GetFlagZ() {
+						t.Fatalf("A=0x%02X C=%v: accumulator rotate set Z", a, carryIn)
+					}
+					if cb.Registers.GetFlagZ() != (cb.Registers.A == 0) {
+						t.Fatalf("A=0x%02X C=%v: CB rotate Z flag wrong", a, carryIn)
+					}
+					if fastCycles != 4 || cbCycles != 8 {
+						t.Fatalf("Expected 4 and 8 cycles, got %d and %d", fastCycles, cbCycles)
+					}
+				}
+			}
+		})
+	}
+}
+
+// toBCD encodes a number 0-99 as two BCD digits.
+func toBCD(n int) uint8 {
+	return uint8(n/10<<4 | n%10)
+}
+
+func TestOpDAA(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        uint8
+		n, h, c  bool
+		result   uint8
+		z, carry bool
+	}{
+		{"already valid", 0x45, false, false, false, 0x45, false, false},
+		{"low digit overflow", 0x4A, false, false, false, 0x50, false, false},
+		{"half-carry after add", 0x41, false, true, false, 0x47, false, false},
+		{"high digit overflow", 0xA0, false, false, false, 0x00, true, true},
+		{"carry after add", 0x20, false, false, true, 0x80, false, true},
+		{"both digits overflow", 0x9A, false, false, false, 0x00, true, true},
+		{"subtract, no borrow", 0x25, true, false, false, 0x25, false, false},
+		{"subtract, half-borrow", 0x2F, true, true, false, 0x29, false, false},
+		{"subtract, borrow", 0xF5, true, false, true, 0x95, false, true},
+		{"subtract, zero", 0x66, true, true, true, 0x00, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu := setupCPU([]byte{0x27})
+			cpu.Registers.A = tt.a
+			cpu.Registers.SetFlags(false, tt.n, tt.h, tt.c)
+
+			cycles := step(t, cpu)
+
+			if cycles != 4 {
+				t.Errorf("Expected 4 cycles, got %d", cycles)
+			}
+			if cpu.Registers.A != tt.result {
+				t.Errorf("Expected A=0x%02X, got A=0x%02X", tt.result, cpu.Registers.A)
+			}
+			// N passes through unchanged; H is always cleared
+			checkFlags(t, cpu.Registers, tt.z, tt.n, false, tt.carry)
+		})
+	}
+}
+
+func TestDAAExhaustiveBCD(t *testing.T) {
+	// For every pair of 2-digit BCD numbers, ADD/SUB followed by DAA
+	// must give the correct decimal result, with C as the decimal carry
+	for x := range 100 {
+		for y := range 100 {
+			// ADD A, B; DAA
+			cpu := setupCPU([]byte{0x80, 0x27})
+			cpu.Registers.A = toBCD(x)
+			cpu.Registers.B = toBCD(y)
+			cpu.Step()
+			cpu.Step()
+
+			sum := x + y
+			if want := toBCD(sum % 100); cpu.Registers.A != want || cpu.Registers.GetFlagC() != (sum >= 100) {
+				t.Fatalf("%02d + %02d: expected A=0x%02X C=%v, got A=0x%02X C=%v",
+					x, y, want, sum >= 100, cpu.Registers.A, cpu.Registers.GetFlagC())
+			}
+			if cpu.Registers.GetFlagZ() != (sum%100 == 0) {
+				t.Fatalf("%02d + %02d: wrong Z flag", x, y)
+			}
+
+			// SUB A, B; DAA
+			cpu = setupCPU([]byte{0x90, 0x27})
+			cpu.Registers.A = toBCD(x)
+			cpu.Registers.B = toBCD(y)
+			cpu.Step()
+			cpu.Step()
+
+			diff := (x - y + 100) % 100
+			if want := toBCD(diff); cpu.Registers.A != want || cpu.Registers.GetFlagC() != (x < y) {
+				t.Fatalf("%02d - %02d: expected A=0x%02X C=%v, got A=0x%02X C=%v",
+					x, y, want, x < y, cpu.Registers.A, cpu.Registers.GetFlagC())
+			}
+			if cpu.Registers.GetFlagZ() != (diff == 0) || !cpu.Registers.GetFlagN() {
+				t.Fatalf("%02d - %02d: wrong Z/N flags F=0x%02X", x, y, cpu.Registers.F)
+			}
+		}
+	}
+}
+
+func TestOpCPL(t *testing.T) {
+	for _, flags := range []uint8{0x00, FlagZ | FlagC} {
+		cpu := setupCPU([]byte{0x2F})
+		cpu.Registers.A = 0b1010_0011
+		cpu.Registers.F = flags
+
+		cycles := step(t, cpu)
+
+		if cycles != 4 {
+			t.Errorf("Expected 4 cycles, got %d", cycles)
+		}
+		if cpu.Registers.A != 0b0101_1100 {
+			t.Errorf("Expected A=0x5C, got A=0x%02X", cpu.Registers.A)
+		}
+		// Z and C are left alone; N and H are always set
+		checkFlags(t, cpu.Registers, flags&FlagZ != 0, true, true, flags&FlagC != 0)
+	}
+}
+
+func TestOpSCF(t *testing.T) {
+	for _, flags := range []uint8{0x00, FlagZ | FlagN | FlagH, 0xF0} {
+		cpu := setupCPU([]byte{0x37})
+		cpu.Registers.F = flags
+
+		cycles := step(t, cpu)
+
+		if cycles != 4 {
+			t.Errorf("Expected 4 cycles, got %d", cycles)
+		}
+		checkFlags(t, cpu.Registers, flags&FlagZ != 0, false, false, true)
+	}
+}
+
+func TestOpCCF(t *testing.T) {
+	for _, flags := range []uint8{0x00, FlagC, FlagZ | FlagN | FlagH, 0xF0} {
+		cpu := setupCPU([]byte{0x3F})
+		cpu.Registers.F = flags
+
+		cycles := step(t, cpu)
+
+		if cycles != 4 {
+			t.Errorf("Expected 4 cycles, got %d", cycles)
+		}
+		checkFlags(t, cpu.Registers, flags&FlagZ != 0, false, false, flags&FlagC == 0)
+	}
+}
+
+func TestClearCarryIdiom(t *testing.T) {
+	// SCF; CCF clears the carry no matter what it was before
+	for _, carryIn := range []bool{false, true} {
+		cpu := setupCPU([]byte{0x37, 0x3F})
+		cpu.Registers.SetFlagC(carryIn)
+
+		cpu.Step()
+		cpu.Step()
+
+		if cpu.Registers.GetFlagC() {
+			t.Errorf("C=%v: SCF; CCF should leave C cleared", carryIn)
+		}
+	}
+}
+
+func TestOpHALT(t *testing.T) {
+	// Program: HALT, INC A
+	cpu := setupCPU([]byte{0x76, 0x3C})
+
+	cycles := step(t, cpu)
+
+	if cycles != 4 {
+		t.Errorf("Expected 4 cycles, got %d", cycles)
+	}
+	if !cpu.Halted {
+		t.Fatal("Expected CPU to be halted")
+	}
+	if cpu.Registers.PC != 0x0001 {
+		t.Errorf("Expected PC=0x0001, got PC=0x%04X", cpu.Registers.PC)
+	}
+
+	// While nothing is pending, the CPU stays put
+	for i := 0; i < 3; i++ {
+		if cycles := step(t, cpu); cycles != 4 {
+			t.Errorf("Expected 4 cycles while halted, got %d", cycles)
+		}
+	}
+	if !cpu.Halted || cpu.Registers.PC != 0x0001 || cpu.Registers.A != 0x00 {
+		t.Fatalf("Expected CPU to stay halted at PC=0x0001, got Halted=%v PC=0x%04X A=0x%02X",
+			cpu.Halted, cpu.Registers.PC, cpu.Registers.A)
+	}
+	if cpu.TotalCycles != 16 {
+		t.Errorf("Expected 16 total cycles, got %d", cpu.TotalCycles)
+	}
+
+	// A requested interrupt that is not enabled does not wake the CPU
+	cpu.Memory.Write(memory.AddrIF, 0x04)
+	cpu.Step()
+	if !cpu.Halted {
+		t.Fatal("Expected CPU to stay halted with IE=0")
+	}
+
+	// Enabling it wakes the CPU, which executes INC A once
+	cpu.Memory.Write(memory.AddrIE, 0x04)
+	cpu.Step()
+	if cpu.Halted {
+		t.Fatal("Expected CPU to wake up")
+	}
+	if cpu.Registers.A != 0x01 {
+		t.Errorf("Expected A=0x01, got A=0x%02X", cpu.Registers.A)
+	}
+	if cpu.Registers.PC != 0x0002 {
+		t.Errorf("Expected PC=0x0002, got PC=0x%04X", cpu.Registers.PC)
+	}
+}
+
+func TestOpHALTBug(t *testing.T) {
+	// Program: HALT, INC A, NOP
+	cpu := setupCPU([]byte{0x76, 0x3C, 0x00})
+	cpu.Memory.Write(memory.AddrIE, 0x01)
+	cpu.Memory.Write(memory.AddrIF, 0x01)
+
+	cpu.Step()
+	if cpu.Halted {
+		t.Fatal("HALT with a pending interrupt and IME=0 must not halt")
+	}
+
+	// INC A is fetched twice: once without incrementing PC, once normally
+	cpu.Step()
+	if cpu.Registers.PC != 0x0001 {
+		t.Errorf("Expected PC=0x0001 after buggy fetch, got PC=0x%04X", cpu.Registers.PC)
+	}
+	cpu.Step()
+	if cpu.Registers.PC != 0x0002 {
+		t.Errorf("Expected PC=0x0002, got PC=0x%04X", cpu.Registers.PC)
+	}
+	if cpu.Registers.A != 0x02 {
+		t.Errorf("Expected INC A to run twice (A=0x02), got A=0x%02X", cpu.Registers.A)
+	}
+}
+
+func TestOpSTOP(t *testing.T) {
+	// Program: STOP 0x00, INC A
+	cpu := setupCPU([]byte{0x10, 0x00, 0x3C})
+
+	cycles := step(t, cpu)
+
+	if cycles != 4 {
+		t.Errorf("Expected 4 cycles, got %d", cycles)
+	}
+	if !cpu.Stopped {
+		t.Fatal("Expected CPU to be stopped")
+	}
+	if cpu.Registers.PC != 0x0002 {
+		t.Errorf("Expected PC=0x0002 (2-byte encoding), got PC=0x%04X", cpu.Registers.PC)
+	}
+
+	// Pending interrupts do not wake a stopped CPU
+	cpu.Memory.Write(memory.AddrIE, 0x01)
+	cpu.Memory.Write(memory.AddrIF, 0x01)
+	cpu.Step()
+	if !cpu.Stopped || cpu.Registers.PC != 0x0002 {
+		t.Fatalf("Expected CPU to stay stopped at PC=0x0002, got Stopped=%v PC=0x%04X",
+			cpu.Stopped, cpu.Registers.PC)
+	}
+
+	// Clearing Stopped resumes execution after the padding byte
+	cpu.Stopped = false
+	cpu.Step()
+	if cpu.Registers.A != 0x01 {
+		t.Errorf("Expected A=0x01, got A=0x%02X", cpu.Registers.A)
+	}
+}
+
+func TestOpSTOPHook(t *testing.T) {
+	// Program: STOP 0x00, INC A
+	cpu := setupCPU([]byte{0x10, 0x00, 0x3C})
+
+	calls := 0
+	cpu.OnStop = func(cpu *CPU) bool {
+		calls++
+		return true // e.g. a speed switch took place
+	}
+
+	cpu.Step()
+	if calls != 1 {
+		t.Errorf("Expected OnStop to be called once, got %d", calls)
+	}
+	if cpu.Stopped {
+		t.Fatal("Expected a handled STOP not to stop the CPU")
+	}
+
+	cpu.Step()
+	if cpu.Registers.A != 0x01 {
+		t.Errorf("Expected A=0x01, got A=0x%02X", cpu.Registers.A)
+	}
+
+	// A hook that declines falls back to stop mode
+	cpu = setupCPU([]byte{0x10, 0x00})
+	cpu.OnStop = func(cpu *CPU) bool { return false }
+	cpu.Step()
+	if !cpu.Stopped {
+		t.Error("Expected CPU to be stopped when OnStop returns false")
+	}
+}
+
+func TestOpEI(t *testing.T) {
+	// Program: EI, NOP, NOP
+	cpu := setupCPU([]byte{0xFB, 0x00, 0x00})
+
+	if cycles := step(t, cpu); cycles != 4 {
+		t.Errorf("Expected 4 cycles, got %d", cycles)
+	}
+	if cpu.IME {
+		t.Fatal("EI must not set IME before the next instruction has run")
+	}
+
+	cpu.Step()
+	if !cpu.IME {
+		t.Fatal("Expected IME to be set after the instruction following EI")
+	}
+}
+
+func TestOpDI(t *testing.T) {
+	// Program: DI
+	cpu := setupCPU([]byte{0xF3})
+	cpu.IME = true
+
+	if cycles := step(t, cpu); cycles != 4 {
+		t.Errorf("Expected 4 cycles, got %d", cycles)
+	}
+	if cpu.IME {
+		t.Error("Expected DI to clear IME immediately")
+	}
+}
+
+func TestOpEIThenDI(t *testing.T) {
+	// Program: EI, DI, NOP - DI cancels the pending enable
+	cpu := setupCPU([]byte{0xFB, 0xF3, 0x00})
+
+	for i := 0; i < 3; i++ {
+		cpu.Step()
+		if cpu.IME {
+			t.Fatalf("Expected IME to stay clear, set after step %d", i+1)
+		}
+	}
+}
+
+func TestOpRETISetsIME(t *testing.T) {
+	// Program: RETI
+	cpu := setupCPU([]byte{0xD9})
+	cpu.Registers.SP = 0xCFFE
+
+	cpu.Step()
+	if !cpu.IME {
+		t.Error("Expected RETI to set IME immediately")
+	}
+}
+
+func TestOpHALTWithIME(t *testing.T) {
+	// Step services interrupts before fetching, so HALT only sees a
+	// pending interrupt with IME=1 if it is called directly
+	cpu := setupCPU([]byte{0x00})
+	cpu.Registers.SP = 0xD000
+	cpu.IME = true
+	cpu.Memory.Write(memory.AddrIE, 0x01)
+	cpu.Memory.Write(memory.AddrIF, 0x01)
+
+	opHALT(cpu)
+
+	// With IME=1 there is no HALT bug
+	if cpu.haltBug {
+		t.Error("Expected no HALT bug with IME=1")
+	}
+	if !cpu.Halted {
+		t.Fatal("Expected CPU to be halted")
+	}
+
+	// The next step wakes up straight into the interrupt handler
+	cpu.Step()
+	if cpu.Halted || cpu.Registers.PC != 0x0040 {
+		t.Errorf("Expected wake-up and dispatch to 0x0040, got Halted=%v PC=0x%04X",
+			cpu.Halted, cpu.Registers.PC)
+	}
+}
+
+// illegalOpcodes lists the opcodes that do not exist on the SM83.
+var illegalOpcodes = []uint8{
+	0xD3, 0xDB, 0xDD,
+	0xE3, 0xE4, 0xEB, 0xEC, 0xED,
+	0xF4, 0xFC, 0xFD,
+}
+
+func TestIllegalOpcodesLock(t *testing.T) {
+	for _, opcode := range illegalOpcodes {
+		t.Run(opcodeTable[opcode].Mnemonic, func(t *testing.T) {
+			// Program: <illegal>, INC A
+			cpu := setupCPU([]byte{opcode, 0x3C})
+			cpu.Registers.SP = 0xD000
+			cpu.IME = true
+			cpu.Memory.Write(memory.AddrIE, 0x1F)
+
+			cycles, err := cpu.Step()
+
+			if cycles != 4 {
+				t.Errorf("Expected 4 cycles, got %d", cycles)
+			}
+			if !cpu.Locked {
+				t.Fatal("Expected CPU to be locked")
+			}
+			var illegal *IllegalOpcodeError
+			if !errors.As(err, &illegal) || illegal.Opcode != opcode || illegal.PC != 0x0000 {
+				t.Errorf("Expected IllegalOpcodeError for 0x%02X at 0x0000, got %v", opcode, err)
+			}
+			if !errors.Is(err, ErrLocked) {
+				t.Errorf("Expected the error to match ErrLocked, got %v", err)
+			}
+
+			// Neither further steps nor interrupts get it going again
+			cpu.RequestInterrupt(InterruptVBlank)
+			for i := 0; i < 3; i++ {
+				if _, err := cpu.Step(); err != ErrLocked {
+					t.Errorf("Expected ErrLocked while locked, got %v", err)
+				}
+			}
+			if cpu.Registers.PC != 0x0001 || cpu.Registers.A != 0x00 {
+				t.Errorf("Expected CPU to stay at PC=0x0001 with A=0x00, got PC=0x%04X A=0x%02X",
+					cpu.Registers.PC, cpu.Registers.A)
+			}
+		})
+	}
+}
+
+func TestIllegalOpcodeCallback(t *testing.T) {
+	cpu := setupCPU([]byte{0x00, 0xE3})
+
+	var got []uint8
+	cpu.OnLock = func(cpu *CPU, opcode uint8) {
+		got = append(got, opcode)
+	}
+
+	cpu.Step() // NOP
+	cpu.Step() // Illegal 0xE3
+	cpu.Step() // Already locked - no second callback
+
+	if len(got) != 1 || got[0] != 0xE3 {
+		t.Errorf("Expected a single callback for 0xE3, got %v", got)
+	}
+}
@@ -0,0 +1,30 @@
+package processor
+
+// ============================================================
+// PUSH rr / POP rr - Save and restore register pairs
+// ============================================================
+// PUSH stores a register pair on the stack (SP -= 2), POP loads
+// it back (SP += 2). Routines use them to preserve registers
+// they are about to clobber:
+//
+//	PUSH BC
+//	...       ; use B and C freely
+//	POP BC
+//
+// POP AF is special: the low nibble of F doesn't exist in
+// hardware, so whatever value was on the stack, bits 0-3 of F
+// always read back as 0. SetAF takes care of the masking.
+//
+// Flags: None affected, except POP AF which loads all of them
+// Cycles: 16 for PUSH, 12 for POP
+// Bytes: 1
+
+func opPUSH_BC(cpu *CPU) { cpu.pushWord(cpu.Registers.BC()) }
+func opPUSH_DE(cpu *CPU) { cpu.pushWord(cpu.Registers.DE()) }
+func opPUSH_HL(cpu *CPU) { cpu.pushWord(cpu.Registers.HL()) }
+func opPUSH_AF(cpu *CPU) { cpu.pushWord(cpu.Registers.AF()) }
+
+func opPOP_BC(cpu *CPU) { cpu.Registers.SetBC(cpu.popWord()) }
+func opPOP_DE(cpu *CPU) { cpu.Registers.SetDE(cpu.popWord()) }
+func opPOP_HL(cpu *CPU) { cpu.Registers.SetHL(cpu.popWord()) }
+func opPOP_AF(cpu *CPU) { cpu.Registers.SetAF(cpu.popWord()) }
@@ -0,0 +1,139 @@
+package processor
+
+import "testing"
+
+func TestOpPUSH_rr(t *testing.T) {
+	tests := []struct {
+		opcode uint8
+		pair   pairTarget
+	}{
+		{0xC5, pairBC},
+		{0xD5, pairDE},
+		{0xE5, pairHL},
+		{0xF5, pairTarget{"AF", (*Registers).SetAF, (*Registers).AF}},
+	}
+
+	for _, tt := range tests {
+		t.Run("PUSH "+tt.pair.name, func(t *testing.T) {
+			cpu := setupCPU([]byte{tt.opcode})
+			cpu.Registers.SP = 0xD000
+			tt.pair.set(cpu.Registers, 0x12F0)
+
+			cycles := step(t, cpu)
+
+			if cycles != 16 {
+				t.Errorf("Expected 16 cycles, got %d", cycles)
+			}
+			if cpu.Registers.SP != 0xCFFE {
+				t.Errorf("Expected SP=0xCFFE, got SP=0x%04X", cpu.Registers.SP)
+			}
+			// Little-endian on the stack: low byte at the lower address
+			if lo, hi := cpu.Memory.Read(0xCFFE), cpu.Memory.Read(0xCFFF); lo != 0xF0 || hi != 0x12 {
+				t.Errorf("Expected stack [0xF0 0x12], got [0x%02X 0x%02X]", lo, hi)
+			}
+		})
+	}
+}
+
+func TestOpPOP_rr(t *testing.T) {
+	tests := []struct {
+		opcode uint8
+		pair   pairTarget
+	}{
+		{0xC1, pairBC},
+		{0xD1, pairDE},
+		{0xE1, pairHL},
+	}
+
+	for _, tt := range tests {
+		t.Run("POP "+tt.pair.name, func(t *testing.T) {
+			cpu := setupCPU([]byte{tt.opcode})
+			cpu.Registers.SP = 0xCFFE
+			cpu.Memory.Write(0xCFFE, 0x34)
+			cpu.Memory.Write(0xCFFF, 0x12)
+
+			cycles := step(t, cpu)
+
+			if cycles != 12 {
+				t.Errorf("Expected 12 cycles, got %d", cycles)
+			}
+			if got := tt.pair.get(cpu.Registers); got != 0x1234 {
+				t.Errorf("Expected %s=0x1234, got 0x%04X", tt.pair.name, got)
+			}
+			if cpu.Registers.SP != 0xD000 {
+				t.Errorf("Expected SP=0xD000, got SP=0x%04X", cpu.Registers.SP)
+			}
+		})
+	}
+}
+
+func TestOpPOP_AF_MasksLowNibble(t *testing.T) {
+	// The stack holds F=0xFF, but only the top 4 bits exist
+	cpu := setupCPU([]byte{0xF1})
+	cpu.Registers.SP = 0xCFFE
+	cpu.Memory.Write(0xCFFE, 0xFF)
+	cpu.Memory.Write(0xCFFF, 0x42)
+
+	cpu.Step()
+
+	if cpu.Registers.A != 0x42 {
+		t.Errorf("Expected A=0x42, got A=0x%02X", cpu.Registers.A)
+	}
+	if cpu.Registers.F != 0xF0 {
+		t.Errorf("Expected F=0xF0 (masked), got F=0x%02X", cpu.Registers.F)
+	}
+}
+
+func TestPushPopRoundTrip(t *testing.T) {
+	// Program: PUSH BC; POP DE (copies BC into DE through the stack)
+	cpu := setupCPU([]byte{0xC5, 0xD1})
+	cpu.Registers.SetBC(0xBEEF)
+	sp := cpu.Registers.SP
+
+	cpu.Step() // PUSH BC
+	cpu.Step() // POP DE
+
+	if cpu.Registers.DE() != 0xBEEF {
+		t.Errorf("Expected DE=0xBEEF, got DE=0x%04X", cpu.Registers.DE())
+	}
+	if cpu.Registers.SP != sp {
+		t.Errorf("SP should be restored to 0x%04X, got 0x%04X", sp, cpu.Registers.SP)
+	}
+}
+
+func TestPOP_AF_AllLowNibbles(t *testing.T) {
+	// Every possible F byte on the stack must come back with bits 0-3
+	// cleared, and PUSH AF must push the masked value, not the original
+	for f := range 256 {
+		cpu := setupCPU([]byte{0xF1, 0xF5}) // POP AF; PUSH AF
+		cpu.Registers.SP = 0xCFFE
+		cpu.Memory.Write(0xCFFE, uint8(f))
+		cpu.Memory.Write(0xCFFF, 0x99)
+
+		cpu.Step() // POP AF
+		if cpu.Registers.F != uint8(f)&0xF0 {
+			t.Fatalf("POP AF with F=0x%02X: expected F=0x%02X, got 0x%02X", f, f&0xF0, cpu.Registers.F)
+		}
+
+		cpu.Step() // PUSH AF
+		if pushed := cpu.Memory.Read(0xCFFE); pushed != uint8(f)&0xF0 {
+			t.Fatalf("PUSH AF after POP AF with F=0x%02X: pushed 0x%02X", f, pushed)
+		}
+		if cpu.Memory.Read(0xCFFF) != 0x99 {
+			t.Fatalf("PUSH AF changed A on the stack: 0x%02X", cpu.Memory.Read(0xCFFF))
+		}
+	}
+}
+
+func TestPOP_AF_FlagsReadable(t *testing.T) {
+	// POP AF is how programs load arbitrary flag states. The flag
+	// getters must see exactly the bits that were popped.
+	cpu := setupCPU([]byte{0xF1})
+	cpu.Registers.SP = 0xCFFE
+	cpu.Memory.Write(0xCFFE, FlagZ|FlagC|0x0F)
+	cpu.Memory.Write(0xCFFF, 0x00)
+
+	cpu.Step()
+
+	checkFlags(t, cpu.Registers, true, false, false, true)
+}
@@ -1,6 +1,12 @@
 package processor
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/antoniosarro/yagbc/internal/core/gb/memory"
@@ -13,12 +19,22 @@ func setupCPU(program []byte) *CPU {
 	return NewCPU(mem)
 }
 
+// step runs one Step and fails the test if it returns an error.
+func step(t *testing.T, cpu *CPU) int {
+	t.Helper()
+	cycles, err := cpu.Step()
+	if err != nil {
+		t.Fatalf("Step failed: %v", err)
+	}
+	return cycles
+}
+
 func TestOpNOP(t *testing.T) {
 	// Program: NOP
 	cpu := setupCPU([]byte{0x00})
 
 	initialPC := cpu.Registers.PC
-	cycles := cpu.Step()
+	cycles := step(t, cpu)
 
 	// NOP should take 4 cycles and advance PC by 1
 	if cycles != 4 {
@@ -33,7 +49,7 @@ func TestOpLD_A_n(t *testing.T) {
 	// Program: LD A, 0x42
 	cpu := setupCPU([]byte{0x3E, 0x42})
 
-	cycles := cpu.Step()
+	cycles := step(t, cpu)
 
 	if cycles != 8 {
 		t.Errorf("Expected 8 cycles, got %d", cycles)
@@ -183,7 +199,7 @@ func TestOpJP_nn(t *testing.T) {
 	// Bytes: 0xC3, 0x50, 0x01 (little-endian!)
 	cpu := setupCPU([]byte{0xC3, 0x50, 0x01})
 
-	cycles := cpu.Step()
+	cycles := step(t, cpu)
 
 	if cycles != 16 {
 		t.Errorf("Expected 16 cycles, got %d", cycles)
@@ -192,3 +208,107 @@ func TestOpJP_nn(t *testing.T) {
 		t.Errorf("Expected PC=0x0150, got PC=0x%04X", cpu.Registers.PC)
 	}
 }
+
+// unknownOpcode returns an opcode that has no implementation yet,
+// skipping the test once the whole table is filled in.
+func unknownOpcode(t *testing.T) uint8 {
+	t.Helper()
+	for i, op := range opcodeTable {
+		if strings.HasPrefix(op.Mnemonic, "UNKNOWN_") {
+			return uint8(i)
+		}
+	}
+	t.Skip("every opcode is implemented")
+	return 0
+}
+
+func TestUnknownOpcodeIgnore(t *testing.T) {
+	opcode := unknownOpcode(t)
+	cpu := setupCPU([]byte{opcode})
+
+	cycles, err := cpu.Step()
+
+	if cycles != 4 || cpu.Registers.PC != 0x0001 {
+		t.Errorf("Expected NOP behavior (4 cycles, PC=0x0001), got %d cycles, PC=0x%04X",
+			cycles, cpu.Registers.PC)
+	}
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestUnknownOpcodeError(t *testing.T) {
+	opcode := unknownOpcode(t)
+	// Program: NOP, <unknown>
+	cpu := setupCPU([]byte{0x00, opcode})
+	cpu.UnknownOpcodes = UnknownOpcodeReturnError
+
+	step(t, cpu)
+
+	for i := 0; i < 2; i++ {
+		_, err := cpu.Step()
+
+		var unknown *UnknownOpcodeError
+		if !errors.As(err, &unknown) {
+			t.Fatalf("Expected *UnknownOpcodeError, got %v", err)
+		}
+		if unknown.Opcode != opcode || unknown.PC != 0x0001 {
+			t.Errorf("Expected opcode 0x%02X at 0x0001, got 0x%02X at 0x%04X",
+				opcode, unknown.Opcode, unknown.PC)
+		}
+		// PC stays parked on the offending opcode
+		if cpu.Registers.PC != 0x0001 {
+			t.Errorf("Expected PC=0x0001, got PC=0x%04X", cpu.Registers.PC)
+		}
+	}
+}
+
+func TestUnknownOpcodePanic(t *testing.T) {
+	opcode := unknownOpcode(t)
+	cpu := setupCPU([]byte{opcode})
+	cpu.UnknownOpcodes = UnknownOpcodePanic
+
+	defer func() {
+		if _, ok := recover().(*UnknownOpcodeError); !ok {
+			t.Error("Expected a panic with *UnknownOpcodeError")
+		}
+	}()
+	cpu.Step()
+}
+
+func TestUnknownOpcodeLog(t *testing.T) {
+	opcode := unknownOpcode(t)
+	cpu := setupCPU([]byte{opcode})
+	cpu.UnknownOpcodes = UnknownOpcodeLog
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	cpu.Step()
+
+	want := fmt.Sprintf("unknown opcode 0x%02X at 0x0000", opcode)
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("Expected log to contain %q, got %q", want, buf.String())
+	}
+	if cpu.Registers.PC != 0x0001 {
+		t.Errorf("Expected execution to continue (PC=0x0001), got PC=0x%04X", cpu.Registers.PC)
+	}
+}
+
+func TestUnknownOpcodeCallback(t *testing.T) {
+	opcode := unknownOpcode(t)
+	cpu := setupCPU([]byte{0x00, opcode})
+
+	var got []*UnknownOpcodeError
+	cpu.OnUnknownOpcode = func(cpu *CPU, err *UnknownOpcodeError) {
+		got = append(got, err)
+	}
+
+	cpu.Step()
+	cpu.Step()
+
+	if len(got) != 1 || got[0].Opcode != opcode || got[0].PC != 0x0001 {
+		t.Errorf("Expected one callback for 0x%02X at 0x0001, got %v", opcode, got)
+	}
+}
@@ -1,17 +1,108 @@
 package processor
 
-import "github.com/antoniosarro/yagbc/internal/core/gb/memory"
+import (
+	"errors"
+	"io"
+
+	"github.com/antoniosarro/yagbc/internal/core/gb/memory"
+)
+
+// ErrLocked is returned by Step while the CPU is locked up after
+// executing an illegal opcode. Only a reset recovers from it.
+var ErrLocked = errors.New("processor: CPU is locked up")
 
 // CPU represents the Sharp SM83 processor used in the Game Boy.
 type CPU struct {
 	Registers *Registers    // CPU registers (A, B, C, D, E, F, H, L, SP, PC)
 	Memory    memory.Memory // Memory interface for reading/writing
 	Halted    bool          // Is the CPU halted? (from HALT instruction)
+	Stopped   bool          // Is the CPU stopped? (from STOP instruction)
+	IME       bool          // Interrupt Master Enable (set by EI/RETI, cleared by DI)
+	Locked    bool          // Is the CPU locked up? (from an illegal opcode)
+
+	// OnStop, if set, is called when STOP executes. Returning true means
+	// STOP was consumed by something else (e.g. the CGB KEY1 speed
+	// switch) and the CPU should carry on instead of entering stop mode.
+	OnStop func(cpu *CPU) bool
+
+	// Tick, if set, puts the CPU in M-cycle mode: it is called once for
+	// every M-cycle (4 T-cycles) as the instruction runs, right before
+	// that cycle's memory access (see mcycle.go).
+	Tick func()
+
+	// OnLock, if set, is called with the offending opcode when an
+	// illegal opcode locks up the CPU.
+	OnLock func(cpu *CPU, opcode uint8)
+
+	// UnknownOpcodes selects how unimplemented opcodes are handled, and
+	// OnUnknownOpcode, if set, is called whenever one is hit.
+	UnknownOpcodes  UnknownOpcodeMode
+	OnUnknownOpcode func(cpu *CPU, err *UnknownOpcodeError)
+
+	// OnBreakpoint and OnDebugMessage, if set, make LD B, B and LD D, D
+	// the breakpoint and message traps homebrew uses (see debugtrap.go).
+	OnBreakpoint   func(cpu *CPU)
+	OnDebugMessage func(cpu *CPU, msg string)
+
+	// OnExplain, if set, is called after every executed instruction
+	// with a description of what it did (see explain.go).
+	OnExplain func(cpu *CPU, e *Explanation)
+
+	// OnBeforeInstruction and OnAfterInstruction, if set, are called
+	// around every executed instruction, for debuggers, tracers and
+	// cheat engines. OnBeforeInstruction runs before the opcode is
+	// fetched, so it may still change registers or memory.
+	OnBeforeInstruction func(cpu *CPU, e InstructionEvent)
+	OnAfterInstruction  func(cpu *CPU, e InstructionEvent)
+
+	// Trace, if set, receives a Gameboy Doctor log line before every
+	// instruction (see trace.go). Write errors are ignored.
+	Trace io.Writer
+
+	// err holds the error raised by the instruction being executed,
+	// returned by Step.
+	err error
+
+	// explanation is the record of the instruction being executed,
+	// while OnExplain is set.
+	explanation *Explanation
+
+	// callStack is the shadow call stack (see callstack.go).
+	callStack []CallFrame
+
+	// ticked counts the T-cycles already reported through Tick during
+	// the current Step.
+	ticked int
+
+	// instructionPC and opcode record where the instruction being
+	// executed was fetched from, and its opcode byte.
+	instructionPC uint16
+	opcode        uint8
+
+	// eiDelay counts down the instructions left before a pending EI
+	// takes effect (0 = nothing pending).
+	eiDelay int
+
+	// haltBug is set when HALT is executed with IME=0 and an interrupt
+	// already pending: the next opcode fetch fails to increment PC.
+	haltBug bool
+
+	// branchTaken is set by a conditional instruction whose condition
+	// held, selecting its CyclesTaken timing (see takeBranch).
+	branchTaken bool
 
 	// Debug/stats
 	TotalCycles uint64 // Total cycles executed (for debugging)
 }
 
+// InstructionEvent describes an executed instruction to the
+// OnBeforeInstruction and OnAfterInstruction hooks.
+type InstructionEvent struct {
+	PC     uint16 // Address the instruction was fetched from
+	Opcode uint8  // Opcode byte (0xCB for prefixed instructions)
+	Cycles int    // Cycles taken (0 for OnBeforeInstruction)
+}
+
 // NewCPU creates a new CPU instance connected to the given memory.
 func NewCPU(mem memory.Memory) *CPU {
 	return &CPU{
@@ -23,31 +114,138 @@ func NewCPU(mem memory.Memory) *CPU {
 }
 
 // Step executes one CPU instruction (fetch-decode-execute cycle).
-// Returns the number of cycles the instruction took.
-func (cpu *CPU) Step() int {
-	// If halted, do nothing (but still consume cycles)
+// Returns the number of cycles the instruction took, and an error if
+// the CPU cannot carry on normally:
+//   - *IllegalOpcodeError when an illegal opcode locks up the CPU,
+//     then ErrLocked on every later Step
+//   - *UnknownOpcodeError for unimplemented opcodes, in
+//     UnknownOpcodeReturnError mode
+//
+// The cycles are still spent when an error is returned.
+func (cpu *CPU) Step() (int, error) {
+	cpu.err = nil
+	cpu.ticked = 0
+
+	// If locked up, nothing but a reset gets the CPU going again
+	if cpu.Locked {
+		return cpu.finish(4), ErrLocked
+	}
+
+	// If stopped, do nothing until something clears Stopped
+	// (a joypad press on real hardware)
+	if cpu.Stopped {
+		return cpu.finish(4), nil
+	}
+
+	// If halted, wait for an interrupt (but still consume cycles)
 	if cpu.Halted {
-		return 4 // NOP-equivalent
+		if cpu.pendingInterrupts() == 0 {
+			return cpu.finish(4), nil // NOP-equivalent
+		}
+		// Any pending interrupt wakes the CPU, even with IME=0
+		cpu.Halted = false
+
+		// Waking up into an interrupt costs one extra M-cycle
+		if cpu.IME {
+			cpu.idle()
+			return cpu.finish(cpu.serviceInterrupt() + 4), nil
+		}
+	}
+
+	// INTERRUPTS: Service the highest-priority pending interrupt instead
+	// of the next instruction, if IME allows it
+	if cycles := cpu.serviceInterrupt(); cycles > 0 {
+		return cpu.finish(cycles), nil
+	}
+
+	if cpu.OnBeforeInstruction != nil {
+		// Peek without ticking: the fetch below is the real access
+		pc := cpu.Registers.PC
+		cpu.OnBeforeInstruction(cpu, InstructionEvent{PC: pc, Opcode: cpu.Memory.Read(pc)})
+	}
+	if cpu.Trace != nil {
+		cpu.traceState()
+	}
+	if cpu.OnExplain != nil {
+		cpu.beginExplanation()
 	}
 
 	// FETCH: Read the opcode at PC
+	cpu.instructionPC = cpu.Registers.PC
 	opcode := cpu.fetchByte()
+	cpu.opcode = opcode
 
-	// DECODE & EXECUTE: Look up and execute the instruction
-	instruction := opcodeTable[opcode]
+	// HALT bug: PC was not incremented, so this byte will be read again
+	if cpu.haltBug {
+		cpu.Registers.PC--
+		cpu.haltBug = false
+	}
 
-	// Execute the instruction
-	instruction.Execute(cpu)
+	// DECODE & EXECUTE: Run the instruction and look up its timing
+	cpu.branchTaken = false
+	instruction := cpu.dispatch(opcode)
+	cycles := instruction.Cycles
+	if cpu.branchTaken {
+		cycles = instruction.CyclesTaken
+	}
+
+	// EI takes effect only after the instruction that follows it
+	if cpu.eiDelay > 0 {
+		cpu.eiDelay--
+		if cpu.eiDelay == 0 {
+			cpu.IME = true
+		}
+	}
+
+	if cpu.explanation != nil {
+		cpu.finishExplanation(cycles)
+	}
+
+	cpu.finish(cycles)
+	if cpu.OnAfterInstruction != nil {
+		cpu.OnAfterInstruction(cpu, InstructionEvent{PC: cpu.instructionPC, Opcode: opcode, Cycles: cycles})
+	}
+	return cycles, cpu.err
+}
+
+// finish wraps up a Step that took the given number of cycles. In
+// M-cycle mode, any cycles the instruction has not ticked through
+// memory accesses are internal cycles, ticked now. Returns cycles.
+func (cpu *CPU) finish(cycles int) int {
+	for cpu.Tick != nil && cpu.ticked < cycles {
+		cpu.idle()
+	}
 
 	// Track total cycles (for debugging/stats)
-	cpu.TotalCycles += uint64(instruction.Cycles)
+	cpu.TotalCycles += uint64(cycles)
+
+	return cycles
+}
 
-	return instruction.Cycles
+// dispatch executes the instruction for an opcode and returns its
+// table entry. The 0xCB prefix selects an instruction from cbTable,
+// fetching the byte that follows it.
+//
+// The instruction runs through the generated execute/executeCB
+// switches rather than its Execute field: a switch of direct calls
+// lets the compiler inline the handlers instead of making an
+// indirect call per instruction (see BenchmarkStep).
+func (cpu *CPU) dispatch(opcode uint8) *Opcode {
+	if opcode == 0xCB {
+		opcode = cpu.fetchByte()
+		cpu.executeCB(opcode)
+		return &cbTable[opcode]
+	}
+	cpu.execute(opcode)
+	return &opcodeTable[opcode]
 }
 
 // fetchByte reads the byte at PC and increments PC.
 // This is used to read the opcode and any immediate operands.
+// Fetches take an M-cycle like any read, but are not data accesses,
+// so the value does not go through read.
 func (cpu *CPU) fetchByte() uint8 {
+	cpu.idle()
 	value := cpu.Memory.Read(cpu.Registers.PC)
 	cpu.Registers.PC++
 	return value
@@ -61,3 +259,27 @@ func (cpu *CPU) fetchWord() uint16 {
 	high := cpu.fetchByte() // Read high byte second
 	return uint16(high)<<8 | uint16(low)
 }
+
+// pushWord pushes a 16-bit value onto the stack.
+// The stack grows downward: SP is decremented before each byte is
+// written, high byte first, so the low byte ends up at the lower
+// address (little-endian, like every other 16-bit value in memory).
+// The first SP decrement costs an internal M-cycle before the writes.
+func (cpu *CPU) pushWord(value uint16) {
+	cpu.idle()
+	cpu.Registers.SP--
+	cpu.write(cpu.Registers.SP, uint8(value>>8)) // High byte
+	cpu.Registers.SP--
+	cpu.write(cpu.Registers.SP, uint8(value)) // Low byte
+}
+
+// popWord pops a 16-bit value off the stack, undoing pushWord:
+// the low byte is read first, then the high byte, and SP moves
+// back up by 2.
+func (cpu *CPU) popWord() uint16 {
+	low := cpu.read(cpu.Registers.SP)
+	cpu.Registers.SP++
+	high := cpu.read(cpu.Registers.SP)
+	cpu.Registers.SP++
+	return uint16(high)<<8 | uint16(low)
+}
@@ -39,7 +39,9 @@ const (
 
 // NewRegisters creates a Registers instance with Game Boy power-on values.
 //
-// Initial register state after boot ROM (which we're skipping):
+// The registers start zeroed, with SP at the top of memory and PC at
+// 0x0000, where a boot ROM (or a test program) begins. CPU.Reset sets
+// the values the boot ROM leaves behind instead, e.g. on a DMG:
 //
 //	AF = 0x01B0  (A=0x01, flags set to 0xB0)
 //	BC = 0x0013
@@ -47,8 +49,6 @@ const (
 //	HL = 0x014D
 //	SP = 0xFFFE  (top of memory)
 //	PC = 0x0100  (where game code starts)
-//
-// For now, we'll use simpler defaults and set PC=0x0000.
 func NewRegisters() *Registers {
 	return &Registers{
 		A:  0x00,
@@ -0,0 +1,75 @@
+package processor
+
+// Model identifies a Game Boy hardware revision. The boot ROMs of
+// the different models leave different values in the registers,
+// which games use to detect what they run on (e.g. A=0x11 on a CGB).
+type Model int
+
+const (
+	ModelDMG Model = iota // Original Game Boy
+	ModelMGB              // Game Boy Pocket
+	ModelCGB              // Game Boy Color, running a CGB game
+)
+
+// String returns the model's usual abbreviation.
+func (m Model) String() string {
+	switch m {
+	case ModelDMG:
+		return "DMG"
+	case ModelMGB:
+		return "MGB"
+	case ModelCGB:
+		return "CGB"
+	}
+	return "unknown model"
+}
+
+// ============================================================
+// Reset - Start as if the boot ROM had just run
+// ============================================================
+// Puts the CPU in the state the model's boot ROM leaves it in when
+// it hands over to the cartridge, with PC at the entry point 0x0100
+// (https://gbdev.io/pandocs/Power_Up_Sequence.html):
+//
+//	       AF      BC      DE      HL      SP
+//	DMG  0x01B0  0x0013  0x00D8  0x014D  0xFFFE
+//	MGB  0xFFB0  0x0013  0x00D8  0x014D  0xFFFE
+//	CGB  0x1180  0x0000  0xFF56  0x000D  0xFFFE
+//
+// On DMG and MGB, the H and C flags are only set if the header
+// checksum at 0x014D is not 0, so Reset reads it from memory: load
+// the cartridge first.
+//
+// Everything else (IME, HALT/STOP, a pending EI, the cycle count and
+// the call stack) is cleared. To run a boot ROM instead, skip Reset:
+// NewCPU starts at 0x0000, where the boot ROM is mapped.
+func (cpu *CPU) Reset(model Model) {
+	r := Registers{SP: 0xFFFE, PC: 0x0100}
+	switch model {
+	case ModelCGB:
+		r.A, r.F = 0x11, FlagZ
+		r.D, r.E = 0xFF, 0x56
+		r.L = 0x0D
+	default:
+		r.A, r.F = 0x01, FlagZ
+		if model == ModelMGB {
+			r.A = 0xFF
+		}
+		if cpu.Memory.Read(0x014D) != 0 {
+			r.F |= FlagH | FlagC
+		}
+		r.C = 0x13
+		r.E = 0xD8
+		r.H, r.L = 0x01, 0x4D
+	}
+	*cpu.Registers = r
+
+	cpu.IME = false
+	cpu.Halted = false
+	cpu.Stopped = false
+	cpu.Locked = false
+	cpu.eiDelay = 0
+	cpu.haltBug = false
+	cpu.callStack = nil
+	cpu.TotalCycles = 0
+}
@@ -0,0 +1,47 @@
+package processor
+
+import "testing"
+
+func TestReset(t *testing.T) {
+	tests := []struct {
+		model    Model
+		checksum uint8
+		want     Registers
+	}{
+		{ModelDMG, 0x66, Registers{A: 0x01, F: 0xB0, C: 0x13, E: 0xD8, H: 0x01, L: 0x4D, SP: 0xFFFE, PC: 0x0100}},
+		{ModelDMG, 0x00, Registers{A: 0x01, F: 0x80, C: 0x13, E: 0xD8, H: 0x01, L: 0x4D, SP: 0xFFFE, PC: 0x0100}},
+		{ModelMGB, 0x66, Registers{A: 0xFF, F: 0xB0, C: 0x13, E: 0xD8, H: 0x01, L: 0x4D, SP: 0xFFFE, PC: 0x0100}},
+		{ModelCGB, 0x66, Registers{A: 0x11, F: 0x80, D: 0xFF, E: 0x56, L: 0x0D, SP: 0xFFFE, PC: 0x0100}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.model.String(), func(t *testing.T) {
+			rom := make([]byte, 0x0150)
+			rom[0x014D] = tt.checksum
+			cpu := setupCPU(rom)
+
+			cpu.Reset(tt.model)
+
+			if *cpu.Registers != tt.want {
+				t.Errorf("Expected %+v, got %+v", tt.want, *cpu.Registers)
+			}
+		})
+	}
+}
+
+func TestResetClearsState(t *testing.T) {
+	// Program: CALL 0x0000 (a frame on the call stack)
+	cpu := setupCPU([]byte{0xCD, 0x00, 0x00})
+	step(t, cpu)
+	cpu.IME, cpu.Halted, cpu.Stopped, cpu.Locked = true, true, true, true
+	cpu.eiDelay, cpu.haltBug = 1, true
+
+	cpu.Reset(ModelDMG)
+
+	if cpu.IME || cpu.Halted || cpu.Stopped || cpu.Locked || cpu.eiDelay != 0 || cpu.haltBug {
+		t.Errorf("Expected IME, HALT, STOP, lock-up and EI/HALT state to be cleared, got %+v", cpu)
+	}
+	if cpu.TotalCycles != 0 || cpu.CallDepth() != 0 {
+		t.Errorf("Expected no cycles and no calls, got %d cycles, %d calls", cpu.TotalCycles, cpu.CallDepth())
+	}
+}
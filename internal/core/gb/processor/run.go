@@ -0,0 +1,46 @@
+package processor
+
+// CyclesPerFrame is the number of CPU cycles in one 59.7 Hz frame:
+// 154 scanlines of 456 cycles each.
+const CyclesPerFrame = 70224
+
+// RunCycles executes instructions until at least n cycles have
+// elapsed. Instructions are never split, so the run can overshoot n
+// by up to one instruction. Returns the cycles actually consumed,
+// stopping early at the first error from Step.
+func (cpu *CPU) RunCycles(n int) (int, error) {
+	total := 0
+	for total < n {
+		cycles, err := cpu.Step()
+		total += cycles
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// RunUntil executes instructions until done returns true, checking
+// it before each Step. Returns the cycles consumed, stopping early at
+// the first error from Step.
+//
+// Example:
+//
+//	// Run until the program halts
+//	cycles, err := cpu.RunUntil(func(cpu *processor.CPU) bool { return cpu.Halted })
+func (cpu *CPU) RunUntil(done func(cpu *CPU) bool) (int, error) {
+	total := 0
+	for !done(cpu) {
+		cycles, err := cpu.Step()
+		total += cycles
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// RunFrame executes one frame's worth of cycles (see RunCycles).
+func (cpu *CPU) RunFrame() (int, error) {
+	return cpu.RunCycles(CyclesPerFrame)
+}
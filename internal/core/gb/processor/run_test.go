@@ -0,0 +1,70 @@
+package processor
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRunCycles(t *testing.T) {
+	// Program: NOPs, then LD BC, nn at 0x0002
+	cpu := setupCPU([]byte{0x00, 0x00, 0x01, 0x34, 0x12})
+
+	// 4 + 4 + 12: the LD is not split to stop at 10 cycles
+	cycles, err := cpu.RunCycles(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cycles != 20 || cpu.Registers.PC != 0x0005 {
+		t.Errorf("Expected 20 cycles and PC=0x0005, got %d cycles and PC=0x%04X", cycles, cpu.Registers.PC)
+	}
+}
+
+func TestRunCyclesStopsOnError(t *testing.T) {
+	// Program: NOP, illegal opcode, NOP
+	cpu := setupCPU([]byte{0x00, 0xD3, 0x00})
+
+	cycles, err := cpu.RunCycles(100)
+	var illegal *IllegalOpcodeError
+	if !errors.As(err, &illegal) {
+		t.Fatalf("Expected an *IllegalOpcodeError, got %v", err)
+	}
+	if cycles != 8 {
+		t.Errorf("Expected 8 cycles before stopping, got %d", cycles)
+	}
+}
+
+func TestRunUntil(t *testing.T) {
+	// Program: LD B, 3; DEC B; JR NZ, -3; HALT
+	cpu := setupCPU([]byte{0x06, 0x03, 0x05, 0x20, 0xFD, 0x76})
+
+	cycles, err := cpu.RunUntil(func(cpu *CPU) bool { return cpu.Halted })
+	if err != nil {
+		t.Fatal(err)
+	}
+	// LD 8, 3 x DEC 4, 2 x JR taken 12, JR not taken 8, HALT 4
+	if want := 8 + 3*4 + 2*12 + 8 + 4; cycles != want {
+		t.Errorf("Expected %d cycles, got %d", want, cycles)
+	}
+	if cpu.Registers.B != 0 {
+		t.Errorf("Expected B=0, got %d", cpu.Registers.B)
+	}
+
+	// Already done: nothing runs
+	if cycles, _ := cpu.RunUntil(func(cpu *CPU) bool { return true }); cycles != 0 {
+		t.Errorf("Expected 0 cycles, got %d", cycles)
+	}
+}
+
+func TestRunFrame(t *testing.T) {
+	// Program: JR -2 (spin forever, 12 cycles per loop)
+	cpu := setupCPU([]byte{0x18, 0xFE})
+
+	cycles, err := cpu.RunFrame()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 70224 is a multiple of 12, so the frame ends exactly
+	if cycles != CyclesPerFrame || cpu.TotalCycles != CyclesPerFrame {
+		t.Errorf("Expected %d cycles, got %d (total %d)", CyclesPerFrame, cycles, cpu.TotalCycles)
+	}
+}
@@ -0,0 +1,113 @@
+package processor
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// ============================================================
+// Snapshots
+// ============================================================
+// Snapshot captures everything needed to resume the CPU exactly
+// where it was: the registers, the CPU state flags, the cycle
+// counter and the internal state that is invisible to programs
+// (a pending EI, an armed HALT bug). It is the building block for
+// save states and test fixtures.
+//
+// The blob is little-endian and starts with a magic string and a
+// format version, so older snapshots can be recognized (and
+// migrated) if the layout ever changes:
+//
+//	"SM83" | version (1 byte) | state (see snapshotV1)
+//
+// Hooks (Tick, OnStop, ...) and the memory are not part of the CPU
+// snapshot: the caller keeps them, and saves memory separately.
+
+// snapshotMagic identifies a CPU snapshot.
+const snapshotMagic = "SM83"
+
+// SnapshotVersion is the format version written by Snapshot.
+const SnapshotVersion = 1
+
+// ErrBadSnapshot is returned by Restore for data that is not a valid
+// CPU snapshot.
+var ErrBadSnapshot = errors.New("processor: invalid CPU snapshot")
+
+// snapshotV1 is the state layout of version 1 snapshots.
+type snapshotV1 struct {
+	A, F, B, C, D, E, H, L uint8
+	SP, PC                 uint16
+	State                  uint8 // Bit field, see the snapshotState* constants
+	EIDelay                uint8
+	TotalCycles            uint64
+}
+
+// Bits of snapshotV1.State.
+const (
+	snapshotStateIME uint8 = 1 << iota
+	snapshotStateHalted
+	snapshotStateStopped
+	snapshotStateLocked
+	snapshotStateHaltBug
+)
+
+// flagBit returns bit if cond holds, 0 otherwise.
+func flagBit(cond bool, bit uint8) uint8 {
+	if cond {
+		return bit
+	}
+	return 0
+}
+
+// Snapshot returns the CPU state as a versioned binary blob, which
+// Restore loads back.
+func (cpu *CPU) Snapshot() []byte {
+	r := cpu.Registers
+	state := snapshotV1{
+		A: r.A, F: r.F, B: r.B, C: r.C, D: r.D, E: r.E, H: r.H, L: r.L,
+		SP: r.SP, PC: r.PC,
+		State: flagBit(cpu.IME, snapshotStateIME) |
+			flagBit(cpu.Halted, snapshotStateHalted) |
+			flagBit(cpu.Stopped, snapshotStateStopped) |
+			flagBit(cpu.Locked, snapshotStateLocked) |
+			flagBit(cpu.haltBug, snapshotStateHaltBug),
+		EIDelay:     uint8(cpu.eiDelay),
+		TotalCycles: cpu.TotalCycles,
+	}
+
+	data := append([]byte(snapshotMagic), SnapshotVersion)
+	data, _ = binary.Append(data, binary.LittleEndian, &state) // Fixed-size, cannot fail
+	return data
+}
+
+// Restore loads a CPU state produced by Snapshot. On error the CPU
+// is left unchanged.
+func (cpu *CPU) Restore(data []byte) error {
+	header := len(snapshotMagic) + 1
+	if len(data) < header || string(data[:len(snapshotMagic)]) != snapshotMagic {
+		return ErrBadSnapshot
+	}
+	if version := data[len(snapshotMagic)]; version != SnapshotVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrBadSnapshot, version)
+	}
+
+	var state snapshotV1
+	if len(data)-header != binary.Size(state) {
+		return fmt.Errorf("%w: expected %d bytes of state, got %d", ErrBadSnapshot, binary.Size(state), len(data)-header)
+	}
+	binary.Decode(data[header:], binary.LittleEndian, &state) // Size checked above
+
+	r := cpu.Registers
+	r.A, r.F, r.B, r.C, r.D, r.E, r.H, r.L = state.A, state.F, state.B, state.C, state.D, state.E, state.H, state.L
+	r.SP, r.PC = state.SP, state.PC
+	cpu.IME = state.State&snapshotStateIME != 0
+	cpu.Halted = state.State&snapshotStateHalted != 0
+	cpu.Stopped = state.State&snapshotStateStopped != 0
+	cpu.Locked = state.State&snapshotStateLocked != 0
+	cpu.haltBug = state.State&snapshotStateHaltBug != 0
+	cpu.eiDelay = int(state.EIDelay)
+	cpu.TotalCycles = state.TotalCycles
+	cpu.callStack = nil // Calls in progress are not part of a snapshot
+	return nil
+}
@@ -0,0 +1,82 @@
+package processor
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	// Program: EI, NOP
+	cpu := setupCPU([]byte{0xFB, 0x00})
+	cpu.Registers.SetBC(0x1234)
+	cpu.Registers.SetHL(0xC0DE)
+	cpu.Registers.F = FlagZ | FlagC
+	cpu.Registers.SP = 0xD000
+	step(t, cpu) // EI: IME becomes 1 after the next instruction
+	cpu.haltBug = true
+
+	snapshot := cpu.Snapshot()
+
+	restored := setupCPU([]byte{0xFB, 0x00})
+	if err := restored.Restore(snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if *restored.Registers != *cpu.Registers {
+		t.Errorf("Registers: expected %+v, got %+v", *cpu.Registers, *restored.Registers)
+	}
+	if restored.eiDelay != cpu.eiDelay || !restored.haltBug || restored.IME || restored.TotalCycles != 4 {
+		t.Errorf("State not restored: eiDelay=%d haltBug=%v IME=%v TotalCycles=%d",
+			restored.eiDelay, restored.haltBug, restored.IME, restored.TotalCycles)
+	}
+
+	// The pending EI still takes effect after the next instruction
+	restored.haltBug = false
+	step(t, restored) // NOP
+	if !restored.IME {
+		t.Error("Expected the pending EI to survive the snapshot")
+	}
+}
+
+func TestSnapshotFlags(t *testing.T) {
+	cpu := setupCPU(nil)
+	cpu.IME, cpu.Halted, cpu.Stopped, cpu.Locked = true, true, true, true
+
+	restored := setupCPU(nil)
+	if err := restored.Restore(cpu.Snapshot()); err != nil {
+		t.Fatal(err)
+	}
+	if !restored.IME || !restored.Halted || !restored.Stopped || !restored.Locked {
+		t.Errorf("Expected IME, Halted, Stopped and Locked, got %v %v %v %v",
+			restored.IME, restored.Halted, restored.Stopped, restored.Locked)
+	}
+}
+
+func TestRestoreInvalid(t *testing.T) {
+	valid := setupCPU(nil).Snapshot()
+	future := append([]byte(nil), valid...)
+	future[len(snapshotMagic)] = SnapshotVersion + 1
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"bad magic", append([]byte("GBZ8"), valid[len(snapshotMagic):]...)},
+		{"unknown version", future},
+		{"truncated", valid[:len(valid)-1]},
+		{"trailing data", append(append([]byte(nil), valid...), 0x00)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu := setupCPU(nil)
+			cpu.Registers.A = 0x42
+			if err := cpu.Restore(tt.data); !errors.Is(err, ErrBadSnapshot) {
+				t.Errorf("Expected ErrBadSnapshot, got %v", err)
+			}
+			if cpu.Registers.A != 0x42 {
+				t.Error("Expected the CPU to be left unchanged")
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package processor
+
+import "fmt"
+
+// ============================================================
+// Gameboy Doctor traces
+// ============================================================
+// Setting cpu.Trace logs the CPU state before every instruction,
+// one line each, in the format of the Gameboy Doctor tool
+// (https://github.com/robert/gameboy-doctor):
+//
+//	A:01 F:B0 B:00 C:13 D:00 E:D8 H:01 L:4D SP:FFFE PC:0100 PCMEM:00,C3,13,02
+//
+// PCMEM is the 4 bytes at PC. Diffing such a log against one from
+// a reference emulator points at the first instruction where the
+// two disagree.
+//
+// Gameboy Doctor logs start right after the boot ROM, with PC at
+// 0x0100 and the post-boot register values (see Reset), and expect
+// LY (0xFF44) to read 0x90. Interrupt dispatches and halted Steps
+// execute no instruction and are not logged.
+
+// traceState writes the CPU state line for the instruction about to
+// be fetched at PC. Reading PCMEM goes straight to memory, so the
+// trace does not tick in M-cycle mode.
+func (cpu *CPU) traceState() {
+	r, pc := cpu.Registers, cpu.Registers.PC
+	fmt.Fprintf(cpu.Trace,
+		"A:%02X F:%02X B:%02X C:%02X D:%02X E:%02X H:%02X L:%02X SP:%04X PC:%04X PCMEM:%02X,%02X,%02X,%02X\n",
+		r.A, r.F, r.B, r.C, r.D, r.E, r.H, r.L, r.SP, pc,
+		cpu.Memory.Read(pc), cpu.Memory.Read(pc+1), cpu.Memory.Read(pc+2), cpu.Memory.Read(pc+3))
+}
@@ -0,0 +1,38 @@
+package processor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTrace(t *testing.T) {
+	// Program: LD A, 0x42; INC A; HALT
+	cpu := setupCPU([]byte{0x3E, 0x42, 0x3C, 0x76})
+	cpu.Registers.SetBC(0x0013)
+	var trace strings.Builder
+	cpu.Trace = &trace
+
+	for range 4 {
+		step(t, cpu) // The last Step is halted and not logged
+	}
+
+	want := "A:00 F:00 B:00 C:13 D:00 E:00 H:00 L:00 SP:FFFE PC:0000 PCMEM:3E,42,3C,76\n" +
+		"A:42 F:00 B:00 C:13 D:00 E:00 H:00 L:00 SP:FFFE PC:0002 PCMEM:3C,76,00,00\n" +
+		"A:43 F:00 B:00 C:13 D:00 E:00 H:00 L:00 SP:FFFE PC:0003 PCMEM:76,00,00,00\n"
+	if trace.String() != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, trace.String())
+	}
+}
+
+func TestTraceDoesNotTick(t *testing.T) {
+	// Program: NOP
+	cpu := setupCPU([]byte{0x00})
+	cpu.Trace = &strings.Builder{}
+	ticks := 0
+	cpu.Tick = func() { ticks++ }
+
+	step(t, cpu)
+	if ticks != 1 {
+		t.Errorf("Expected 1 tick for NOP with tracing on, got %d", ticks)
+	}
+}
@@ -0,0 +1,84 @@
+// Package serial implements the Game Boy link port.
+package serial
+
+import (
+	"io"
+
+	"github.com/antoniosarro/yagbc/internal/core/gb/memory"
+)
+
+// Serial port registers.
+const (
+	AddrSB uint16 = 0xFF01 // Serial transfer data
+	AddrSC uint16 = 0xFF02 // Serial transfer control
+)
+
+// SC bits.
+const (
+	scTransfer      uint8 = 0x80 // Bit 7: transfer requested / in progress
+	scInternalClock uint8 = 0x01 // Bit 0: this Game Boy drives the clock
+)
+
+var (
+	registerSB, _ = memory.LookupIORegister(AddrSB)
+	registerSC, _ = memory.LookupIORegister(AddrSC)
+)
+
+// Serial is the link port: SB holds the byte to send, and writing
+// SC with bits 7 and 0 set starts shifting it out on the internal
+// clock, while the other side's byte shifts in.
+//
+// No link cable is emulated. A transfer on the internal clock
+// completes at once: the byte goes to Output, SB reads 0xFF (the
+// line idles high with nothing connected), SC bit 7 clears and the
+// serial interrupt is requested. On the external clock, a transfer
+// waits for a partner that never comes, as on a lone Game Boy.
+//
+// Many test ROMs and homebrew programs print text this way, so
+// Output is a handy debug console.
+type Serial struct {
+	Output io.Writer // Receives every byte sent, if set
+
+	requestInterrupt func()
+	sb, sc           uint8
+}
+
+// New creates a serial port that calls requestInterrupt when a
+// transfer completes.
+func New(requestInterrupt func()) *Serial {
+	return &Serial{requestInterrupt: requestInterrupt, sb: registerSB.Reset, sc: registerSC.Reset}
+}
+
+func (s *Serial) Read(addr uint16) uint8 {
+	switch addr {
+	case AddrSB:
+		return registerSB.Read(s.sb)
+	case AddrSC:
+		return registerSC.Read(s.sc)
+	}
+	return 0xFF
+}
+
+func (s *Serial) Write(addr uint16, val uint8) {
+	switch addr {
+	case AddrSB:
+		s.sb = registerSB.Write(s.sb, val)
+	case AddrSC:
+		s.sc = registerSC.Write(s.sc, val)
+		if s.sc&(scTransfer|scInternalClock) == scTransfer|scInternalClock {
+			s.transfer()
+		}
+	}
+}
+
+// transfer completes a transfer on the internal clock.
+func (s *Serial) transfer() {
+	if s.Output != nil {
+		s.Output.Write([]byte{s.sb}) // Best effort, like a real console
+	}
+	s.sb = 0xFF
+	s.sc &^= scTransfer
+	if s.requestInterrupt != nil {
+		s.requestInterrupt()
+	}
+}
@@ -0,0 +1,51 @@
+package serial
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSerialTransfer(t *testing.T) {
+	var out strings.Builder
+	interrupts := 0
+	s := New(func() { interrupts++ })
+	s.Output = &out
+
+	for _, c := range []byte("Hi") {
+		s.Write(AddrSB, c)
+		s.Write(AddrSC, 0x81)
+	}
+
+	if out.String() != "Hi" {
+		t.Errorf("Expected output %q, got %q", "Hi", out.String())
+	}
+	if interrupts != 2 {
+		t.Errorf("Expected 2 serial interrupts, got %d", interrupts)
+	}
+	// Nothing connected: 0xFF shifted in, transfer bit cleared
+	if got := s.Read(AddrSB); got != 0xFF {
+		t.Errorf("SB: expected 0xFF, got 0x%02X", got)
+	}
+	if got := s.Read(AddrSC); got != 0x7F {
+		t.Errorf("SC: expected 0x7F, got 0x%02X", got)
+	}
+}
+
+func TestSerialExternalClock(t *testing.T) {
+	var out strings.Builder
+	s := New(nil)
+	s.Output = &out
+
+	s.Write(AddrSB, 'A')
+	s.Write(AddrSC, 0x80) // Waits for a partner's clock
+
+	if out.Len() != 0 {
+		t.Errorf("Expected no output, got %q", out.String())
+	}
+	if got := s.Read(AddrSC); got != 0xFE {
+		t.Errorf("SC: expected the transfer still pending (0xFE), got 0x%02X", got)
+	}
+	if got := s.Read(AddrSB); got != 'A' {
+		t.Errorf("SB: expected 'A', got 0x%02X", got)
+	}
+}